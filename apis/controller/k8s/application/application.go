@@ -0,0 +1,141 @@
+package application
+
+import (
+	"github.com/gin-gonic/gin"
+	"soul/apis/dto"
+	"soul/apis/service"
+	"soul/utils/httputil"
+)
+
+// GetApplicationList
+//
+//	@description	获取应用列表
+//	@tags			K8s,Application
+//	@summary		获取应用列表
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			namespace		path	string					false	"Namespace 不填为全部"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回应用列表"
+//	@router			/api/v1/k8s/{clusterName}/application/ [get]
+//	@router			/api/v1/k8s/{clusterName}/application/{namespace} [get]
+func GetApplicationList(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+
+	apps, err := service.K8sApplication.GetApplicationList(clusterName, namespace)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, map[string]any{"total": len(apps), "items": apps}, "获取成功")
+}
+
+// GetApplicationByName
+//
+//	@description	获取应用详情, 包含应用下所有Deployment、Service、Ingress的状态
+//	@tags			K8s,Application
+//	@summary		获取应用详情
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			namespace		path	string					true	"Namespace"
+//	@param			appName			path	string					true	"应用名称"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回应用详情"
+//	@router			/api/v1/k8s/{clusterName}/application/{namespace}/{appName} [get]
+func GetApplicationByName(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "appName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+	name := c.Param("appName")
+
+	app, err := service.K8sApplication.GetApplicationByName(clusterName, namespace, name)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, app, "获取成功")
+}
+
+// CreateApplication
+//
+//	@description	创建应用, 同时创建同名的Deployment、根据容器端口生成的Service和可选的Ingress
+//	@tags			K8s,Application
+//	@summary		创建应用
+//	@Accept			json
+//	@produce		json
+//	@param			clusterName		path	string						true	"Cluster Name"
+//	@Param			Authorization	header	string						true	"Authorization token"
+//	@param			data			body	dto.K8sApplicationCreate	true	"应用信息"
+//	@success		200				object	httputil.ResponseBody		"成功返回应用"
+//	@router			/api/v1/k8s/{clusterName}/application/ [post]
+func CreateApplication(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+
+	// 初始化默认值
+	appCreate := dto.K8sApplicationCreate{
+		DeploymentCreate: dto.K8sDeploymentCreate{
+			Replicas:             1,
+			RevisionHistoryLimit: 10,
+		},
+	}
+
+	if err := c.ShouldBindJSON(&appCreate); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &appCreate).Error())
+		return
+	}
+
+	app, err := service.K8sApplication.CreateApplication(clusterName, &appCreate)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, app, "创建成功")
+}
+
+// DeleteApplication
+//
+//	@description	删除应用, 级联删除应用下所有Deployment、Service和Ingress
+//	@tags			K8s,Application
+//	@summary		删除应用
+//	@produce		json
+//	@param			clusterName		path	string	true	"Cluster Name"
+//	@param			namespace		path	string	true	"Namespace"
+//	@param			appName			path	string	true	"应用名称"
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@success		200				object	nil		"成功返回"
+//	@router			/api/v1/k8s/{clusterName}/application/{namespace}/{appName} [delete]
+func DeleteApplication(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "appName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+	name := c.Param("appName")
+
+	if err := service.K8sApplication.DeleteApplication(clusterName, namespace, name); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, nil, "删除成功")
+}
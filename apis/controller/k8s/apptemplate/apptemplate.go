@@ -0,0 +1,187 @@
+package apptemplate
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"soul/apis/dto"
+	"soul/apis/service"
+	"soul/utils/httputil"
+	"strconv"
+)
+
+func parseId(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.Error(c, "模板ID格式错误")
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// requireAdmin 模板会被渲染成任意工作负载, 只有管理员可以修改
+func requireAdmin(c *gin.Context) bool {
+	if !service.SystemUser.IsAdmin(c.GetUint("userId")) {
+		httputil.ErrorWithCode(c, http.StatusForbidden, "只有管理员可以修改应用模板")
+		return false
+	}
+	return true
+}
+
+// GetAppTemplateList
+//
+//	@description	获取应用模板列表
+//	@tags			K8s,AppTemplate
+//	@summary		获取应用模板列表
+//	@produce		json
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回模板列表"
+//	@router			/api/v1/k8s/apptemplate/ [get]
+func GetAppTemplateList(c *gin.Context) {
+	templates := service.K8sAppTemplate.ListAppTemplate()
+
+	httputil.OK(c, map[string]any{"total": len(templates), "items": templates}, "获取成功")
+}
+
+// GetAppTemplateById
+//
+//	@description	获取应用模板
+//	@tags			K8s,AppTemplate
+//	@summary		获取应用模板
+//	@produce		json
+//	@param			id				path	int						true	"模板ID"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回模板"
+//	@router			/api/v1/k8s/apptemplate/{id} [get]
+func GetAppTemplateById(c *gin.Context) {
+	id, ok := parseId(c)
+	if !ok {
+		return
+	}
+
+	template, err := service.K8sAppTemplate.GetAppTemplateById(id)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, template, "获取成功")
+}
+
+// GetAppTemplateVersions
+//
+//	@description	获取应用模板的所有版本
+//	@tags			K8s,AppTemplate
+//	@summary		获取应用模板的所有版本
+//	@produce		json
+//	@param			id				path	int						true	"模板ID"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回模板版本列表"
+//	@router			/api/v1/k8s/apptemplate/{id}/versions [get]
+func GetAppTemplateVersions(c *gin.Context) {
+	id, ok := parseId(c)
+	if !ok {
+		return
+	}
+
+	versions, err := service.K8sAppTemplate.ListAppTemplateVersion(id)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, map[string]any{"total": len(versions), "items": versions}, "获取成功")
+}
+
+// CreateAppTemplate
+//
+//	@description	创建应用模板, 只有管理员可以操作
+//	@tags			K8s,AppTemplate
+//	@summary		创建应用模板
+//	@Accept			json
+//	@produce		json
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@param			data			body	dto.K8sAppTemplateCreate	true	"模板信息"
+//	@success		200				object	httputil.ResponseBody	"成功返回模板"
+//	@router			/api/v1/k8s/apptemplate/ [post]
+func CreateAppTemplate(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+
+	create := dto.K8sAppTemplateCreate{}
+	if err := c.ShouldBindJSON(&create); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &create).Error())
+		return
+	}
+
+	template, err := service.K8sAppTemplate.CreateAppTemplate(create)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, template, "创建成功")
+}
+
+// UpdateAppTemplate
+//
+//	@description	更新应用模板, 参数定义或模板内容变化时生成新版本, 只有管理员可以操作
+//	@tags			K8s,AppTemplate
+//	@summary		更新应用模板
+//	@Accept			json
+//	@produce		json
+//	@param			id				path	int						true	"模板ID"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@param			data			body	dto.K8sAppTemplateCreate	true	"模板信息"
+//	@success		200				object	httputil.ResponseBody	"成功返回模板"
+//	@router			/api/v1/k8s/apptemplate/{id} [put]
+func UpdateAppTemplate(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	id, ok := parseId(c)
+	if !ok {
+		return
+	}
+
+	create := dto.K8sAppTemplateCreate{}
+	if err := c.ShouldBindJSON(&create); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &create).Error())
+		return
+	}
+
+	template, err := service.K8sAppTemplate.UpdateAppTemplate(id, create)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, template, "更新成功")
+}
+
+// DeleteAppTemplate
+//
+//	@description	删除应用模板, 已被实例化的模板不能删除, 只有管理员可以操作
+//	@tags			K8s,AppTemplate
+//	@summary		删除应用模板
+//	@produce		json
+//	@param			id				path	int		true	"模板ID"
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@success		200				object	nil		"成功返回"
+//	@router			/api/v1/k8s/apptemplate/{id} [delete]
+func DeleteAppTemplate(c *gin.Context) {
+	if !requireAdmin(c) {
+		return
+	}
+	id, ok := parseId(c)
+	if !ok {
+		return
+	}
+
+	if err := service.K8sAppTemplate.DeleteAppTemplate(id); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, nil, "删除成功")
+}
@@ -0,0 +1,152 @@
+package apptemplate
+
+import (
+	"github.com/gin-gonic/gin"
+	"soul/apis/dto"
+	"soul/apis/service"
+	"soul/utils/httputil"
+	"strconv"
+)
+
+// GetTemplateInstanceList
+//
+//	@description	获取由模板创建的应用实例列表
+//	@tags			K8s,AppTemplate
+//	@summary		获取模板实例列表
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			namespace		path	string					false	"Namespace 不填为全部"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回实例列表"
+//	@router			/api/v1/k8s/{clusterName}/templateinstance/ [get]
+//	@router			/api/v1/k8s/{clusterName}/templateinstance/{namespace} [get]
+func GetTemplateInstanceList(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+
+	instances := service.K8sAppTemplate.ListTemplateInstance(clusterName, namespace)
+
+	httputil.OK(c, map[string]any{"total": len(instances), "items": instances}, "获取成功")
+}
+
+// GetTemplateInstance
+//
+//	@description	获取模板实例, 包含模板版本和参数值
+//	@tags			K8s,AppTemplate
+//	@summary		获取模板实例
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			namespace		path	string					true	"Namespace"
+//	@param			name			path	string					true	"应用名称"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回实例"
+//	@router			/api/v1/k8s/{clusterName}/templateinstance/{namespace}/{name} [get]
+func GetTemplateInstance(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "name"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+
+	instance, err := service.K8sAppTemplate.GetTemplateInstance(clusterName, namespace, name)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, instance, "获取成功")
+}
+
+// CreateTemplateInstance
+//
+//	@description	使用模板创建应用, dryRun为true时只返回渲染结果
+//	@tags			K8s,AppTemplate
+//	@summary		使用模板创建应用
+//	@Accept			json
+//	@produce		json
+//	@param			clusterName		path	string							true	"Cluster Name"
+//	@Param			Authorization	header	string							true	"Authorization token"
+//	@param			data			body	dto.K8sTemplateInstanceCreate	true	"模板及参数"
+//	@param			dryRun			query	bool							false	"只渲染不创建"
+//	@success		200				object	httputil.ResponseBody			"成功返回实例"
+//	@router			/api/v1/k8s/{clusterName}/templateinstance/ [post]
+func CreateTemplateInstance(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	dryRun, _ := strconv.ParseBool(c.Query("dryRun"))
+
+	create := dto.K8sTemplateInstanceCreate{}
+	if err := c.ShouldBindJSON(&create); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &create).Error())
+		return
+	}
+
+	result, err := service.K8sAppTemplate.Instantiate(clusterName, create, dryRun)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	if dryRun {
+		httputil.OK(c, result, "获取成功")
+		return
+	}
+	httputil.OK(c, result, "创建成功")
+}
+
+// UpgradeTemplateInstance
+//
+//	@description	使用新版本模板或新参数重新渲染并更新应用, 参数会与之前的参数合并, dryRun为true时只返回渲染结果
+//	@tags			K8s,AppTemplate
+//	@summary		升级模板实例
+//	@Accept			json
+//	@produce		json
+//	@param			clusterName		path	string							true	"Cluster Name"
+//	@param			namespace		path	string							true	"Namespace"
+//	@param			name			path	string							true	"应用名称"
+//	@Param			Authorization	header	string							true	"Authorization token"
+//	@param			data			body	dto.K8sTemplateInstanceUpgrade	true	"模板版本及参数"
+//	@param			dryRun			query	bool							false	"只渲染不更新"
+//	@success		200				object	httputil.ResponseBody			"成功返回实例"
+//	@router			/api/v1/k8s/{clusterName}/templateinstance/{namespace}/{name} [put]
+func UpgradeTemplateInstance(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "name"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	dryRun, _ := strconv.ParseBool(c.Query("dryRun"))
+
+	upgrade := dto.K8sTemplateInstanceUpgrade{}
+	if err := c.ShouldBindJSON(&upgrade); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &upgrade).Error())
+		return
+	}
+
+	result, err := service.K8sAppTemplate.Upgrade(clusterName, namespace, name, upgrade, dryRun)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	if dryRun {
+		httputil.OK(c, result, "获取成功")
+		return
+	}
+	httputil.OK(c, result, "更新成功")
+}
@@ -19,7 +19,7 @@ import (
 //	@param			deploymentName	path	string					true	"deployment名称"
 //	@param			namespace		path	string					true	"Namespace"
 //	@Param			Authorization	header	string					true	"Authorization token"
-//	@success		200				object	httputil.ResponseBody	"成功返回Deployment信息"
+//	@success		200				object	httputil.ResponseBody	"成功返回Deployment信息, metrics为所有Pod资源使用量的合计, metrics-server不可用时为null"
 //	@router			/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName} [get]
 func GetDeploymentByName(c *gin.Context) {
 	if err := httputil.CheckParams(c, "clusterName", "namespace", "deploymentName"); err != nil {
@@ -31,7 +31,7 @@ func GetDeploymentByName(c *gin.Context) {
 	name := c.Param("deploymentName")
 	namespace := c.Param("namespace")
 
-	deployment, err := service.K8sDeployment.GetDeploymentByName(clusterName, name, namespace)
+	deployment, err := service.K8sDeployment.GetDeploymentDetail(c.Request.Context(), clusterName, name, namespace)
 
 	if err != nil {
 		httputil.Error(c, err.Error())
@@ -115,8 +115,8 @@ func GetDeploymentPods(c *gin.Context) {
 	}
 
 	data := map[string]interface{}{
-		"total": len(pods.Items),
-		"items": pods.Items,
+		"total": len(pods),
+		"items": pods,
 	}
 
 	httputil.OK(c, data, "获取成功")
@@ -124,7 +124,7 @@ func GetDeploymentPods(c *gin.Context) {
 
 // CreateDeployment
 //
-//	@description	创建 Deployment
+//	@description	创建 Deployment, 通过containers和initContainers定义容器. containers为空时兼容旧版本的单容器字段image、imagePullSecret、cpu、memory、containerPort、httpHealthCheck, 生成一个与Deployment同名的容器
 //	@tags			K8s,Deployment
 //	@summary		创建 Deployment
 //	@Accept			json
@@ -144,9 +144,8 @@ func CreateDeployment(c *gin.Context) {
 
 	// 初始化默认值
 	deploymentCreate := dto.K8sDeploymentCreate{
-		Replicas: 1,
-		Cpu:      "300m",
-		Memory:   "512Mi",
+		Replicas:             1,
+		RevisionHistoryLimit: 10,
 	}
 
 	if err := c.ShouldBindJSON(&deploymentCreate); err != nil {
@@ -328,6 +327,7 @@ func RestartDeployment(c *gin.Context) {
 //	@produce		json
 //	@param			clusterName		path	string	true	"Cluster Name"
 //	@param			deploymentName	body	object	true	"Deployment Api Object"
+//	@param			diff			query	bool	false	"只返回 dry-run 更新后的差异, 不实际更新"
 //	@Param			Authorization	header	string	true	"Authorization token"
 //	@router			/api/v1/k8s/{clusterName}/deployment [put]
 func UpdateK8sDeployment(c *gin.Context) {
@@ -343,6 +343,17 @@ func UpdateK8sDeployment(c *gin.Context) {
 		httputil.Error(c, "参数异常")
 		return
 	}
+
+	if diff, _ := strconv.ParseBool(c.Query("diff")); diff {
+		result, err := service.K8sDeployment.DiffK8sDeployment(clusterName, string(content))
+		if err != nil {
+			httputil.Error(c, err.Error())
+			return
+		}
+		httputil.OK(c, result, "获取成功")
+		return
+	}
+
 	err = service.K8sDeployment.UpdateK8sDeployment(clusterName, string(content))
 	if err != nil {
 		httputil.Error(c, err.Error())
@@ -352,6 +363,120 @@ func UpdateK8sDeployment(c *gin.Context) {
 	httputil.OK(c, nil, "更新成功")
 }
 
+// PromoteDeployment
+//
+//	@description	将 Deployment 及其关联的 Service、Ingress、ConfigMap、Secret 复制到目标集群/Namespace, dryRun 时只返回差异
+//	@tags			K8s,Deployment
+//	@summary		迁移 Deployment 到其他集群/Namespace
+//	@Accept			json
+//	@produce		json
+//	@param			clusterName		path	string						true	"Cluster Name"
+//	@param			deploymentName	path	string						true	"Deployment名称"
+//	@param			namespace		path	string						true	"Namespace"
+//	@Param			Authorization	header	string						true	"Authorization token"
+//	@param			data			body	dto.K8sDeploymentPromote	true	"迁移参数"
+//	@success		200				object	httputil.ResponseBody		"成功返回每个对象的迁移结果"
+//	@router			/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}/promote [post]
+func PromoteDeployment(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "deploymentName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("deploymentName")
+	namespace := c.Param("namespace")
+
+	promote := dto.K8sDeploymentPromote{}
+	if err := c.ShouldBindJSON(&promote); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &promote).Error())
+		return
+	}
+
+	items, err := service.K8sDeployment.PromoteDeployment(clusterName, name, namespace, &promote)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	data := map[string]interface{}{
+		"total": len(items),
+		"items": items,
+	}
+
+	httputil.OK(c, data, "操作成功")
+}
+
+// ExecDeploymentCommand
+//
+//	@description	在Deployment的所有Pod中并行执行同一个非交互命令, 返回每个Pod的stdout、stderr和退出码
+//	@tags			K8s,Deployment
+//	@summary		批量执行命令
+//	@Accept			json
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			deploymentName	path	string					true	"Deployment名称"
+//	@param			namespace		path	string					true	"Namespace"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@param			data			body	dto.K8sPodExec			true	"命令参数, containerName为空时使用每个Pod的第1个容器"
+//	@success		200				object	dto.K8sBatchExecResult	"成功返回汇总的执行结果"
+//	@router			/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}/exec [post]
+func ExecDeploymentCommand(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "deploymentName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("deploymentName")
+	namespace := c.Param("namespace")
+
+	exec := dto.K8sPodExec{}
+	if err := c.ShouldBindJSON(&exec); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &exec).Error())
+		return
+	}
+
+	result, err := service.K8sPod.ExecDeploymentCommand(c.Request.Context(), clusterName, namespace, name, exec, c.GetUint("userId"))
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, result, "执行完成")
+}
+
+// DiagnoseDeployment
+//
+//	@description	诊断Deployment的状态和所有Pod无法正常运行的原因
+//	@tags			K8s,Deployment
+//	@summary		Deployment诊断
+//	@produce		json
+//	@param			clusterName		path	string						true	"Cluster Name"
+//	@param			deploymentName	path	string						true	"Deployment名称"
+//	@param			namespace		path	string						true	"Namespace"
+//	@Param			Authorization	header	string						true	"Authorization token"
+//	@success		200				object	dto.K8sDeploymentDiagnosis	"成功返回诊断结果"
+//	@router			/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}/diagnose [get]
+func DiagnoseDeployment(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "deploymentName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("deploymentName")
+	namespace := c.Param("namespace")
+
+	diagnosis, err := service.K8sPod.DiagnoseDeployment(c.Request.Context(), clusterName, name, namespace)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, diagnosis, "获取成功")
+}
+
 //TODO CreateK8sDeployment 使用 K8s 1:1 Api 创建Deployment
 
 //TODO UpdateDeployment 使用 dto.K8sDeploymentCreate 对象更新Deployment
@@ -0,0 +1,122 @@
+package gateway
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"soul/apis/service"
+	"soul/utils/httputil"
+)
+
+// GetGatewayClassByName
+//
+//	@description	获取GatewayClass信息
+//	@tags			K8s,GatewayAPI
+//	@summary		获取GatewayClass信息
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			name			path	string					true	"GatewayClass名称"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回GatewayClass信息"
+//	@router			/api/v1/k8s/{clusterName}/gateway/gatewayclass/{name} [get]
+func GetGatewayClassByName(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "name"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("name")
+
+	obj, err := service.K8sGatewayClass.GetGatewayClassByName(clusterName, name)
+
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, obj, "获取成功")
+}
+
+// GetGatewayClassList
+//
+//	@description	获取GatewayClass列表, GatewayClass是集群级别的资源
+//	@tags			K8s,GatewayAPI
+//	@summary		获取GatewayClass列表
+//	@produce		json
+//	@param			clusterName		path	string						true	"Cluster Name"
+//	@Param			Authorization	header	string						true	"Authorization token"
+//	@Param			filter			query	string						false	"根据GatewayClass名字模糊查询"
+//	@Param			limit			query	string						false	"一页获取多少条数据,默认十条"
+//	@Param			page			query	string						false	"获取第几页的数据,默认第一页"
+//	@success		200				object	httputil.PageResponseBody	"成功返回GatewayClass列表"
+//	@router			/api/v1/k8s/{clusterName}/gateway/gatewayclass/ [get]
+func GetGatewayClassList(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+
+	params := new(struct {
+		FilterName string `form:"filter"`
+		Limit      int    `form:"limit,default=10"`
+		Page       int    `form:"page,default=1"`
+	})
+
+	if err := c.ShouldBind(params); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, params).Error())
+		return
+	}
+
+	list, err := service.K8sGatewayClass.GetGatewayClassList(clusterName, params.FilterName, params.Limit, params.Page)
+
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.Page(c, list, "获取成功")
+}
+
+// DeleteGatewayClassByName
+//
+//	@description	删除GatewayClass
+//	@tags			K8s,GatewayAPI
+//	@summary		删除GatewayClass
+//	@produce		json
+//	@param			clusterName		path	string	true	"Cluster Name"
+//	@param			name			path	string	true	"GatewayClass名称"
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@success		200				object	nil		"成功返回"
+//	@router			/api/v1/k8s/{clusterName}/gateway/gatewayclass/{name} [delete]
+func DeleteGatewayClassByName(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "name"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("name")
+
+	_, err := service.K8sGatewayClass.GetGatewayClassByName(clusterName, name)
+	if err != nil {
+		switch {
+		case errors.IsNotFound(err):
+			httputil.Error(c, fmt.Sprintf(`GatewayClass "%s" 未找到`, name))
+		default:
+			httputil.Error(c, err.Error())
+		}
+		return
+	}
+
+	err = service.K8sGatewayClass.DeleteGatewayClassByName(clusterName, name)
+
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, nil, "删除成功")
+}
@@ -0,0 +1,244 @@
+package gateway
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"soul/apis/dto"
+	"soul/apis/service"
+	"soul/utils/httputil"
+	"strconv"
+)
+
+// GetHTTPRouteByName
+//
+//	@description	获取HTTPRoute信息
+//	@tags			K8s,GatewayAPI
+//	@summary		获取HTTPRoute信息
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			name			path	string					true	"HTTPRoute名称"
+//	@param			namespace		path	string					true	"Namespace"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回HTTPRoute信息"
+//	@router			/api/v1/k8s/{clusterName}/gateway/httproute/{namespace}/{name} [get]
+func GetHTTPRouteByName(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "name"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("name")
+	namespace := c.Param("namespace")
+
+	obj, err := service.K8sHTTPRoute.GetHTTPRouteByName(clusterName, name, namespace)
+
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, obj, "获取成功")
+}
+
+// GetHTTPRouteList
+//
+//	@description	获取HTTPRoute列表
+//	@tags			K8s,GatewayAPI
+//	@summary		获取HTTPRoute列表
+//	@produce		json
+//	@param			clusterName		path	string						true	"Cluster Name"
+//	@param			namespace		path	string						false	"Namespace 不填为全部"
+//	@Param			Authorization	header	string						true	"Authorization token"
+//	@Param			filter			query	string						false	"根据HTTPRoute名字模糊查询"
+//	@Param			limit			query	string						false	"一页获取多少条数据,默认十条"
+//	@Param			page			query	string						false	"获取第几页的数据,默认第一页"
+//	@success		200				object	httputil.PageResponseBody	"成功返回HTTPRoute列表"
+//	@router			/api/v1/k8s/{clusterName}/gateway/httproute/ [get]
+//	@router			/api/v1/k8s/{clusterName}/gateway/httproute/{namespace} [get]
+func GetHTTPRouteList(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+
+	params := new(struct {
+		FilterName string `form:"filter"`
+		Limit      int    `form:"limit,default=10"`
+		Page       int    `form:"page,default=1"`
+	})
+
+	if err := c.ShouldBind(params); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, params).Error())
+		return
+	}
+
+	list, err := service.K8sHTTPRoute.GetHTTPRouteList(clusterName, params.FilterName, namespace, params.Limit, params.Page)
+
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.Page(c, list, "获取成功")
+}
+
+// DeleteHTTPRouteByName
+//
+//	@description	删除HTTPRoute
+//	@tags			K8s,GatewayAPI
+//	@summary		删除HTTPRoute
+//	@produce		json
+//	@param			clusterName		path	string	true	"Cluster Name"
+//	@param			name			path	string	true	"HTTPRoute名称"
+//	@param			namespace		path	string	true	"Namespace"
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@success		200				object	nil		"成功返回"
+//	@router			/api/v1/k8s/{clusterName}/gateway/httproute/{namespace}/{name} [delete]
+func DeleteHTTPRouteByName(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "name"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("name")
+	namespace := c.Param("namespace")
+
+	_, err := service.K8sHTTPRoute.GetHTTPRouteByName(clusterName, name, namespace)
+	if err != nil {
+		switch {
+		case errors.IsNotFound(err):
+			httputil.Error(c, fmt.Sprintf(`HTTPRoute "%s" 在 "%s" 中未找到`, name, namespace))
+		default:
+			httputil.Error(c, err.Error())
+		}
+		return
+	}
+
+	err = service.K8sHTTPRoute.DeleteHTTPRouteByName(clusterName, name, namespace)
+
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, nil, "删除成功")
+}
+
+// CreateSimpleHTTPRoute
+//
+//	@description	创建 HTTPRoute, 支持多个parentRef、hostname和规则, 每个规则可以有多个匹配条件和带权重的后端
+//	@tags			K8s,GatewayAPI
+//	@summary		创建简单 HTTPRoute
+//	@produce		json
+//	@param			clusterName		path	string						true	"Cluster Name"
+//	@Param			Authorization	header	string						true	"Authorization token"
+//	@param			data			body	dto.K8sHTTPRouteSimpleCreate	true	"K8sHTTPRouteSimpleCreate 对象"
+//	@success		200				object	httputil.ResponseBody		"成功返回"
+//	@router			/api/v1/k8s/{clusterName}/gateway/httproute/ [post]
+func CreateSimpleHTTPRoute(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	route := dto.K8sHTTPRouteSimpleCreate{}
+
+	if err := c.ShouldBindJSON(&route); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &route).Error())
+		return
+	}
+
+	err := service.K8sHTTPRoute.CreateSimpleHTTPRoute(clusterName, &route)
+
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, nil, "创建成功")
+}
+
+// UpdateSimpleHTTPRoute
+//
+//	@description	更新 HTTPRoute, 使用merge patch, 保留其他工具添加的注解
+//	@tags			K8s,GatewayAPI
+//	@summary		更新简单 HTTPRoute
+//	@produce		json
+//	@param			clusterName		path	string						true	"Cluster Name"
+//	@param			diff			query	bool						false	"只返回 dry-run 更新后的差异, 不实际更新"
+//	@Param			Authorization	header	string						true	"Authorization token"
+//	@param			data			body	dto.K8sHTTPRouteSimpleCreate	true	"K8sHTTPRouteSimpleCreate 对象"
+//	@success		200				object	httputil.ResponseBody		"成功返回"
+//	@router			/api/v1/k8s/{clusterName}/gateway/httproute/ [put]
+func UpdateSimpleHTTPRoute(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	route := dto.K8sHTTPRouteSimpleCreate{}
+
+	if err := c.ShouldBindJSON(&route); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &route).Error())
+		return
+	}
+
+	if diff, _ := strconv.ParseBool(c.Query("diff")); diff {
+		result, err := service.K8sHTTPRoute.DiffSimpleHTTPRoute(clusterName, &route)
+		if err != nil {
+			httputil.Error(c, err.Error())
+			return
+		}
+		httputil.OK(c, result, "获取成功")
+		return
+	}
+
+	err := service.K8sHTTPRoute.UpdateSimpleHTTPRoute(clusterName, &route)
+
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, nil, "更新成功")
+}
+
+// GetHTTPRouteStatus
+//
+//	@description	获取 HTTPRoute 在每个Gateway上的状态, 包括是否被接受、后端是否能解析以及原因
+//	@tags			K8s,GatewayAPI
+//	@summary		获取 HTTPRoute 状态
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			name			path	string					true	"HTTPRoute名称"
+//	@param			namespace		path	string					true	"Namespace"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回 dto.K8sHTTPRouteStatus"
+//	@router			/api/v1/k8s/{clusterName}/gateway/httproute/{namespace}/{name}/status [get]
+func GetHTTPRouteStatus(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "name"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("name")
+	namespace := c.Param("namespace")
+
+	status, err := service.K8sHTTPRoute.GetHTTPRouteStatus(clusterName, name, namespace)
+
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, status, "获取成功")
+}
@@ -0,0 +1,342 @@
+package helm
+
+import (
+	"github.com/gin-gonic/gin"
+	"mime/multipart"
+	"net/http"
+	"soul/apis/dto"
+	"soul/apis/service"
+	"soul/utils/httputil"
+	"strconv"
+)
+
+// formFile 获取上传的chart包, 没有上传时返回nil
+func formFile(c *gin.Context) (*multipart.FileHeader, error) {
+	file, err := c.FormFile("file")
+	if err == http.ErrMissingFile || err == http.ErrNotMultipart {
+		return nil, nil
+	}
+	return file, err
+}
+
+// GetChartList
+//
+//	@description	获取本地chart仓库中的chart列表
+//	@tags			K8s,Helm
+//	@summary		获取本地chart列表
+//	@produce		json
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回chart列表"
+//	@router			/api/v1/k8s/helmchart/ [get]
+func GetChartList(c *gin.Context) {
+	charts, err := service.K8sHelm.ListChart()
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, map[string]any{"total": len(charts), "items": charts}, "获取成功")
+}
+
+// GetReleaseList
+//
+//	@description	获取Helm Release列表
+//	@tags			K8s,Helm
+//	@summary		获取Helm Release列表
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			namespace		path	string					false	"Namespace 不填为全部"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回Release列表"
+//	@router			/api/v1/k8s/{clusterName}/helm/ [get]
+//	@router			/api/v1/k8s/{clusterName}/helm/{namespace} [get]
+func GetReleaseList(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+
+	releases, err := service.K8sHelm.ListRelease(clusterName, namespace)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, map[string]any{"total": len(releases), "items": releases}, "获取成功")
+}
+
+// GetReleaseByName
+//
+//	@description	获取Helm Release详情
+//	@tags			K8s,Helm
+//	@summary		获取Helm Release详情
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			namespace		path	string					true	"Namespace"
+//	@param			releaseName		path	string					true	"Release名称"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回Release详情"
+//	@router			/api/v1/k8s/{clusterName}/helm/{namespace}/{releaseName} [get]
+func GetReleaseByName(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "releaseName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+	name := c.Param("releaseName")
+
+	release, err := service.K8sHelm.GetRelease(clusterName, namespace, name)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, release, "获取成功")
+}
+
+// GetReleaseHistory
+//
+//	@description	获取Helm Release历史版本
+//	@tags			K8s,Helm
+//	@summary		获取Helm Release历史版本
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			namespace		path	string					true	"Namespace"
+//	@param			releaseName		path	string					true	"Release名称"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回历史版本"
+//	@router			/api/v1/k8s/{clusterName}/helm/{namespace}/{releaseName}/history [get]
+func GetReleaseHistory(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "releaseName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+	name := c.Param("releaseName")
+
+	history, err := service.K8sHelm.GetReleaseHistory(clusterName, namespace, name)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, map[string]any{"total": len(history), "items": history}, "获取成功")
+}
+
+// GetReleaseValues
+//
+//	@description	获取Helm Release的values
+//	@tags			K8s,Helm
+//	@summary		获取Helm Release的values
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			namespace		path	string					true	"Namespace"
+//	@param			releaseName		path	string					true	"Release名称"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@param			revision		query	int						false	"版本, 默认最新版本"
+//	@param			all				query	bool					false	"包含chart默认值"
+//	@success		200				object	httputil.ResponseBody	"成功返回values"
+//	@router			/api/v1/k8s/{clusterName}/helm/{namespace}/{releaseName}/values [get]
+func GetReleaseValues(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "releaseName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+	name := c.Param("releaseName")
+
+	params := new(struct {
+		Revision int  `form:"revision"`
+		All      bool `form:"all"`
+	})
+	if err := c.ShouldBindQuery(params); err != nil {
+		httputil.Error(c, "参数格式错误")
+		return
+	}
+
+	values, err := service.K8sHelm.GetReleaseValues(clusterName, namespace, name, params.Revision, params.All)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, values, "获取成功")
+}
+
+// InstallRelease
+//
+//	@description	安装chart, 上传chart包(file)和本地chart仓库中的chart二选一
+//	@tags			K8s,Helm
+//	@summary		安装chart
+//	@Accept			multipart/form-data
+//	@produce		json
+//	@param			clusterName		path		string					true	"Cluster Name"
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@param			file			formData	file					false	"chart包(.tgz)"
+//	@param			chart			formData	string					false	"本地chart仓库中的chart"
+//	@param			name			formData	string					true	"Release名称"
+//	@param			namespace		formData	string					true	"Namespace"
+//	@param			values			formData	string					false	"values(yaml)"
+//	@param			wait			formData	bool					false	"等待资源就绪"
+//	@param			timeout			formData	int						false	"超时时间(秒), 默认300"
+//	@success		200				object		httputil.ResponseBody	"成功返回Release"
+//	@router			/api/v1/k8s/{clusterName}/helm/ [post]
+func InstallRelease(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+
+	install := dto.K8sHelmInstall{}
+	if err := c.ShouldBind(&install); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &install).Error())
+		return
+	}
+
+	file, err := formFile(c)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	release, err := service.K8sHelm.InstallRelease(clusterName, install, file)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, release, "安装成功")
+}
+
+// UpgradeRelease
+//
+//	@description	升级Helm Release, 上传chart包(file)和本地chart仓库中的chart二选一
+//	@tags			K8s,Helm
+//	@summary		升级Helm Release
+//	@Accept			multipart/form-data
+//	@produce		json
+//	@param			clusterName		path		string					true	"Cluster Name"
+//	@param			namespace		path		string					true	"Namespace"
+//	@param			releaseName		path		string					true	"Release名称"
+//	@Param			Authorization	header		string					true	"Authorization token"
+//	@param			file			formData	file					false	"chart包(.tgz)"
+//	@param			chart			formData	string					false	"本地chart仓库中的chart"
+//	@param			values			formData	string					false	"values(yaml)"
+//	@param			reuseValues		formData	bool					false	"在上一个版本values的基础上合并"
+//	@param			wait			formData	bool					false	"等待资源就绪"
+//	@param			timeout			formData	int						false	"超时时间(秒), 默认300"
+//	@success		200				object		httputil.ResponseBody	"成功返回Release"
+//	@router			/api/v1/k8s/{clusterName}/helm/{namespace}/{releaseName} [put]
+func UpgradeRelease(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "releaseName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+	name := c.Param("releaseName")
+
+	upgrade := dto.K8sHelmUpgrade{}
+	if err := c.ShouldBind(&upgrade); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &upgrade).Error())
+		return
+	}
+
+	file, err := formFile(c)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	release, err := service.K8sHelm.UpgradeRelease(clusterName, namespace, name, upgrade, file)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, release, "更新成功")
+}
+
+// RollbackRelease
+//
+//	@description	回滚Helm Release, revision为0时回滚到上一个版本
+//	@tags			K8s,Helm
+//	@summary		回滚Helm Release
+//	@Accept			json
+//	@produce		json
+//	@param			clusterName		path	string				true	"Cluster Name"
+//	@param			namespace		path	string				true	"Namespace"
+//	@param			releaseName		path	string				true	"Release名称"
+//	@Param			Authorization	header	string				true	"Authorization token"
+//	@param			data			body	dto.K8sHelmRollback	true	"回滚版本"
+//	@success		200				object	nil					"成功返回"
+//	@router			/api/v1/k8s/{clusterName}/helm/{namespace}/{releaseName}/rollback [put]
+func RollbackRelease(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "releaseName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+	name := c.Param("releaseName")
+
+	// 初始化默认值
+	rollback := dto.K8sHelmRollback{Timeout: 300}
+	if err := c.ShouldBindJSON(&rollback); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &rollback).Error())
+		return
+	}
+
+	if err := service.K8sHelm.RollbackRelease(clusterName, namespace, name, rollback); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, nil, "操作成功")
+}
+
+// UninstallRelease
+//
+//	@description	卸载Helm Release
+//	@tags			K8s,Helm
+//	@summary		卸载Helm Release
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			namespace		path	string					true	"Namespace"
+//	@param			releaseName		path	string					true	"Release名称"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@param			keepHistory		query	bool					false	"保留历史版本"
+//	@success		200				object	httputil.ResponseBody	"成功返回"
+//	@router			/api/v1/k8s/{clusterName}/helm/{namespace}/{releaseName} [delete]
+func UninstallRelease(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "releaseName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+	name := c.Param("releaseName")
+	keepHistory, _ := strconv.ParseBool(c.Query("keepHistory"))
+
+	info, err := service.K8sHelm.UninstallRelease(clusterName, namespace, name, keepHistory)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, info, "删除成功")
+}
@@ -1,12 +1,15 @@
 package ingress
 
 import (
+	stderrors "errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"soul/apis/dto"
 	"soul/apis/service"
+	ingresssvc "soul/apis/service/k8s/ingress"
 	"soul/utils/httputil"
+	"strconv"
 )
 
 // GetIngressByName
@@ -87,7 +90,7 @@ func GetIngressList(c *gin.Context) {
 
 // CreateSimpleIngress
 //
-//	@description	创建简单 Ingress ,仅支持一个路由前缀,支持多个Host, 对应一个Service
+//	@description	创建 Ingress, 支持多个host和path、默认后端和TLS, 后端端口可以是端口号或端口名称
 //	@tags			K8s,Ingress
 //	@summary		创建简单 Ingress
 //	@produce		json
@@ -95,8 +98,9 @@ func GetIngressList(c *gin.Context) {
 //	@produce		json
 //	@param			clusterName		path	string						true	"Cluster Name"
 //	@Param			Authorization	header	string						true	"Authorization token"
+//	@param			force			query	bool						false	"跳过后端、TLS和host冲突检查"
 //	@param			data			body	dto.K8sIngressSimpleCreate	true	"K8sIngressSimpleCreate 对象"
-//	@success		200				object	httputil.ResponseBody		"成功返回"
+//	@success		200				object	httputil.ResponseBody		"成功返回, 检查未通过时data为 dto.K8sIngressValidation"
 //	@router			/api/v1/k8s/{clusterName}/ingress/ [post]
 func CreateSimpleIngress(c *gin.Context) {
 	if err := httputil.CheckParams(c, "clusterName"); err != nil {
@@ -106,19 +110,18 @@ func CreateSimpleIngress(c *gin.Context) {
 
 	clusterName := c.Param("clusterName")
 
-	// 初始化默认值
 	ingress := dto.K8sIngressSimpleCreate{}
-	ingress.Rule.Path = "/"
 
 	if err := c.ShouldBindJSON(&ingress); err != nil {
 		httputil.Error(c, httputil.ParseValidateError(err, &ingress).Error())
 		return
 	}
 
-	err := service.K8sIngress.CreateSimpleIngress(clusterName, &ingress)
+	force, _ := strconv.ParseBool(c.Query("force"))
+	err := service.K8sIngress.CreateSimpleIngress(clusterName, &ingress, force)
 
 	if err != nil {
-		httputil.Error(c, err.Error())
+		applyError(c, err)
 		return
 	}
 
@@ -127,16 +130,18 @@ func CreateSimpleIngress(c *gin.Context) {
 
 // UpdateSimpleIngress
 //
-//	@description	更新简单 Ingress
+//	@description	更新 Ingress, 使用merge patch, 保留其他工具添加的注解
 //	@tags			K8s,Ingress
 //	@summary		更新简单 Ingress
 //	@produce		json
 //	@param			clusterName	path	string	true	"Cluster Name"
 //	@produce		json
 //	@param			clusterName		path	string						true	"Cluster Name"
+//	@param			diff			query	bool	false	"只返回 dry-run 更新后的差异, 不实际更新"
 //	@Param			Authorization	header	string						true	"Authorization token"
+//	@param			force			query	bool						false	"跳过后端、TLS和host冲突检查"
 //	@param			data			body	dto.K8sIngressSimpleCreate	true	"K8sIngressSimpleCreate 对象"
-//	@success		200				object	httputil.ResponseBody		"成功返回"
+//	@success		200				object	httputil.ResponseBody		"成功返回, 检查未通过时data为 dto.K8sIngressValidation"
 //	@router			/api/v1/k8s/{clusterName}/ingress/ [put]
 func UpdateSimpleIngress(c *gin.Context) {
 	if err := httputil.CheckParams(c, "clusterName"); err != nil {
@@ -146,19 +151,28 @@ func UpdateSimpleIngress(c *gin.Context) {
 
 	clusterName := c.Param("clusterName")
 
-	// 初始化默认值
 	ingress := dto.K8sIngressSimpleCreate{}
-	ingress.Rule.Path = "/"
 
 	if err := c.ShouldBindJSON(&ingress); err != nil {
 		httputil.Error(c, httputil.ParseValidateError(err, &ingress).Error())
 		return
 	}
 
-	err := service.K8sIngress.UpdateSimpleIngress(clusterName, &ingress)
+	if diff, _ := strconv.ParseBool(c.Query("diff")); diff {
+		result, err := service.K8sIngress.DiffSimpleIngress(clusterName, &ingress)
+		if err != nil {
+			httputil.Error(c, err.Error())
+			return
+		}
+		httputil.OK(c, result, "获取成功")
+		return
+	}
+
+	force, _ := strconv.ParseBool(c.Query("force"))
+	err := service.K8sIngress.UpdateSimpleIngress(clusterName, &ingress, force)
 
 	if err != nil {
-		httputil.Error(c, err.Error())
+		applyError(c, err)
 		return
 	}
 
@@ -207,3 +221,76 @@ func DeleteIngressByName(c *gin.Context) {
 
 	httputil.OK(c, nil, "删除成功")
 }
+
+// ValidateSimpleIngress
+//
+//	@description	检查 Ingress 的后端Service和端口、TLS Secret和证书、IngressClass以及与其他Ingress的host和path冲突, 不会创建或更新
+//	@tags			K8s,Ingress
+//	@summary		检查 Ingress
+//	@produce		json
+//	@param			clusterName		path	string						true	"Cluster Name"
+//	@Param			Authorization	header	string						true	"Authorization token"
+//	@param			data			body	dto.K8sIngressSimpleCreate	true	"K8sIngressSimpleCreate 对象"
+//	@success		200				object	httputil.ResponseBody		"成功返回 dto.K8sIngressValidation"
+//	@router			/api/v1/k8s/{clusterName}/ingress/validate [post]
+func ValidateSimpleIngress(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	ingress := dto.K8sIngressSimpleCreate{}
+
+	if err := c.ShouldBindJSON(&ingress); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &ingress).Error())
+		return
+	}
+
+	result, err := service.K8sIngress.ValidateSimpleIngress(c.Request.Context(), clusterName, &ingress)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, result, "检查完成")
+}
+
+// LintIngresses
+//
+//	@description	检查集群中已有的 Ingress, 只返回有问题的 Ingress, host和path冲突在整个集群范围内检查
+//	@tags			K8s,Ingress
+//	@summary		检查集群中的 Ingress
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			namespace		query	string					false	"Namespace 不填为全部"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回 dto.K8sIngressLint"
+//	@router			/api/v1/k8s/{clusterName}/ingress/lint [get]
+func LintIngresses(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Query("namespace")
+
+	result, err := service.K8sIngress.LintIngresses(c.Request.Context(), clusterName, namespace)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, result, "检查完成")
+}
+
+// applyError 检查未通过时返回具体的问题
+func applyError(c *gin.Context, err error) {
+	var validationErr *ingresssvc.ValidationError
+	if stderrors.As(err, &validationErr) {
+		httputil.ErrorWithData(c, validationErr.Validation, "Ingress检查未通过, 可以使用force=true跳过检查")
+		return
+	}
+	httputil.Error(c, err.Error())
+}
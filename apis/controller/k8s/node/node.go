@@ -0,0 +1,58 @@
+package node
+
+import (
+	"github.com/gin-gonic/gin"
+	"soul/apis/service"
+	"soul/utils/httputil"
+)
+
+// GetNodeList
+//
+//	@description	获取节点列表, 包括可分配资源和使用量, metrics-server不可用时usage为null
+//	@tags			K8s,Node
+//	@summary		获取节点列表
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回节点列表"
+//	@router			/api/v1/k8s/{clusterName}/node/ [get]
+func GetNodeList(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	nodes, err := service.K8sNode.GetNodeList(c.Request.Context(), c.Param("clusterName"))
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, map[string]any{"total": len(nodes), "items": nodes}, "获取成功")
+}
+
+// GetNodeByName
+//
+//	@description	获取节点的可分配资源和使用量, metrics-server不可用时usage为null
+//	@tags			K8s,Node
+//	@summary		获取节点信息
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			nodeName		path	string					true	"节点名称"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回节点信息"
+//	@router			/api/v1/k8s/{clusterName}/node/{nodeName} [get]
+func GetNodeByName(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "nodeName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	node, err := service.K8sNode.GetNodeByName(c.Request.Context(), c.Param("clusterName"), c.Param("nodeName"))
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, node, "获取成功")
+}
@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"net/http"
+	"path"
+	"soul/apis/dto"
 	"soul/apis/service"
+	log "soul/internal/logger"
 	"soul/utils/httputil"
 	"strconv"
 )
@@ -19,7 +23,7 @@ import (
 //	@param			podName			path	string					true	"Pod名称"
 //	@param			namespace		path	string					true	"Namespace"
 //	@Param			Authorization	header	string					true	"Authorization token"
-//	@success		200				object	httputil.ResponseBody	"成功返回Pod信息"
+//	@success		200				object	httputil.ResponseBody	"成功返回Pod信息, metrics为各容器的资源使用量, metrics-server不可用时为null"
 //	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/{podName} [get]
 func GetPodByName(c *gin.Context) {
 	if err := httputil.CheckParams(c, "clusterName", "namespace", "podName"); err != nil {
@@ -31,7 +35,7 @@ func GetPodByName(c *gin.Context) {
 	name := c.Param("podName")
 	namespace := c.Param("namespace")
 
-	pod, err := service.K8sPod.GetPodByName(clusterName, name, namespace)
+	pod, err := service.K8sPod.GetPodDetail(c.Request.Context(), clusterName, name, namespace)
 
 	if err != nil {
 		httputil.Error(c, err.Error())
@@ -88,13 +92,14 @@ func GetPodList(c *gin.Context) {
 
 // DeletePodByName
 //
-//	@description	删除Pod
+//	@description	删除Pod, force为true时以宽限期0强制删除, 用于一直处于Terminating的Pod
 //	@tags			K8s,Pod
 //	@summary		删除Pod
 //	@produce		json
 //	@param			clusterName		path	string	true	"Cluster Name"
 //	@param			podName			path	string	true	"Pod名称"
 //	@param			namespace		path	string	true	"Namespace"
+//	@param			force			query	bool	false	"强制删除"
 //	@Param			Authorization	header	string	true	"Authorization token"
 //	@success		200				object	nil		"成功返回"
 //	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/{podName} [delete]
@@ -119,7 +124,11 @@ func DeletePodByName(c *gin.Context) {
 		return
 	}
 
-	err = service.K8sPod.DeletePodByName(clusterName, name, namespace)
+	if c.Query("force") == "true" {
+		err = service.K8sPod.ForceDeletePodByName(c.Request.Context(), clusterName, name, namespace)
+	} else {
+		err = service.K8sPod.DeletePodByName(clusterName, name, namespace)
+	}
 
 	if err != nil {
 		httputil.Error(c, err.Error())
@@ -129,6 +138,107 @@ func DeletePodByName(c *gin.Context) {
 	httputil.OK(c, nil, "删除成功")
 }
 
+// DiagnosePod
+//
+//	@description	分析Pod无法正常运行的原因, 包括调度失败、容器状态、退出码、事件、缺少的ConfigMap/Secret/PVC和镜像拉取密钥问题
+//	@tags			K8s,Pod
+//	@summary		Pod诊断
+//	@produce		json
+//	@param			clusterName		path	string				true	"Cluster Name"
+//	@param			podName			path	string				true	"Pod名称"
+//	@param			namespace		path	string				true	"Namespace"
+//	@Param			Authorization	header	string				true	"Authorization token"
+//	@success		200				object	dto.K8sPodDiagnosis	"成功返回诊断结果"
+//	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/diagnose [get]
+func DiagnosePod(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "podName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("podName")
+	namespace := c.Param("namespace")
+
+	diagnosis, err := service.K8sPod.DiagnosePod(c.Request.Context(), clusterName, name, namespace)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, diagnosis, "获取成功")
+}
+
+// EvictPod
+//
+//	@description	通过Eviction API驱逐Pod, 会遵守PodDisruptionBudget, 被PDB阻止时返回错误
+//	@tags			K8s,Pod
+//	@summary		驱逐Pod
+//	@produce		json
+//	@param			clusterName		path	string	true	"Cluster Name"
+//	@param			podName			path	string	true	"Pod名称"
+//	@param			namespace		path	string	true	"Namespace"
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@success		200				object	nil		"成功返回"
+//	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/evict [post]
+func EvictPod(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "podName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("podName")
+	namespace := c.Param("namespace")
+
+	err := service.K8sPod.EvictPodByName(c.Request.Context(), clusterName, name, namespace)
+	if err != nil {
+		switch {
+		case errors.IsNotFound(err):
+			httputil.Error(c, fmt.Sprintf(`Pod "%s" 在 "%s" 中未找到`, name, namespace))
+		default:
+			httputil.Error(c, err.Error())
+		}
+		return
+	}
+
+	httputil.OK(c, nil, "驱逐成功")
+}
+
+// BulkPodOperation
+//
+//	@description	按标签选择器和状态批量删除、强制删除或驱逐Pod, 例如删除所有Evicted的Pod, dryRun为true时只返回匹配的Pod
+//	@tags			K8s,Pod
+//	@summary		批量操作Pod
+//	@accept			json
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			namespace		path	string					true	"Namespace"
+//	@param			data			body	dto.K8sPodBulkOperation	true	"批量操作参数"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	dto.K8sPodBulkResult	"成功返回每个Pod的操作结果"
+//	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/bulk [post]
+func BulkPodOperation(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	operation := dto.K8sPodBulkOperation{}
+	if err := c.ShouldBindJSON(&operation); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &operation).Error())
+		return
+	}
+
+	result, err := service.K8sPod.BulkPodOperation(c.Request.Context(), c.Param("clusterName"), c.Param("namespace"), operation, c.GetUint("userId"))
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, result, "操作完成")
+}
+
 // GetPodLog
 //
 //	@description	获取Pod日志
@@ -173,6 +283,506 @@ func GetPodLog(c *gin.Context) {
 	httputil.OK(c, pod, "获取成功")
 }
 
+// StreamPodLog
+//
+//	@description	以SSE的方式推送Pod日志, 事件类型: log(一行日志)、error(读取失败)、end(日志结束)
+//	@tags			K8s,Pod
+//	@summary		实时推送Pod日志
+//	@produce		text/event-stream
+//	@param			clusterName		path	string	true	"Cluster Name"
+//	@param			podName			path	string	true	"Pod名称"
+//	@param			namespace		path	string	true	"Namespace"
+//	@param			containerName	query	string	false	"容器名,默认第1个容器"
+//	@param			follow			query	bool	false	"持续推送新日志"
+//	@param			previous		query	bool	false	"上一个容器实例的日志"
+//	@param			timestamps		query	bool	false	"每行日志带上时间戳"
+//	@param			tailLines		query	int		false	"从最后多少行开始"
+//	@param			sinceSeconds	query	int		false	"最近多少秒的日志, 与sinceTime二选一"
+//	@param			sinceTime		query	string	false	"从某个时间开始的日志(RFC3339), 与sinceSeconds二选一"
+//	@param			limitBytes		query	int		false	"最多读取多少字节"
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@success		200				object	nil		"日志流"
+//	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/log/stream [get]
+func StreamPodLog(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "podName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("podName")
+	namespace := c.Param("namespace")
+
+	query := dto.K8sPodLogQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &query).Error())
+		return
+	}
+
+	events, err := service.K8sPod.StreamPodLog(c.Request.Context(), clusterName, name, namespace, query)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.SSE(c, events)
+}
+
+// StreamAggregatedLog
+//
+//	@description	以SSE的方式推送多个Pod的聚合日志, 每行带有[pod/container]前缀并按时间戳排序, follow模式下自动读取新Pod的日志
+//	@tags			K8s,Pod
+//	@summary		聚合推送多个Pod的日志
+//	@produce		text/event-stream
+//	@param			clusterName		path	string	true	"Cluster Name"
+//	@param			namespace		path	string	true	"Namespace"
+//	@param			kind			query	string	false	"deployment或statefulset, 与labelSelector二选一"
+//	@param			name			query	string	false	"Deployment/StatefulSet名称"
+//	@param			labelSelector	query	string	false	"Pod标签选择器"
+//	@param			containerName	query	string	false	"容器名,默认所有容器"
+//	@param			follow			query	bool	false	"持续推送新日志"
+//	@param			timestamps		query	bool	false	"每行日志带上时间戳"
+//	@param			tailLines		query	int		false	"每个容器从最后多少行开始, 默认1000"
+//	@param			sinceSeconds	query	int		false	"最近多少秒的日志"
+//	@param			filter			query	string	false	"正则表达式, 只返回匹配的行"
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@success		200				object	nil		"日志流"
+//	@router			/api/v1/k8s/{clusterName}/log/{namespace} [get]
+func StreamAggregatedLog(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+
+	query := dto.K8sAggregatedLogQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &query).Error())
+		return
+	}
+
+	events, err := service.K8sPod.StreamAggregatedLog(c.Request.Context(), clusterName, namespace, query)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.SSE(c, events)
+}
+
+// DownloadPodLog
+//
+//	@description	以文件的方式下载Pod容器日志
+//	@tags			K8s,Pod
+//	@summary		下载Pod日志
+//	@produce		octet-stream
+//	@param			clusterName		path	string	true	"Cluster Name"
+//	@param			podName			path	string	true	"Pod名称"
+//	@param			namespace		path	string	true	"Namespace"
+//	@param			containerName	query	string	false	"容器名,默认第1个容器"
+//	@param			previous		query	bool	false	"上一个容器实例的日志"
+//	@param			timestamps		query	bool	false	"每行日志带上时间戳"
+//	@param			tailLines		query	int		false	"从最后多少行开始"
+//	@param			sinceSeconds	query	int		false	"最近多少秒的日志, 与sinceTime二选一"
+//	@param			sinceTime		query	string	false	"从某个时间开始的日志(RFC3339), 与sinceSeconds二选一"
+//	@param			limitBytes		query	int		false	"最多读取多少字节"
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@success		200				file	file	"日志文件"
+//	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/log/download [get]
+func DownloadPodLog(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "podName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("podName")
+	namespace := c.Param("namespace")
+
+	query := dto.K8sPodLogQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &query).Error())
+		return
+	}
+	// 下载的是某一时刻的日志文件
+	query.Follow = false
+
+	stream, containerName, err := service.K8sPod.OpenPodLog(c.Request.Context(), clusterName, name, namespace, query)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+	defer stream.Close()
+
+	fileName := fmt.Sprintf("%s-%s.log", name, containerName)
+	if query.Previous {
+		fileName = fmt.Sprintf("%s-%s.previous.log", name, containerName)
+	}
+	c.DataFromReader(http.StatusOK, -1, "text/plain; charset=utf-8", stream, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="%s"`, fileName),
+	})
+}
+
+// DownloadDiagnosticBundle
+//
+//	@description	下载工作负载的诊断包(tar.gz), 包含所有Pod的YAML、事件、所有容器的日志和上一个实例的日志
+//	@tags			K8s,Pod
+//	@summary		下载诊断包
+//	@produce		octet-stream
+//	@param			clusterName		path	string	true	"Cluster Name"
+//	@param			namespace		path	string	true	"Namespace"
+//	@param			kind			query	string	false	"deployment或statefulset, 与labelSelector二选一"
+//	@param			name			query	string	false	"Deployment/StatefulSet名称"
+//	@param			labelSelector	query	string	false	"Pod标签选择器"
+//	@param			tailLines		query	int		false	"每个日志从最后多少行开始"
+//	@param			sinceSeconds	query	int		false	"最近多少秒的日志"
+//	@param			limitBytes		query	int		false	"每个日志文件的大小上限, 默认10MB"
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@success		200				file	file	"诊断包"
+//	@router			/api/v1/k8s/{clusterName}/log/{namespace}/bundle [get]
+func DownloadDiagnosticBundle(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	namespace := c.Param("namespace")
+
+	query := dto.K8sDiagnosticBundleQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &query).Error())
+		return
+	}
+
+	bundle, err := service.K8sPod.GetDiagnosticBundle(c.Request.Context(), clusterName, namespace, query)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	c.Header("Content-Type", "application/gzip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, bundle.Name))
+	c.Status(http.StatusOK)
+	// 响应头已经发送, 出错时只能中断下载
+	if err = bundle.WriteTo(c.Request.Context(), c.Writer); err != nil {
+		log.Error("生成诊断包失败: %s", err.Error())
+	}
+}
+
+// GetPodFileList
+//
+//	@description	列出容器中的目录
+//	@tags			K8s,Pod
+//	@summary		列出容器中的目录
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			podName			path	string					true	"Pod名称"
+//	@param			namespace		path	string					true	"Namespace"
+//	@param			containerName	query	string					false	"容器名,默认第1个容器"
+//	@param			path			query	string					true	"目录的绝对路径"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回文件列表"
+//	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/files [get]
+func GetPodFileList(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "podName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("podName")
+	namespace := c.Param("namespace")
+
+	query := dto.K8sPodFileQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &query).Error())
+		return
+	}
+
+	files, err := service.K8sPod.ListPodFiles(c.Request.Context(), clusterName, name, namespace, query)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, map[string]any{"total": len(files), "items": files}, "获取成功")
+}
+
+// attachmentWriter 第一次写入时才发送下载的响应头, 在此之前出错仍然可以返回错误响应
+type attachmentWriter struct {
+	c           *gin.Context
+	fileName    string
+	contentType string
+	started     bool
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.c.Header("Content-Type", w.contentType)
+		w.c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, w.fileName))
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(p)
+}
+
+// DownloadPodFile
+//
+//	@description	以tar格式下载容器中的文件或目录, 需要容器中有tar命令
+//	@tags			K8s,Pod
+//	@summary		下载容器中的文件
+//	@produce		octet-stream
+//	@param			clusterName		path	string	true	"Cluster Name"
+//	@param			podName			path	string	true	"Pod名称"
+//	@param			namespace		path	string	true	"Namespace"
+//	@param			containerName	query	string	false	"容器名,默认第1个容器"
+//	@param			path			query	string	true	"文件或目录的绝对路径"
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@success		200				file	file	"tar包"
+//	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/files/download [get]
+func DownloadPodFile(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "podName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("podName")
+	namespace := c.Param("namespace")
+
+	query := dto.K8sPodFileQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &query).Error())
+		return
+	}
+
+	fileName := path.Base(query.Path)
+	if fileName == "/" {
+		fileName = "root"
+	}
+	w := &attachmentWriter{c: c, fileName: fileName + ".tar", contentType: "application/x-tar"}
+	if err := service.K8sPod.DownloadPodFile(c.Request.Context(), clusterName, name, namespace, query, w); err != nil {
+		if !w.started {
+			httputil.Error(c, err.Error())
+			return
+		}
+		// 响应头已经发送, 只能中断下载
+		log.Error("下载容器文件失败: %s", err.Error())
+	}
+}
+
+// UploadPodFile
+//
+//	@description	上传文件到容器的目录中, 需要容器中有tar命令。archive=true时请求体是tar包, 解压到path目录; 否则请求体是单个文件的内容, 保存为path/fileName
+//	@tags			K8s,Pod
+//	@summary		上传文件到容器
+//	@accept			octet-stream
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			podName			path	string					true	"Pod名称"
+//	@param			namespace		path	string					true	"Namespace"
+//	@param			containerName	query	string					false	"容器名,默认第1个容器"
+//	@param			path			query	string					true	"目标目录的绝对路径"
+//	@param			fileName		query	string					false	"文件名, archive为false时必填"
+//	@param			archive			query	bool					false	"请求体是否是tar包"
+//	@param			file			body	string					true	"文件内容"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"上传成功"
+//	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/files/upload [put]
+func UploadPodFile(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "podName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("podName")
+	namespace := c.Param("namespace")
+
+	upload := dto.K8sPodFileUpload{}
+	if err := c.ShouldBindQuery(&upload); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &upload).Error())
+		return
+	}
+
+	err := service.K8sPod.UploadPodFile(c.Request.Context(), clusterName, name, namespace, upload, c.Request.Body, c.Request.ContentLength)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, nil, "上传成功")
+}
+
+// parsePort 解析路径中的端口号
+func parsePort(c *gin.Context) (int32, error) {
+	port, err := strconv.ParseInt(c.Param("port"), 10, 32)
+	if err != nil || port <= 0 || port > 65535 {
+		return 0, errors.NewBadRequest("端口必须是1-65535之间的数字")
+	}
+	return int32(port), nil
+}
+
+// StartPortForward
+//
+//	@description	创建到Pod端口的转发会话, 之后可以通过返回的proxyPath访问Pod端口, 会话空闲或达到最长时间后自动关闭
+//	@tags			K8s,Pod
+//	@summary		创建端口转发
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			podName			path	string					true	"Pod名称"
+//	@param			namespace		path	string					true	"Namespace"
+//	@param			port			path	int						true	"Pod端口"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回端口转发会话"
+//	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/portforward/{port} [post]
+func StartPortForward(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "podName", "port"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("podName")
+	namespace := c.Param("namespace")
+	port, err := parsePort(c)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	session, err := service.K8sPod.StartPortForward(clusterName, namespace, name, port, "", c.GetUint("userId"))
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, session, "创建成功")
+}
+
+// StopPortForward
+//
+//	@description	关闭自己的端口转发会话
+//	@tags			K8s,Pod
+//	@summary		关闭端口转发
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			podName			path	string					true	"Pod名称"
+//	@param			namespace		path	string					true	"Namespace"
+//	@param			port			path	int						true	"Pod端口"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"操作成功"
+//	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/portforward/{port} [delete]
+func StopPortForward(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "podName", "port"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("podName")
+	namespace := c.Param("namespace")
+	port, err := parsePort(c)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	if err = service.K8sPod.StopPortForward(clusterName, namespace, name, port, c.GetUint("userId")); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, nil, "操作成功")
+}
+
+// ProxyPortForward
+//
+//	@description	通过已创建的端口转发会话, 将请求反向代理到Pod端口, Authorization头不会转发给Pod
+//	@tags			K8s,Pod
+//	@summary		端口转发反向代理
+//	@param			clusterName		path	string	true	"Cluster Name"
+//	@param			podName			path	string	true	"Pod名称"
+//	@param			namespace		path	string	true	"Namespace"
+//	@param			port			path	int		true	"Pod端口"
+//	@param			path			path	string	true	"转发到Pod的路径"
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@success		200				object	nil		"Pod的响应"
+//	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/portforward/{port}/{path} [get]
+func ProxyPortForward(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "podName", "port"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("podName")
+	namespace := c.Param("namespace")
+	port, err := parsePort(c)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	err = service.K8sPod.ProxyPortForward(c.Writer, c.Request, clusterName, namespace, name, port, c.GetUint("userId"), c.Param("path"))
+	if err != nil {
+		httputil.ErrorWithCode(c, http.StatusNotFound, err.Error())
+	}
+}
+
+// GetPortForwardSessionList
+//
+//	@description	获取当前的端口转发会话, 管理员可以看到所有用户的会话, 其他用户只能看到自己的会话
+//	@tags			K8s,Pod
+//	@summary		获取端口转发会话列表
+//	@produce		json
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回会话列表"
+//	@router			/api/v1/k8s/portforward/ [get]
+func GetPortForwardSessionList(c *gin.Context) {
+	userId := c.GetUint("userId")
+	if service.SystemUser.IsAdmin(userId) {
+		userId = 0
+	}
+
+	sessions := service.K8sPod.ListPortForwardSessions(userId)
+
+	httputil.OK(c, map[string]any{"total": len(sessions), "items": sessions}, "获取成功")
+}
+
+// GetPortForwardRecordList
+//
+//	@description	分页查询端口转发审计记录, podName为模糊匹配, 时间为RFC3339格式; 非管理员只能查询自己的记录
+//	@tags			K8s,Pod
+//	@summary		查询端口转发审计记录
+//	@produce		json
+//	@param			clusterName		query	string						false	"集群名称"
+//	@param			namespace		query	string						false	"Namespace"
+//	@param			podName			query	string						false	"Pod名称"
+//	@param			username		query	string						false	"用户名"
+//	@param			startTime		query	string						false	"会话开始时间不早于"
+//	@param			endTime			query	string						false	"会话开始时间不晚于"
+//	@Param			limit			query	string						false	"分页大小,默认10"
+//	@Param			page			query	string						false	"获取第几页的数据,默认第一页"
+//	@Param			Authorization	header	string						true	"Authorization token"
+//	@success		200				object	httputil.PageResponseBody	"成功返回审计记录"
+//	@router			/api/v1/k8s/portforward/record [get]
+func GetPortForwardRecordList(c *gin.Context) {
+	query := dto.K8sPortForwardRecordQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &query).Error())
+		return
+	}
+	if userId := c.GetUint("userId"); !service.SystemUser.IsAdmin(userId) {
+		query.UserID = userId
+	}
+
+	httputil.Page(c, service.K8sPod.ListPortForwardRecord(query), "获取成功")
+}
+
 // GetPodContainers
 //
 //	@description	获取Pod容器信息
@@ -221,7 +831,7 @@ func GetPodContainers(c *gin.Context) {
 //	@param			containerName	query	string	false	"容器名,默认第1个容器"
 //	@Param			shell			query	string	false	"执行shell"
 //	@Param			Authorization	header	string	true	"Authorization token"
-//	@success		200				object	nil		"成功返回 sessionId 日志"
+//	@success		200				object	nil		"成功返回 sessionId 和一次性bind token"
 //	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/exec [get]
 func ExecContainer(c *gin.Context) {
 	if err := httputil.CheckParams(c, "clusterName", "namespace", "podName"); err != nil {
@@ -245,11 +855,176 @@ func ExecContainer(c *gin.Context) {
 
 	shell := c.Query("shell")
 
-	sessionID, err := service.K8sPod.StartTerminal(clusterName, namespace, name, containerName, shell)
+	sessionID, token, err := service.K8sPod.StartTerminal(clusterName, namespace, name, containerName, shell, c.GetUint("userId"))
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, gin.H{"id": sessionID, "token": token}, "获取成功")
+}
+
+// ExecPodCommand
+//
+//	@description	在容器中执行非交互命令, 不分配TTY, 返回stdout、stderr和退出码, 超时时exitCode为-1
+//	@tags			K8s,Pod
+//	@summary		执行命令
+//	@accept			json
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			podName			path	string					true	"Pod名称"
+//	@param			namespace		path	string					true	"Namespace"
+//	@param			data			body	dto.K8sPodExec			true	"命令参数"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	dto.K8sPodExecResult	"成功返回执行结果"
+//	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/exec [post]
+func ExecPodCommand(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "podName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("podName")
+	namespace := c.Param("namespace")
+
+	exec := dto.K8sPodExec{}
+	if err := c.ShouldBindJSON(&exec); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &exec).Error())
+		return
+	}
+
+	result, err := service.K8sPod.ExecPodCommand(c.Request.Context(), clusterName, namespace, name, exec, c.GetUint("userId"))
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, result, "执行完成")
+}
+
+// DebugContainer
+//
+//	@description	在Pod中添加临时调试容器并获取终端sessionId, 用于没有shell的镜像, 指定targetContainer时共享该容器的进程命名空间
+//	@tags			K8s,Pod
+//	@summary		临时调试容器
+//	@accept			json
+//	@produce		json
+//	@param			clusterName		path	string				true	"Cluster Name"
+//	@param			podName			path	string				true	"Pod名称"
+//	@param			namespace		path	string				true	"Namespace"
+//	@param			data			body	dto.K8sPodDebug		true	"调试参数"
+//	@Param			Authorization	header	string				true	"Authorization token"
+//	@success		200				object	dto.K8sDebugTerminal	"成功返回 sessionId 和一次性bind token"
+//	@router			/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/debug [post]
+func DebugContainer(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "podName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("podName")
+	namespace := c.Param("namespace")
+
+	debug := dto.K8sPodDebug{}
+	if err := c.ShouldBindJSON(&debug); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &debug).Error())
+		return
+	}
+
+	terminal, err := service.K8sPod.StartDebugTerminal(c.Request.Context(), clusterName, namespace, name, debug, c.GetUint("userId"))
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, terminal, "获取成功")
+}
+
+// DebugNode
+//
+//	@description	在节点上启动特权调试Pod并获取终端sessionId, 节点根目录挂载在/host, 终端关闭后删除调试Pod, 只有管理员可以使用
+//	@tags			K8s,Pod
+//	@summary		节点调试
+//	@accept			json
+//	@produce		json
+//	@param			clusterName		path	string				true	"Cluster Name"
+//	@param			nodeName		path	string				true	"节点名称"
+//	@param			data			body	dto.K8sNodeDebug	true	"调试参数"
+//	@Param			Authorization	header	string				true	"Authorization token"
+//	@success		200				object	dto.K8sDebugTerminal	"成功返回 sessionId 和一次性bind token"
+//	@router			/api/v1/k8s/{clusterName}/node/{nodeName}/debug [post]
+func DebugNode(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "nodeName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	userId := c.GetUint("userId")
+	// 调试Pod拥有节点的root权限
+	if !service.SystemUser.IsAdmin(userId) {
+		httputil.ErrorWithCode(c, http.StatusForbidden, "只有管理员可以调试节点")
+		return
+	}
+
+	debug := dto.K8sNodeDebug{}
+	if err := c.ShouldBindJSON(&debug); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &debug).Error())
+		return
+	}
+
+	terminal, err := service.K8sPod.StartNodeDebugTerminal(c.Request.Context(), c.Param("clusterName"), c.Param("nodeName"), debug, userId)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, terminal, "获取成功")
+}
+
+// GetTerminalSessionList
+//
+//	@description	获取当前打开的终端会话, 管理员可以看到所有用户的会话, 其他用户只能看到自己的会话
+//	@tags			K8s,Pod
+//	@summary		获取终端会话列表
+//	@produce		json
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回会话列表"
+//	@router			/api/v1/k8s/terminal/ [get]
+func GetTerminalSessionList(c *gin.Context) {
+	userId := c.GetUint("userId")
+	if service.SystemUser.IsAdmin(userId) {
+		userId = 0
+	}
+
+	sessions := service.K8sPod.ListTerminalSessions(userId)
+
+	httputil.OK(c, map[string]any{"total": len(sessions), "items": sessions}, "获取成功")
+}
+
+// KillTerminalSession
+//
+//	@description	强制结束终端会话, 管理员可以结束任意用户的会话, 其他用户只能结束自己的会话
+//	@tags			K8s,Pod
+//	@summary		强制结束终端会话
+//	@produce		json
+//	@param			sessionId		path	string					true	"会话ID"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"操作成功"
+//	@router			/api/v1/k8s/terminal/{sessionId} [delete]
+func KillTerminalSession(c *gin.Context) {
+	if err := httputil.CheckParams(c, "sessionId"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	userId := c.GetUint("userId")
+	err := service.K8sPod.KillTerminalSession(c.Param("sessionId"), userId, service.SystemUser.IsAdmin(userId))
 	if err != nil {
 		httputil.Error(c, err.Error())
 		return
 	}
 
-	httputil.OK(c, gin.H{"id": sessionID}, "获取成功")
+	httputil.OK(c, nil, "操作成功")
 }
@@ -0,0 +1,215 @@
+package scalepolicy
+
+import (
+	"github.com/gin-gonic/gin"
+	"soul/apis/dto"
+	"soul/apis/service"
+	"soul/utils/httputil"
+	"strconv"
+)
+
+// GetScalePolicyList
+//
+//	@description	获取定时扩缩容策略列表
+//	@tags			K8s,ScalePolicy
+//	@summary		获取定时扩缩容策略列表
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回策略列表"
+//	@router			/api/v1/k8s/{clusterName}/scalepolicy/ [get]
+func GetScalePolicyList(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+
+	policies := service.K8sScalePolicy.ListScalePolicy(clusterName)
+
+	httputil.OK(c, map[string]any{"total": len(policies), "items": policies}, "获取成功")
+}
+
+// GetScalePolicyById
+//
+//	@description	获取定时扩缩容策略
+//	@tags			K8s,ScalePolicy
+//	@summary		获取定时扩缩容策略
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			id				path	int						true	"策略ID"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回策略"
+//	@router			/api/v1/k8s/{clusterName}/scalepolicy/{id} [get]
+func GetScalePolicyById(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "id"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.Error(c, "策略ID格式错误")
+		return
+	}
+
+	policy, err := service.K8sScalePolicy.GetScalePolicyById(clusterName, uint(id))
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, policy, "获取成功")
+}
+
+// CreateScalePolicy
+//
+//	@description	创建定时扩缩容策略, deploymentName为空时作用于namespace下所有Deployment
+//	@tags			K8s,ScalePolicy
+//	@summary		创建定时扩缩容策略
+//	@produce		json
+//	@param			clusterName		path	string						true	"Cluster Name"
+//	@param			policy			body	dto.K8sScalePolicyCreate	true	"策略信息"
+//	@Param			Authorization	header	string						true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody		"成功返回策略"
+//	@router			/api/v1/k8s/{clusterName}/scalepolicy/ [post]
+func CreateScalePolicy(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+
+	create := dto.K8sScalePolicyCreate{}
+	if err := c.ShouldBindJSON(&create); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &create).Error())
+		return
+	}
+
+	policy, err := service.K8sScalePolicy.CreateScalePolicy(clusterName, create)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, policy, "创建成功")
+}
+
+// UpdateScalePolicy
+//
+//	@description	更新定时扩缩容策略
+//	@tags			K8s,ScalePolicy
+//	@summary		更新定时扩缩容策略
+//	@produce		json
+//	@param			clusterName		path	string						true	"Cluster Name"
+//	@param			id				path	int							true	"策略ID"
+//	@param			policy			body	dto.K8sScalePolicyCreate	true	"策略信息"
+//	@Param			Authorization	header	string						true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody		"成功返回策略"
+//	@router			/api/v1/k8s/{clusterName}/scalepolicy/{id} [put]
+func UpdateScalePolicy(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "id"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.Error(c, "策略ID格式错误")
+		return
+	}
+
+	create := dto.K8sScalePolicyCreate{}
+	if err = c.ShouldBindJSON(&create); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &create).Error())
+		return
+	}
+
+	policy, err := service.K8sScalePolicy.UpdateScalePolicy(clusterName, uint(id), create)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, policy, "更新成功")
+}
+
+// DeleteScalePolicy
+//
+//	@description	删除定时扩缩容策略
+//	@tags			K8s,ScalePolicy
+//	@summary		删除定时扩缩容策略
+//	@produce		json
+//	@param			clusterName		path	string	true	"Cluster Name"
+//	@param			id				path	int		true	"策略ID"
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@success		200				object	nil		"成功返回"
+//	@router			/api/v1/k8s/{clusterName}/scalepolicy/{id} [delete]
+func DeleteScalePolicy(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "id"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.Error(c, "策略ID格式错误")
+		return
+	}
+
+	if err = service.K8sScalePolicy.DeleteScalePolicy(clusterName, uint(id)); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, nil, "删除成功")
+}
+
+// GetScaleHistory
+//
+//	@description	获取定时扩缩容策略的执行记录
+//	@tags			K8s,ScalePolicy
+//	@summary		获取策略执行记录
+//	@produce		json
+//	@param			clusterName		path	string						true	"Cluster Name"
+//	@param			id				path	int							true	"策略ID"
+//	@Param			Authorization	header	string						true	"Authorization token"
+//	@Param			limit			query	string						false	"一页获取多少条数据,默认十条"
+//	@Param			page			query	string						false	"获取第几页的数据,默认第一页"
+//	@success		200				object	httputil.PageResponseBody	"成功返回执行记录"
+//	@router			/api/v1/k8s/{clusterName}/scalepolicy/{id}/history [get]
+func GetScaleHistory(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "id"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.Error(c, "策略ID格式错误")
+		return
+	}
+
+	params := new(struct {
+		Limit int `form:"limit,default=10"`
+		Page  int `form:"page,default=1"`
+	})
+	if err = c.ShouldBind(params); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, params).Error())
+		return
+	}
+
+	histories, err := service.K8sScalePolicy.ListScaleHistory(clusterName, uint(id), params.Limit, params.Page)
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.Page(c, histories, "获取成功")
+}
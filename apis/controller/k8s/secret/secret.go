@@ -7,6 +7,7 @@ import (
 	"soul/apis/dto"
 	"soul/apis/service"
 	"soul/utils/httputil"
+	"strconv"
 )
 
 // GetSecretByName
@@ -176,6 +177,7 @@ func CreateSecret(c *gin.Context) {
 //	@param			clusterName	path	string	true	"Cluster Name"
 //	@produce		json
 //	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			diff			query	bool	false	"只返回 dry-run 更新后的差异, 不实际更新"
 //	@Param			Authorization	header	string					true	"Authorization token"
 //	@param			data			body	dto.K8sSecretCreate		true	"K8sSecretCreate 对象"
 //	@success		200				object	httputil.ResponseBody	"成功返回"
@@ -195,6 +197,16 @@ func UpdateSecret(c *gin.Context) {
 		return
 	}
 
+	if diff, _ := strconv.ParseBool(c.Query("diff")); diff {
+		result, err := service.K8sSecret.DiffSecret(clusterName, &secret)
+		if err != nil {
+			httputil.Error(c, err.Error())
+			return
+		}
+		httputil.OK(c, result, "获取成功")
+		return
+	}
+
 	err := service.K8sSecret.UpdateSecret(clusterName, &secret)
 
 	if err != nil {
@@ -252,6 +264,7 @@ func CreateSecretForDockerRegistry(c *gin.Context) {
 //	@param			clusterName	path	string	true	"Cluster Name"
 //	@produce		json
 //	@param			clusterName		path	string									true	"Cluster Name"
+//	@param			diff			query	bool	false	"只返回 dry-run 更新后的差异, 不实际更新"
 //	@Param			Authorization	header	string									true	"Authorization token"
 //	@param			data			body	dto.K8sSecretForDockerRegistryCreate	true	"K8sSecretForDockerRegistryCreate 对象"
 //	@success		200				object	httputil.ResponseBody					"成功返回"
@@ -270,6 +283,16 @@ func UpdateSecretForDockerRegistry(c *gin.Context) {
 		return
 	}
 
+	if diff, _ := strconv.ParseBool(c.Query("diff")); diff {
+		result, err := service.K8sSecret.DiffSecretForDockerRegistry(clusterName, &secret)
+		if err != nil {
+			httputil.Error(c, err.Error())
+			return
+		}
+		httputil.OK(c, result, "获取成功")
+		return
+	}
+
 	err := service.K8sSecret.UpdateSecretForDockerRegistry(clusterName, &secret)
 
 	if err != nil {
@@ -327,6 +350,7 @@ func CreateSecretForTls(c *gin.Context) {
 //	@param			clusterName	path	string	true	"Cluster Name"
 //	@produce		json
 //	@param			clusterName		path	string						true	"Cluster Name"
+//	@param			diff			query	bool	false	"只返回 dry-run 更新后的差异, 不实际更新"
 //	@Param			Authorization	header	string						true	"Authorization token"
 //	@param			data			body	dto.K8sSecretForTlsCreate	true	"K8sSecretForTlsCreate 对象"
 //	@success		200				object	httputil.ResponseBody		"成功返回"
@@ -346,6 +370,16 @@ func UpdateSecretForTls(c *gin.Context) {
 		return
 	}
 
+	if diff, _ := strconv.ParseBool(c.Query("diff")); diff {
+		result, err := service.K8sSecret.DiffSecretForTls(clusterName, &secret)
+		if err != nil {
+			httputil.Error(c, err.Error())
+			return
+		}
+		httputil.OK(c, result, "获取成功")
+		return
+	}
+
 	err := service.K8sSecret.UpdateSecretForTls(clusterName, &secret)
 
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"soul/apis/dto"
 	"soul/apis/service"
 	"soul/utils/httputil"
+	"strconv"
 )
 
 // GetSvcByName
@@ -41,6 +42,38 @@ func GetSvcByName(c *gin.Context) {
 	httputil.OK(c, svc, "获取成功")
 }
 
+// GetSvcDetail
+//
+//	@description	获取Svc的EndpointSlice、后端Pod和引用它的Ingress, 并检查selector和targetPort是否能匹配到Pod
+//	@tags			K8s,Svc
+//	@summary		获取Svc连通性详情
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			svcName			path	string					true	"Svc名称"
+//	@param			namespace		path	string					true	"Namespace"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回 dto.K8sSvcDetail"
+//	@router			/api/v1/k8s/{clusterName}/svc/{namespace}/{svcName}/detail [get]
+func GetSvcDetail(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "svcName"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("svcName")
+	namespace := c.Param("namespace")
+
+	detail, err := service.K8sSvc.GetSvcDetail(c.Request.Context(), clusterName, name, namespace)
+
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, detail, "获取成功")
+}
+
 // GetSvcList
 //
 //	@description	获取Svc列表
@@ -175,6 +208,7 @@ func CreateSimpleSvc(c *gin.Context) {
 //	@param			clusterName	path	string	true	"Cluster Name"
 //	@produce		json
 //	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			diff			query	bool	false	"只返回 dry-run 更新后的差异, 不实际更新"
 //	@Param			Authorization	header	string					true	"Authorization token"
 //	@param			data			body	dto.K8sSvcSimpleCreate	true	"K8sSvcSimpleCreate 对象"
 //	@success		200				object	httputil.ResponseBody	"成功返回"
@@ -193,6 +227,16 @@ func UpdateSimpleSvc(c *gin.Context) {
 		return
 	}
 
+	if diff, _ := strconv.ParseBool(c.Query("diff")); diff {
+		result, err := service.K8sSvc.DiffSimpleSvc(clusterName, &svc)
+		if err != nil {
+			httputil.Error(c, err.Error())
+			return
+		}
+		httputil.OK(c, result, "获取成功")
+		return
+	}
+
 	err := service.K8sSvc.UpdateSimpleSvc(clusterName, &svc)
 
 	if err != nil {
@@ -202,3 +246,47 @@ func UpdateSimpleSvc(c *gin.Context) {
 
 	httputil.OK(c, nil, "更新成功")
 }
+
+// StartSvcPortForward
+//
+//	@description	创建到Service端口的转发会话, 与kubectl port-forward svc/name一样选择一个就绪的Pod, 返回的proxyPath指向该Pod
+//	@tags			K8s,Svc
+//	@summary		创建Service端口转发
+//	@produce		json
+//	@param			clusterName		path	string					true	"Cluster Name"
+//	@param			svcName			path	string					true	"Service名称"
+//	@param			namespace		path	string					true	"Namespace"
+//	@param			port			path	int						true	"Service端口"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回端口转发会话"
+//	@router			/api/v1/k8s/{clusterName}/svc/{namespace}/{svcName}/portforward/{port} [post]
+func StartSvcPortForward(c *gin.Context) {
+	if err := httputil.CheckParams(c, "clusterName", "namespace", "svcName", "port"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	clusterName := c.Param("clusterName")
+	name := c.Param("svcName")
+	namespace := c.Param("namespace")
+	port, err := strconv.ParseInt(c.Param("port"), 10, 32)
+	if err != nil || port <= 0 || port > 65535 {
+		httputil.Error(c, "端口必须是1-65535之间的数字")
+		return
+	}
+
+	podName, podPort, err := service.K8sSvc.ResolvePortForwardTarget(clusterName, name, namespace, int32(port))
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	target := fmt.Sprintf("service/%s:%d", name, port)
+	session, err := service.K8sPod.StartPortForward(clusterName, namespace, podName, podPort, target, c.GetUint("userId"))
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, session, "创建成功")
+}
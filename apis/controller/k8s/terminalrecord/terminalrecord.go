@@ -0,0 +1,154 @@
+package terminalrecord
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"soul/apis/dto"
+	"soul/apis/service"
+	"soul/utils/httputil"
+	"strconv"
+)
+
+// GetTerminalRecordList
+//
+//	@description	分页查询终端录像, podName为模糊匹配, 时间为RFC3339格式; 非管理员只能查询自己的录像
+//	@tags			K8s,TerminalRecord
+//	@summary		查询终端录像
+//	@produce		json
+//	@param			clusterName		query	string						false	"集群名称"
+//	@param			namespace		query	string						false	"Namespace"
+//	@param			podName			query	string						false	"Pod名称"
+//	@param			containerName	query	string						false	"容器名称"
+//	@param			username		query	string						false	"用户名"
+//	@param			startTime		query	string						false	"会话开始时间不早于"
+//	@param			endTime			query	string						false	"会话开始时间不晚于"
+//	@Param			limit			query	string						false	"分页大小,默认10"
+//	@Param			page			query	string						false	"获取第几页的数据,默认第一页"
+//	@Param			Authorization	header	string						true	"Authorization token"
+//	@success		200				object	httputil.PageResponseBody	"成功返回录像列表"
+//	@router			/api/v1/k8s/terminalrecord/ [get]
+func GetTerminalRecordList(c *gin.Context) {
+	query := dto.K8sTerminalRecordQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		httputil.Error(c, httputil.ParseValidateError(err, &query).Error())
+		return
+	}
+	if userId := c.GetUint("userId"); !service.SystemUser.IsAdmin(userId) {
+		query.UserID = userId
+	}
+
+	httputil.Page(c, service.K8sTerminalRecord.ListTerminalRecord(query), "获取成功")
+}
+
+// GetTerminalRecordById
+//
+//	@description	获取终端录像信息, 非管理员只能获取自己的录像
+//	@tags			K8s,TerminalRecord
+//	@summary		获取终端录像信息
+//	@produce		json
+//	@param			id				path	int						true	"录像ID"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"成功返回录像信息"
+//	@router			/api/v1/k8s/terminalrecord/{id} [get]
+func GetTerminalRecordById(c *gin.Context) {
+	if err := httputil.CheckParams(c, "id"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.Error(c, "录像ID格式错误")
+		return
+	}
+
+	record, err := service.K8sTerminalRecord.GetTerminalRecordById(uint(id), recordOwner(c))
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, record, "获取成功")
+}
+
+// ReplayTerminalRecord
+//
+//	@description	获取asciicast v2格式的录像内容, 可以直接交给asciinema-player回放; download=true时以附件下载; 非管理员只能回放自己的录像
+//	@tags			K8s,TerminalRecord
+//	@summary		回放终端录像
+//	@produce		octet-stream
+//	@param			id				path	int		true	"录像ID"
+//	@param			download		query	bool	false	"以附件下载"
+//	@Param			Authorization	header	string	true	"Authorization token"
+//	@success		200				file	file	"asciicast录像"
+//	@router			/api/v1/k8s/terminalrecord/{id}/cast [get]
+func ReplayTerminalRecord(c *gin.Context) {
+	if err := httputil.CheckParams(c, "id"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.Error(c, "录像ID格式错误")
+		return
+	}
+
+	record, file, err := service.K8sTerminalRecord.OpenTerminalRecord(uint(id), recordOwner(c))
+	if err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+	defer file.Close()
+
+	headers := map[string]string{}
+	if download, _ := strconv.ParseBool(c.Query("download")); download {
+		headers["Content-Disposition"] = fmt.Sprintf(`attachment; filename="%s.cast"`, record.SessionID)
+	}
+	c.DataFromReader(http.StatusOK, -1, "application/x-asciicast", file, headers)
+}
+
+// DeleteTerminalRecord
+//
+//	@description	删除终端录像记录和文件, 只有管理员可以删除
+//	@tags			K8s,TerminalRecord
+//	@summary		删除终端录像
+//	@produce		json
+//	@param			id				path	int						true	"录像ID"
+//	@Param			Authorization	header	string					true	"Authorization token"
+//	@success		200				object	httputil.ResponseBody	"删除成功"
+//	@router			/api/v1/k8s/terminalrecord/{id} [delete]
+func DeleteTerminalRecord(c *gin.Context) {
+	if err := httputil.CheckParams(c, "id"); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	if !service.SystemUser.IsAdmin(c.GetUint("userId")) {
+		httputil.ErrorWithCode(c, http.StatusForbidden, "只有管理员可以删除录像")
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		httputil.Error(c, "录像ID格式错误")
+		return
+	}
+
+	if err = service.K8sTerminalRecord.DeleteTerminalRecord(uint(id)); err != nil {
+		httputil.Error(c, err.Error())
+		return
+	}
+
+	httputil.OK(c, nil, "删除成功")
+}
+
+// recordOwner 管理员返回0可以访问所有录像, 其他用户只能访问自己的录像
+func recordOwner(c *gin.Context) uint {
+	userId := c.GetUint("userId")
+	if service.SystemUser.IsAdmin(userId) {
+		return 0
+	}
+	return userId
+}
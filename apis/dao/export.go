@@ -6,8 +6,13 @@ import (
 )
 
 var (
-	SystemUser     system.User
-	SystemRole     system.Role
-	SystemInitData system.InitData
-	K8sCluster     k8s.Cluster
+	SystemUser        system.User
+	SystemRole        system.Role
+	SystemInitData    system.InitData
+	K8sCluster        k8s.Cluster
+	K8sScalePolicy    k8s.ScalePolicy
+	K8sApplication    k8s.Application
+	K8sAppTemplate    k8s.AppTemplate
+	K8sTerminalRecord k8s.TerminalRecord
+	K8sPortForward    k8s.PortForward
 )
@@ -0,0 +1,151 @@
+package k8s
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"soul/global"
+	log "soul/internal/logger"
+	"soul/model"
+)
+
+type AppTemplate struct{}
+
+// CreateAppTemplate 创建模板, 同时保存第一个版本
+func (a *AppTemplate) CreateAppTemplate(template *model.K8sAppTemplate) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(template).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.K8sAppTemplateVersion{
+			TemplateID: template.ID.ID,
+			Version:    template.Version,
+			Parameters: template.Parameters,
+			Content:    template.Content,
+		}).Error
+	})
+}
+
+// UpdateAppTemplate 更新模板, newVersion为true时保存新版本
+func (a *AppTemplate) UpdateAppTemplate(template *model.K8sAppTemplate, newVersion bool) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Model(&model.K8sAppTemplate{}).
+			Select("*").
+			Omit("id", "created_at").
+			Where("id = ?", template.ID.ID).
+			Updates(*template).Error
+		if err != nil || !newVersion {
+			return err
+		}
+		return tx.Create(&model.K8sAppTemplateVersion{
+			TemplateID: template.ID.ID,
+			Version:    template.Version,
+			Parameters: template.Parameters,
+			Content:    template.Content,
+		}).Error
+	})
+}
+
+func (a *AppTemplate) GetAppTemplateById(id uint) *model.K8sAppTemplate {
+	template := &model.K8sAppTemplate{}
+	if err := global.DB.First(template, id).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error(err.Error())
+		}
+		return nil
+	}
+	return template
+}
+
+func (a *AppTemplate) GetAppTemplateByName(name string) *model.K8sAppTemplate {
+	template := &model.K8sAppTemplate{}
+	if err := global.DB.Where("name = ?", name).First(template).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error(err.Error())
+		}
+		return nil
+	}
+	return template
+}
+
+func (a *AppTemplate) ListAppTemplate() (templates []model.K8sAppTemplate) {
+	global.DB.Order("name").Find(&templates)
+	return
+}
+
+// DeleteAppTemplateById 删除模板及其所有版本
+func (a *AppTemplate) DeleteAppTemplateById(id uint) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("template_id = ?", id).Delete(&model.K8sAppTemplateVersion{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&model.K8sAppTemplate{}, id).Error
+	})
+}
+
+func (a *AppTemplate) GetAppTemplateVersion(templateId, version uint) *model.K8sAppTemplateVersion {
+	templateVersion := &model.K8sAppTemplateVersion{}
+	err := global.DB.Where("template_id = ? and version = ?", templateId, version).First(templateVersion).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error(err.Error())
+		}
+		return nil
+	}
+	return templateVersion
+}
+
+func (a *AppTemplate) ListAppTemplateVersion(templateId uint) (versions []model.K8sAppTemplateVersion) {
+	global.DB.Where("template_id = ?", templateId).Order("version desc").Find(&versions)
+	return
+}
+
+func (a *AppTemplate) CreateTemplateInstance(instance *model.K8sTemplateInstance) error {
+	return global.DB.Create(instance).Error
+}
+
+func (a *AppTemplate) UpdateTemplateInstance(instance *model.K8sTemplateInstance) error {
+	result := global.DB.
+		Model(&model.K8sTemplateInstance{}).
+		Select("*").
+		Omit("id", "created_at").
+		Where("id = ?", instance.ID.ID).
+		Updates(*instance)
+	return result.Error
+}
+
+func (a *AppTemplate) GetTemplateInstance(clusterName, namespace, name string) *model.K8sTemplateInstance {
+	instance := &model.K8sTemplateInstance{}
+	err := global.DB.
+		Where("cluster_name = ? and namespace = ? and name = ?", clusterName, namespace, name).
+		First(instance).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error(err.Error())
+		}
+		return nil
+	}
+	return instance
+}
+
+// ListTemplateInstance namespace为空时返回集群下所有实例
+func (a *AppTemplate) ListTemplateInstance(clusterName, namespace string) (instances []model.K8sTemplateInstance) {
+	tx := global.DB.Where("cluster_name = ?", clusterName)
+	if namespace != "" {
+		tx = tx.Where("namespace = ?", namespace)
+	}
+	tx.Order("namespace, name").Find(&instances)
+	return
+}
+
+func (a *AppTemplate) CountTemplateInstance(templateId uint) (count int64) {
+	global.DB.Model(&model.K8sTemplateInstance{}).Where("template_id = ?", templateId).Count(&count)
+	return
+}
+
+func (a *AppTemplate) DeleteTemplateInstance(clusterName, namespace, name string) error {
+	return global.DB.
+		Unscoped().
+		Where("cluster_name = ? and namespace = ? and name = ?", clusterName, namespace, name).
+		Delete(&model.K8sTemplateInstance{}).Error
+}
@@ -0,0 +1,54 @@
+package k8s
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"soul/global"
+	log "soul/internal/logger"
+	"soul/model"
+)
+
+type Application struct{}
+
+func (a *Application) CreateApplication(app *model.K8sApplication) error {
+	result := global.DB.Create(app)
+	return result.Error
+}
+
+func (a *Application) GetApplication(clusterName, namespace, name string) *model.K8sApplication {
+	app := &model.K8sApplication{}
+	err := global.DB.
+		Where("cluster_name = ? and namespace = ? and name = ?", clusterName, namespace, name).
+		First(app).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error(err.Error())
+		}
+		return nil
+	}
+	return app
+}
+
+// ListApplication namespace为空时返回集群下所有应用
+func (a *Application) ListApplication(clusterName, namespace string) (apps []model.K8sApplication) {
+	tx := global.DB.Where("cluster_name = ?", clusterName)
+	if namespace != "" {
+		tx = tx.Where("namespace = ?", namespace)
+	}
+	tx.Order("namespace, name").Find(&apps)
+	return
+}
+
+func (a *Application) DeleteApplicationById(id uint) error {
+	return global.DB.Unscoped().Delete(&model.K8sApplication{}, id).Error
+}
+
+func (a *Application) UpdateApplication(app *model.K8sApplication) error {
+	result := global.DB.
+		Model(&model.K8sApplication{}).
+		Select("*").
+		Omit("id", "created_at").
+		Where("id = ?", app.ID.ID).
+		Updates(*app)
+	return result.Error
+}
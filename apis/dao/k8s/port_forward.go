@@ -0,0 +1,52 @@
+package k8s
+
+import (
+	"soul/apis/dto"
+	"soul/global"
+	"soul/model"
+	"time"
+)
+
+type PortForward struct{}
+
+func (p *PortForward) CreatePortForwardRecord(record *model.K8sPortForwardRecord) error {
+	return global.DB.Create(record).Error
+}
+
+// FinishPortForwardRecord 会话关闭时记录结束时间、请求数和关闭原因
+func (p *PortForward) FinishPortForwardRecord(sessionId string, endedAt time.Time, requests int64, reason string) error {
+	return global.DB.
+		Model(&model.K8sPortForwardRecord{}).
+		Where("session_id = ?", sessionId).
+		Updates(map[string]any{"ended_at": endedAt, "requests": requests, "close_reason": reason}).Error
+}
+
+// ListPortForwardRecord 按条件分页查询审计记录, 按开始时间倒序
+func (p *PortForward) ListPortForwardRecord(query dto.K8sPortForwardRecordQuery) (records []model.K8sPortForwardRecord, total int64) {
+	tx := global.DB.Model(&model.K8sPortForwardRecord{})
+	if query.ClusterName != "" {
+		tx = tx.Where("cluster_name = ?", query.ClusterName)
+	}
+	if query.Namespace != "" {
+		tx = tx.Where("namespace = ?", query.Namespace)
+	}
+	if query.PodName != "" {
+		tx = tx.Where("pod_name like ?", "%"+query.PodName+"%")
+	}
+	if query.Username != "" {
+		tx = tx.Where("username = ?", query.Username)
+	}
+	if query.UserID != 0 {
+		tx = tx.Where("user_id = ?", query.UserID)
+	}
+	if !query.StartTime.IsZero() {
+		tx = tx.Where("started_at >= ?", query.StartTime)
+	}
+	if !query.EndTime.IsZero() {
+		tx = tx.Where("started_at <= ?", query.EndTime)
+	}
+
+	tx.Count(&total)
+	tx.Order("started_at desc").Limit(query.Limit).Offset(query.Limit * (query.Page - 1)).Find(&records)
+	return
+}
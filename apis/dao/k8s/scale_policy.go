@@ -0,0 +1,107 @@
+package k8s
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"soul/global"
+	log "soul/internal/logger"
+	"soul/model"
+)
+
+type ScalePolicy struct{}
+
+func (s *ScalePolicy) CreateScalePolicy(policy *model.K8sScalePolicy) error {
+	result := global.DB.Create(policy)
+	return result.Error
+}
+
+func (s *ScalePolicy) UpdateScalePolicy(policy *model.K8sScalePolicy) error {
+	result := global.DB.
+		Model(&model.K8sScalePolicy{}).
+		Select("*").
+		Omit("id", "created_at").
+		Where("id = ?", policy.ID.ID).
+		Updates(*policy)
+	return result.Error
+}
+
+func (s *ScalePolicy) GetScalePolicyById(id uint) *model.K8sScalePolicy {
+	policy := &model.K8sScalePolicy{}
+	if err := global.DB.First(policy, id).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error(err.Error())
+		}
+		return nil
+	}
+	return policy
+}
+
+func (s *ScalePolicy) GetScalePolicyByName(name string) *model.K8sScalePolicy {
+	policy := &model.K8sScalePolicy{}
+	if err := global.DB.Where("name = ?", name).First(policy).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error(err.Error())
+		}
+		return nil
+	}
+	return policy
+}
+
+// ListScalePolicy clusterName为空时返回所有集群的策略
+func (s *ScalePolicy) ListScalePolicy(clusterName string) (policies []model.K8sScalePolicy) {
+	tx := global.DB
+	if clusterName != "" {
+		tx = tx.Where("cluster_name = ?", clusterName)
+	}
+	tx.Find(&policies)
+	return
+}
+
+func (s *ScalePolicy) DeleteScalePolicyById(id uint) error {
+	return global.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("policy_id = ?", id).Delete(&model.K8sScaleRecord{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&model.K8sScalePolicy{}, id).Error
+	})
+}
+
+// GetScaleRecord 获取缩容前记录的原始副本数
+func (s *ScalePolicy) GetScaleRecord(policyId uint, namespace, deploymentName string) *model.K8sScaleRecord {
+	record := &model.K8sScaleRecord{}
+	err := global.DB.
+		Where("policy_id = ? and namespace = ? and deployment_name = ?", policyId, namespace, deploymentName).
+		First(record).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error(err.Error())
+		}
+		return nil
+	}
+	return record
+}
+
+func (s *ScalePolicy) ListScaleRecord(policyId uint) (records []model.K8sScaleRecord) {
+	global.DB.Where("policy_id = ?", policyId).Find(&records)
+	return
+}
+
+func (s *ScalePolicy) CreateScaleRecord(record *model.K8sScaleRecord) error {
+	return global.DB.Create(record).Error
+}
+
+func (s *ScalePolicy) DeleteScaleRecord(id uint) error {
+	return global.DB.Delete(&model.K8sScaleRecord{}, id).Error
+}
+
+func (s *ScalePolicy) CreateScaleHistory(history *model.K8sScaleHistory) error {
+	return global.DB.Create(history).Error
+}
+
+// ListScaleHistory 分页获取策略执行记录, 按时间倒序
+func (s *ScalePolicy) ListScaleHistory(policyId uint, limit, page int) (histories []model.K8sScaleHistory, total int64) {
+	tx := global.DB.Model(&model.K8sScaleHistory{}).Where("policy_id = ?", policyId)
+	tx.Count(&total)
+	tx.Order("id desc").Limit(limit).Offset(limit * (page - 1)).Find(&histories)
+	return
+}
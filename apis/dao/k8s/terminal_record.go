@@ -0,0 +1,73 @@
+package k8s
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"soul/apis/dto"
+	"soul/global"
+	log "soul/internal/logger"
+	"soul/model"
+	"time"
+)
+
+type TerminalRecord struct{}
+
+func (t *TerminalRecord) CreateTerminalRecord(record *model.K8sTerminalRecord) error {
+	return global.DB.Create(record).Error
+}
+
+// FinishTerminalRecord 会话结束时记录结束时间和录像大小
+func (t *TerminalRecord) FinishTerminalRecord(sessionId string, endedAt time.Time, size int64) error {
+	return global.DB.
+		Model(&model.K8sTerminalRecord{}).
+		Where("session_id = ?", sessionId).
+		Updates(map[string]any{"ended_at": endedAt, "size": size}).Error
+}
+
+func (t *TerminalRecord) GetTerminalRecordById(id uint) *model.K8sTerminalRecord {
+	record := &model.K8sTerminalRecord{}
+	if err := global.DB.First(record, id).Error; err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Error(err.Error())
+		}
+		return nil
+	}
+	return record
+}
+
+// ListTerminalRecord 按条件分页查询录像, 按开始时间倒序
+func (t *TerminalRecord) ListTerminalRecord(query dto.K8sTerminalRecordQuery) (records []model.K8sTerminalRecord, total int64) {
+	tx := global.DB.Model(&model.K8sTerminalRecord{})
+	if query.ClusterName != "" {
+		tx = tx.Where("cluster_name = ?", query.ClusterName)
+	}
+	if query.Namespace != "" {
+		tx = tx.Where("namespace = ?", query.Namespace)
+	}
+	if query.PodName != "" {
+		tx = tx.Where("pod_name like ?", "%"+query.PodName+"%")
+	}
+	if query.ContainerName != "" {
+		tx = tx.Where("container_name = ?", query.ContainerName)
+	}
+	if query.Username != "" {
+		tx = tx.Where("username = ?", query.Username)
+	}
+	if query.UserID != 0 {
+		tx = tx.Where("user_id = ?", query.UserID)
+	}
+	if !query.StartTime.IsZero() {
+		tx = tx.Where("started_at >= ?", query.StartTime)
+	}
+	if !query.EndTime.IsZero() {
+		tx = tx.Where("started_at <= ?", query.EndTime)
+	}
+
+	tx.Count(&total)
+	tx.Order("started_at desc").Limit(query.Limit).Offset(query.Limit * (query.Page - 1)).Find(&records)
+	return
+}
+
+func (t *TerminalRecord) DeleteTerminalRecordById(id uint) error {
+	return global.DB.Delete(&model.K8sTerminalRecord{}, id).Error
+}
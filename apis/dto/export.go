@@ -12,13 +12,65 @@ type (
 	SystemUserInfo                   = system.UserInfo
 	SystemRoleInfo                   = system.RoleInfo
 	K8sDeploymentCreate              = k8s.DeploymentCreate
+	K8sDeploymentPromote             = k8s.DeploymentPromote
+	K8sPromoteItem                   = k8s.PromoteItem
 	K8sSetImage                      = k8s.SetImage
+	K8sPodLogQuery                   = k8s.PodLogQuery
+	K8sDiagnosticBundleQuery         = k8s.DiagnosticBundleQuery
+	K8sTerminalRecordQuery           = k8s.TerminalRecordQuery
+	K8sTerminalSessionInfo           = k8s.TerminalSessionInfo
+	K8sPortForwardRecordQuery        = k8s.PortForwardRecordQuery
+	K8sPortForwardSession            = k8s.PortForwardSession
+	K8sAggregatedLogQuery            = k8s.AggregatedLogQuery
+	K8sPodFileQuery                  = k8s.PodFileQuery
+	K8sPodFileUpload                 = k8s.PodFileUpload
+	K8sPodFile                       = k8s.PodFile
+	K8sPodDebug                      = k8s.PodDebug
+	K8sNodeDebug                     = k8s.NodeDebug
+	K8sDebugTerminal                 = k8s.DebugTerminal
+	K8sPodExec                       = k8s.PodExec
+	K8sPodExecResult                 = k8s.PodExecResult
+	K8sBatchExecResult               = k8s.BatchExecResult
+	K8sPodBulkOperation              = k8s.PodBulkOperation
+	K8sPodOperationResult            = k8s.PodOperationResult
+	K8sPodBulkResult                 = k8s.PodBulkResult
+	K8sDiagnosticFinding             = k8s.DiagnosticFinding
+	K8sPodDiagnosis                  = k8s.PodDiagnosis
+	K8sDeploymentDiagnosis           = k8s.DeploymentDiagnosis
+	K8sContainerMetrics              = k8s.ContainerMetrics
+	K8sPodMetrics                    = k8s.PodMetrics
+	K8sPodWithMetrics                = k8s.PodWithMetrics
+	K8sWorkloadMetrics               = k8s.WorkloadMetrics
+	K8sDeploymentWithMetrics         = k8s.DeploymentWithMetrics
+	K8sNodeUsage                     = k8s.NodeUsage
+	K8sNodeMetrics                   = k8s.NodeMetrics
 	K8sIngressSimpleCreate           = k8s.IngressSimpleCreate
+	K8sIngressValidation             = k8s.IngressValidation
+	K8sIngressLint                   = k8s.IngressLint
 	K8sSvcSimpleCreate               = k8s.SvcSimpleCreate
+	K8sSvcDetail                     = k8s.SvcDetail
+	K8sSvcEndpoint                   = k8s.SvcEndpoint
+	K8sSvcEndpointPort               = k8s.SvcEndpointPort
+	K8sSvcIngressRef                 = k8s.SvcIngressRef
+	K8sHTTPRouteSimpleCreate         = k8s.HTTPRouteSimpleCreate
+	K8sHTTPRouteParentStatus         = k8s.HTTPRouteParentStatus
+	K8sHTTPRouteStatus               = k8s.HTTPRouteStatus
 	K8sSecretCreate                  = k8s.SecretCreate
 	K8sSecretForDockerRegistryCreate = k8s.SecretForDockerRegistryCreate
 	K8sSecretForTlsCreate            = k8s.SecretForTlsCreate
 	K8sClusterCreate                 = k8s.ClusterCreate
 	K8sClusterInfo                   = k8s.ClusterInfo
+	K8sScalePolicyCreate             = k8s.ScalePolicyCreate
+	K8sApplicationCreate             = k8s.ApplicationCreate
+	K8sTemplateParameter             = k8s.TemplateParameter
+	K8sAppTemplateCreate             = k8s.AppTemplateCreate
+	K8sTemplateInstanceCreate        = k8s.TemplateInstanceCreate
+	K8sTemplateInstanceUpgrade       = k8s.TemplateInstanceUpgrade
+	K8sHelmInstall                   = k8s.HelmInstall
+	K8sHelmUpgrade                   = k8s.HelmUpgrade
+	K8sHelmRollback                  = k8s.HelmRollback
+	K8sHelmRelease                   = k8s.HelmRelease
+	K8sHelmReleaseDetail             = k8s.HelmReleaseDetail
+	K8sHelmChart                     = k8s.HelmChart
 	//SystemUserInfo system.UserInfo
 )
@@ -0,0 +1,34 @@
+package k8s
+
+type TemplateParameter struct {
+	Name        string   `json:"name" binding:"required" msg:"参数名不能为空"`
+	Label       string   `json:"label"`
+	Description string   `json:"description"`
+	Type        string   `json:"type" binding:"required,oneof=string int bool image domain cpu memory" msg:"参数类型只能是string、int、bool、image、domain、cpu、memory"`
+	Required    bool     `json:"required"`
+	Default     any      `json:"default"`
+	Options     []string `json:"options"`
+}
+
+// AppTemplateCreate 应用模板, content使用text/template语法, 渲染后为 K8sApplicationCreate 格式的json,
+// 参数通过 {{ .参数名 }} 引用, 字符串可以使用 {{ json .参数名 }} 输出带引号并转义的json字符串
+type AppTemplateCreate struct {
+	Name        string              `json:"name" binding:"required" msg:"模板名称不能为空"`
+	Description string              `json:"description"`
+	Parameters  []TemplateParameter `json:"parameters" binding:"dive"`
+	Content     string              `json:"content" binding:"required" msg:"模板内容不能为空"`
+}
+
+type TemplateInstanceCreate struct {
+	TemplateID uint           `json:"templateId" binding:"required" msg:"模板ID不能为空"`
+	Version    uint           `json:"version"` // 为空时使用最新版本
+	Name       string         `json:"name" binding:"required" msg:"应用名称不能为空"`
+	Namespace  string         `json:"namespace" binding:"required" msg:"Namespace不能为空"`
+	Values     map[string]any `json:"values"`
+}
+
+// TemplateInstanceUpgrade 使用新版本模板或新参数重新渲染应用, values会与之前的参数合并
+type TemplateInstanceUpgrade struct {
+	Version uint           `json:"version"` // 为空时使用最新版本
+	Values  map[string]any `json:"values"`
+}
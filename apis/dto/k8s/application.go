@@ -0,0 +1,88 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type applicationIngress struct {
+	IngressClassName string            `json:"ingressClassName"`
+	Hosts            []string          `json:"hosts"`
+	Path             string            `json:"path"`
+	ServicePort      int32             `json:"servicePort"` // 默认第一个容器端口
+	Annotations      map[string]string `json:"annotations"`
+	Tls              []tlsConfig       `json:"tls"`
+}
+
+// ApplicationCreate 应用, 由一个Deployment、根据容器端口生成的Service和可选的Ingress组成,
+// 三者名称与应用名称相同
+type ApplicationCreate struct {
+	DeploymentCreate
+	Description string              `json:"description"`
+	Ingress     *applicationIngress `json:"ingress"`
+}
+
+// ToSvcSimpleCreate 根据所有容器的端口生成Service, 没有端口时返回nil
+func (a *ApplicationCreate) ToSvcSimpleCreate(labels map[string]string) *SvcSimpleCreate {
+	a.SetLegacyContainer()
+	var svcPorts []ports
+	for _, c := range a.Containers {
+		for _, p := range c.Ports {
+			protocol := p.Protocol
+			if protocol == "" {
+				protocol = "TCP"
+			}
+			name := p.Name
+			if name == "" {
+				name = fmt.Sprintf("%s-%d", strings.ToLower(protocol), p.Port)
+			}
+			svcPorts = append(svcPorts, ports{Name: name, Protocol: protocol, ContainerPort: p.Port})
+		}
+	}
+	if len(svcPorts) == 0 {
+		return nil
+	}
+
+	return &SvcSimpleCreate{
+		Name:      a.Name,
+		Namespace: a.Namespace,
+		Labels:    labels,
+		Selector:  labels,
+		Ports:     svcPorts,
+	}
+}
+
+// ToIngressSimpleCreate 生成指向应用Service的Ingress, 未配置ingress时返回nil
+func (a *ApplicationCreate) ToIngressSimpleCreate(labels map[string]string, svc *SvcSimpleCreate) (*IngressSimpleCreate, error) {
+	if a.Ingress == nil {
+		return nil, nil
+	}
+	if svc == nil {
+		return nil, errors.New("容器没有配置端口, 无法创建Ingress")
+	}
+
+	servicePort := a.Ingress.ServicePort
+	if servicePort == 0 {
+		servicePort = svc.Ports[0].ContainerPort
+	}
+	path := a.Ingress.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return &IngressSimpleCreate{
+		Name:             a.Name,
+		Namespace:        a.Namespace,
+		Labels:           labels,
+		Annotations:      a.Ingress.Annotations,
+		IngressClassName: a.Ingress.IngressClassName,
+		Rule: &ruleConfig{
+			Hosts:       a.Ingress.Hosts,
+			Path:        path,
+			Service:     svc.Name,
+			ServicePort: servicePort,
+		},
+		Tls: a.Ingress.Tls,
+	}, nil
+}
@@ -1,32 +1,485 @@
 package k8s
 
+import (
+	"errors"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"soul/utils/diffutil"
+	"strconv"
+)
+
+const (
+	defaultCpu    = "300m"
+	defaultMemory = "512Mi"
+	// 旧版本单容器字段的默认资源
+	legacyDefaultCpu    = "500m"
+	legacyDefaultMemory = "512Mi"
+)
+
 type containerPort struct {
 	Name     string `json:"name"`
-	Port     int32  `json:"port"`
-	Protocol string `json:"protocol"`
+	Port     int32  `json:"port" binding:"required,min=1,max=65535" msg:"容器端口范围为1-65535"`
+	Protocol string `json:"protocol" binding:"omitempty,oneof=TCP UDP SCTP" msg:"端口协议只能是TCP、UDP、SCTP"`
 }
 
 type strategy struct {
-	MaxUnavailable string
-	MaxSurge       string
+	Type           string `json:"type" binding:"omitempty,oneof=RollingUpdate Recreate" msg:"更新策略只能是RollingUpdate或Recreate"`
+	MaxUnavailable string `json:"maxUnavailable"` // 默认20%
+	MaxSurge       string `json:"maxSurge"`       // 默认20%
+}
+
+type resourceList struct {
+	Cpu    string `json:"cpu"`
+	Memory string `json:"memory"`
+}
+
+type resources struct {
+	Requests resourceList `json:"requests"`
+	Limits   resourceList `json:"limits"`
+}
+
+type keySelector struct {
+	Name     string `json:"name" binding:"required" msg:"引用的ConfigMap/Secret名称不能为空"`
+	Key      string `json:"key" binding:"required" msg:"引用的ConfigMap/Secret key不能为空"`
+	Optional bool   `json:"optional"`
 }
 
+// envVar 环境变量, value、configMapKeyRef、secretKeyRef 三选一
+type envVar struct {
+	Name            string       `json:"name" binding:"required" msg:"环境变量名不能为空"`
+	Value           string       `json:"value"`
+	ConfigMapKeyRef *keySelector `json:"configMapKeyRef"`
+	SecretKeyRef    *keySelector `json:"secretKeyRef"`
+}
+
+// envFrom 将整个ConfigMap或Secret导入为环境变量, configMap、secret 二选一
+type envFrom struct {
+	Prefix    string `json:"prefix"`
+	ConfigMap string `json:"configMap" binding:"required_without=Secret" msg:"configMap和secret不能同时为空"`
+	Secret    string `json:"secret" binding:"required_without=ConfigMap" msg:"configMap和secret不能同时为空"`
+}
+
+type volumeMount struct {
+	Name      string `json:"name" binding:"required" msg:"挂载的卷名称不能为空"`
+	MountPath string `json:"mountPath" binding:"required" msg:"挂载路径不能为空"`
+	SubPath   string `json:"subPath"`
+	ReadOnly  bool   `json:"readOnly"`
+}
+
+// probe 健康检查, type为http时使用path和port, tcp时使用port, exec时使用command
+type probe struct {
+	Type                string   `json:"type" binding:"required,oneof=http tcp exec" msg:"健康检查类型只能是http、tcp、exec"`
+	Path                string   `json:"path" binding:"required_if=Type http" msg:"http健康检查路径不能为空"`
+	Port                string   `json:"port"` // 默认使用容器的0号端口
+	Scheme              string   `json:"scheme" binding:"omitempty,oneof=HTTP HTTPS" msg:"scheme只能是HTTP或HTTPS"`
+	Command             []string `json:"command" binding:"required_if=Type exec" msg:"exec健康检查命令不能为空"`
+	InitialDelaySeconds int32    `json:"initialDelaySeconds"`
+	TimeoutSeconds      int32    `json:"timeoutSeconds"`
+	PeriodSeconds       int32    `json:"periodSeconds"`
+	SuccessThreshold    int32    `json:"successThreshold"`
+	FailureThreshold    int32    `json:"failureThreshold"`
+}
+
+type container struct {
+	Name            string          `json:"name" binding:"required" msg:"容器名称不能为空"`
+	Image           string          `json:"image" binding:"required" msg:"容器镜像不能为空"`
+	ImagePullPolicy string          `json:"imagePullPolicy" binding:"omitempty,oneof=Always IfNotPresent Never" msg:"镜像拉取策略只能是Always、IfNotPresent、Never"`
+	Command         []string        `json:"command"`
+	Args            []string        `json:"args"`
+	WorkingDir      string          `json:"workingDir"`
+	Ports           []containerPort `json:"ports" binding:"dive"`
+	Env             []envVar        `json:"env" binding:"dive"`
+	EnvFrom         []envFrom       `json:"envFrom" binding:"dive"`
+	Resources       resources       `json:"resources"`
+	VolumeMounts    []volumeMount   `json:"volumeMounts" binding:"dive"`
+	ReadinessProbe  *probe          `json:"readinessProbe"`
+	LivenessProbe   *probe          `json:"livenessProbe"`
+	StartupProbe    *probe          `json:"startupProbe"`
+}
+
+// volume 卷, type为configMap、secret、pvc时source为对应的资源名称
+type volume struct {
+	Name      string `json:"name" binding:"required" msg:"卷名称不能为空"`
+	Type      string `json:"type" binding:"required,oneof=configMap secret pvc emptyDir" msg:"卷类型只能是configMap、secret、pvc、emptyDir"`
+	Source    string `json:"source" binding:"required_unless=Type emptyDir" msg:"卷引用的资源名称不能为空"`
+	ReadOnly  bool   `json:"readOnly"`  // pvc
+	Medium    string `json:"medium"`    // emptyDir, 可选 Memory
+	SizeLimit string `json:"sizeLimit"` // emptyDir
+}
+
+type toleration struct {
+	Key               string `json:"key"`
+	Operator          string `json:"operator" binding:"omitempty,oneof=Exists Equal" msg:"容忍度operator只能是Exists或Equal"`
+	Value             string `json:"value"`
+	Effect            string `json:"effect" binding:"omitempty,oneof=NoSchedule PreferNoSchedule NoExecute" msg:"容忍度effect只能是NoSchedule、PreferNoSchedule、NoExecute"`
+	TolerationSeconds *int64 `json:"tolerationSeconds"`
+}
+
+// httpHealthCheck 旧版本的健康检查, 同时用于readiness、liveness和startup探针
 type httpHealthCheck struct {
 	HttpHealthPath string `json:"httpHealthPath" binding:"required" msg:"健康检查api路径不能为空"`
 	HttpHealthPort string `json:"httpHealthPort"` // 默认使用容器的0号端口
 }
 
+// DeploymentCreate containers为空时使用旧版本的image、imagePullSecret、cpu、memory、containerPort、
+// httpHealthCheck字段生成一个与Deployment同名的容器, containers不为空时忽略这些字段
 type DeploymentCreate struct {
 	Name                 string            `json:"name" binding:"required" msg:"Deployment名称不能为空"`
 	Namespace            string            `json:"namespace" binding:"required" msg:"Namespace不能为空"`
 	Replicas             int32             `json:"replicas,default=1"`
-	Image                string            `json:"image" binding:"required" msg:"容器镜像不能为空"`
-	ImagePullSecret      string            `json:"imagePullSecret"`
 	Label                map[string]string `json:"label"`
-	Cpu                  string            `json:"cpu,default='500m'"`
-	Memory               string            `json:"memory,default='512Mi'"`
-	ContainerPort        []containerPort   `json:"containerPort"`
-	HttpHealthCheck      httpHealthCheck   `json:"httpHealthCheck"`
-	RevisionHistoryLimit int32             `json:"-"` // 预留
-	Strategy             strategy          `json:"-"` // 预留
+	ImagePullSecrets     []string          `json:"imagePullSecrets"`
+	Containers           []container       `json:"containers" binding:"dive"`
+	InitContainers       []container       `json:"initContainers" binding:"dive"`
+	Volumes              []volume          `json:"volumes" binding:"dive"`
+	NodeSelector         map[string]string `json:"nodeSelector"`
+	Tolerations          []toleration      `json:"tolerations" binding:"dive"`
+	Affinity             *corev1.Affinity  `json:"affinity" swaggertype:"object"`
+	Strategy             strategy          `json:"strategy"`
+	RevisionHistoryLimit int32             `json:"revisionHistoryLimit,default=10"`
+
+	Image           string           `json:"image"`
+	ImagePullSecret string           `json:"imagePullSecret"`
+	Cpu             string           `json:"cpu,default='500m'"`
+	Memory          string           `json:"memory,default='512Mi'"`
+	ContainerPort   []containerPort  `json:"containerPort" binding:"dive"`
+	HttpHealthCheck *httpHealthCheck `json:"httpHealthCheck"`
+}
+
+// SetLegacyContainer containers为空时将旧版本的单容器字段转换为containers[0], 可以重复调用
+func (d *DeploymentCreate) SetLegacyContainer() {
+	if len(d.Containers) > 0 || d.Image == "" {
+		return
+	}
+
+	c := container{
+		Name:  d.Name,
+		Image: d.Image,
+		Ports: d.ContainerPort,
+		Resources: resources{
+			Requests: resourceList{Cpu: d.Cpu, Memory: d.Memory},
+		},
+	}
+	if c.Resources.Requests.Cpu == "" {
+		c.Resources.Requests.Cpu = legacyDefaultCpu
+	}
+	if c.Resources.Requests.Memory == "" {
+		c.Resources.Requests.Memory = legacyDefaultMemory
+	}
+	// 与旧版本一致, 有端口时才添加健康检查
+	if d.HttpHealthCheck != nil && len(d.ContainerPort) > 0 {
+		c.ReadinessProbe = &probe{Type: "http", Path: d.HttpHealthCheck.HttpHealthPath, Port: d.HttpHealthCheck.HttpHealthPort}
+		c.LivenessProbe = &probe{Type: "http", Path: d.HttpHealthCheck.HttpHealthPath, Port: d.HttpHealthCheck.HttpHealthPort}
+		c.StartupProbe = &probe{Type: "http", Path: d.HttpHealthCheck.HttpHealthPath, Port: d.HttpHealthCheck.HttpHealthPort}
+	}
+	d.Containers = []container{c}
+
+	if d.ImagePullSecret == "" {
+		return
+	}
+	for _, name := range d.ImagePullSecrets {
+		if name == d.ImagePullSecret {
+			return
+		}
+	}
+	d.ImagePullSecrets = append(d.ImagePullSecrets, d.ImagePullSecret)
+}
+
+// SetDefaults 为未填写的容器资源和更新策略设置默认值
+func (d *DeploymentCreate) SetDefaults() {
+	if d.Label == nil {
+		d.Label = map[string]string{}
+	}
+
+	d.SetLegacyContainer()
+	for i := range d.Containers {
+		d.Containers[i].Resources.setDefaults()
+	}
+	for i := range d.InitContainers {
+		d.InitContainers[i].Resources.setDefaults()
+	}
+
+	if d.Strategy.Type == "" {
+		d.Strategy.Type = "RollingUpdate"
+	}
+	if d.Strategy.MaxUnavailable == "" {
+		d.Strategy.MaxUnavailable = "20%"
+	}
+	if d.Strategy.MaxSurge == "" {
+		d.Strategy.MaxSurge = "20%"
+	}
+}
+
+// setDefaults requests未填写时使用默认值, limits未填写时与requests一致
+func (r *resources) setDefaults() {
+	if r.Requests.Cpu == "" {
+		r.Requests.Cpu = defaultCpu
+	}
+	if r.Requests.Memory == "" {
+		r.Requests.Memory = defaultMemory
+	}
+	if r.Limits.Cpu == "" {
+		r.Limits.Cpu = r.Requests.Cpu
+	}
+	if r.Limits.Memory == "" {
+		r.Limits.Memory = r.Requests.Memory
+	}
+}
+
+// ToContainer 转换为 corev1.Container
+func (c *container) ToContainer() (corev1.Container, error) {
+	var ports []corev1.ContainerPort
+	for _, item := range c.Ports {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          item.Name,
+			ContainerPort: item.Port,
+			Protocol:      corev1.Protocol(item.Protocol),
+		})
+	}
+
+	var env []corev1.EnvVar
+	for _, item := range c.Env {
+		if item.ConfigMapKeyRef != nil && item.SecretKeyRef != nil {
+			return corev1.Container{}, fmt.Errorf("环境变量%s的configMapKeyRef和secretKeyRef只能二选一", item.Name)
+		}
+		envVar := corev1.EnvVar{Name: item.Name, Value: item.Value}
+		switch {
+		case item.ConfigMapKeyRef != nil:
+			envVar.ValueFrom = &corev1.EnvVarSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: item.ConfigMapKeyRef.Name},
+					Key:                  item.ConfigMapKeyRef.Key,
+					Optional:             &item.ConfigMapKeyRef.Optional,
+				},
+			}
+		case item.SecretKeyRef != nil:
+			envVar.ValueFrom = &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: item.SecretKeyRef.Name},
+					Key:                  item.SecretKeyRef.Key,
+					Optional:             &item.SecretKeyRef.Optional,
+				},
+			}
+		}
+		if envVar.ValueFrom != nil && envVar.Value != "" {
+			return corev1.Container{}, fmt.Errorf("环境变量%s的value和引用只能二选一", item.Name)
+		}
+		env = append(env, envVar)
+	}
+
+	var envFromSources []corev1.EnvFromSource
+	for _, item := range c.EnvFrom {
+		if item.ConfigMap != "" && item.Secret != "" {
+			return corev1.Container{}, fmt.Errorf("容器%s的envFrom中configMap和secret只能二选一", c.Name)
+		}
+		source := corev1.EnvFromSource{Prefix: item.Prefix}
+		if item.ConfigMap != "" {
+			source.ConfigMapRef = &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: item.ConfigMap},
+			}
+		} else {
+			source.SecretRef = &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: item.Secret},
+			}
+		}
+		envFromSources = append(envFromSources, source)
+	}
+
+	var volumeMounts []corev1.VolumeMount
+	for _, item := range c.VolumeMounts {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      item.Name,
+			MountPath: item.MountPath,
+			SubPath:   item.SubPath,
+			ReadOnly:  item.ReadOnly,
+		})
+	}
+
+	requests, err := c.Resources.Requests.toResourceList()
+	if err != nil {
+		return corev1.Container{}, fmt.Errorf("容器%s requests格式错误, %s", c.Name, err.Error())
+	}
+	limits, err := c.Resources.Limits.toResourceList()
+	if err != nil {
+		return corev1.Container{}, fmt.Errorf("容器%s limits格式错误, %s", c.Name, err.Error())
+	}
+
+	result := corev1.Container{
+		Name:            c.Name,
+		Image:           c.Image,
+		Command:         c.Command,
+		Args:            c.Args,
+		WorkingDir:      c.WorkingDir,
+		Ports:           ports,
+		EnvFrom:         envFromSources,
+		Env:             env,
+		Resources:       corev1.ResourceRequirements{Limits: limits, Requests: requests},
+		VolumeMounts:    volumeMounts,
+		ImagePullPolicy: corev1.PullPolicy(c.ImagePullPolicy),
+	}
+
+	if result.ReadinessProbe, err = c.ReadinessProbe.toProbe(c.Ports, defaultProbe); err != nil {
+		return corev1.Container{}, err
+	}
+	if result.LivenessProbe, err = c.LivenessProbe.toProbe(c.Ports, defaultProbe); err != nil {
+		return corev1.Container{}, err
+	}
+	if result.StartupProbe, err = c.StartupProbe.toProbe(c.Ports, defaultStartupProbe); err != nil {
+		return corev1.Container{}, err
+	}
+
+	return result, nil
+}
+
+func (r resourceList) toResourceList() (corev1.ResourceList, error) {
+	list := corev1.ResourceList{}
+	if r.Cpu != "" {
+		cpu, err := resource.ParseQuantity(r.Cpu)
+		if err != nil {
+			return nil, err
+		}
+		list[corev1.ResourceCPU] = cpu
+	}
+	if r.Memory != "" {
+		memory, err := resource.ParseQuantity(r.Memory)
+		if err != nil {
+			return nil, err
+		}
+		list[corev1.ResourceMemory] = memory
+	}
+	return list, nil
+}
+
+var (
+	defaultProbe = corev1.Probe{
+		InitialDelaySeconds: 10,
+		TimeoutSeconds:      2,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		FailureThreshold:    3,
+	}
+	// 给予105s的启动时间
+	defaultStartupProbe = corev1.Probe{
+		InitialDelaySeconds: 5,
+		TimeoutSeconds:      3,
+		PeriodSeconds:       10,
+		SuccessThreshold:    1,
+		FailureThreshold:    10,
+	}
+)
+
+// toProbe 转换为 corev1.Probe, 未填写的时间参数使用defaults中的值
+func (p *probe) toProbe(ports []containerPort, defaults corev1.Probe) (*corev1.Probe, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	port := p.Port
+	if port == "" && p.Type != "exec" {
+		if len(ports) == 0 {
+			return nil, errors.New("容器没有端口, 健康检查端口必填")
+		}
+		port = ports[0].Name
+		if port == "" {
+			port = strconv.Itoa(int(ports[0].Port))
+		}
+	}
+
+	result := defaults
+	switch p.Type {
+	case "http":
+		scheme := corev1.URISchemeHTTP
+		if p.Scheme != "" {
+			scheme = corev1.URIScheme(p.Scheme)
+		}
+		result.HTTPGet = &corev1.HTTPGetAction{
+			Path:   p.Path,
+			Port:   intstr.Parse(port),
+			Scheme: scheme,
+		}
+	case "tcp":
+		result.TCPSocket = &corev1.TCPSocketAction{Port: intstr.Parse(port)}
+	case "exec":
+		result.Exec = &corev1.ExecAction{Command: p.Command}
+	}
+
+	if p.InitialDelaySeconds != 0 {
+		result.InitialDelaySeconds = p.InitialDelaySeconds
+	}
+	if p.TimeoutSeconds != 0 {
+		result.TimeoutSeconds = p.TimeoutSeconds
+	}
+	if p.PeriodSeconds != 0 {
+		result.PeriodSeconds = p.PeriodSeconds
+	}
+	if p.SuccessThreshold != 0 {
+		result.SuccessThreshold = p.SuccessThreshold
+	}
+	if p.FailureThreshold != 0 {
+		result.FailureThreshold = p.FailureThreshold
+	}
+	return &result, nil
+}
+
+// ToVolume 转换为 corev1.Volume
+func (v *volume) ToVolume() (corev1.Volume, error) {
+	result := corev1.Volume{Name: v.Name}
+	switch v.Type {
+	case "configMap":
+		result.ConfigMap = &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: v.Source},
+		}
+	case "secret":
+		result.Secret = &corev1.SecretVolumeSource{SecretName: v.Source}
+	case "pvc":
+		result.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: v.Source,
+			ReadOnly:  v.ReadOnly,
+		}
+	case "emptyDir":
+		result.EmptyDir = &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMedium(v.Medium)}
+		if v.SizeLimit != "" {
+			sizeLimit, err := resource.ParseQuantity(v.SizeLimit)
+			if err != nil {
+				return corev1.Volume{}, fmt.Errorf("卷%s sizeLimit格式错误, %s", v.Name, err.Error())
+			}
+			result.EmptyDir.SizeLimit = &sizeLimit
+		}
+	}
+	return result, nil
+}
+
+// ToToleration 转换为 corev1.Toleration
+func (t *toleration) ToToleration() corev1.Toleration {
+	return corev1.Toleration{
+		Key:               t.Key,
+		Operator:          corev1.TolerationOperator(t.Operator),
+		Value:             t.Value,
+		Effect:            corev1.TaintEffect(t.Effect),
+		TolerationSeconds: t.TolerationSeconds,
+	}
+}
+
+type DeploymentPromote struct {
+	TargetCluster   string            `json:"targetCluster" binding:"required" msg:"目标集群不能为空"`
+	TargetNamespace string            `json:"targetNamespace" binding:"required" msg:"目标Namespace不能为空"`
+	WithService     bool              `json:"withService"`    // 同时迁移selector匹配的Service
+	WithIngress     bool              `json:"withIngress"`    // 同时迁移引用了上述Service的Ingress
+	WithConfigMaps  bool              `json:"withConfigMaps"` // 同时迁移pod中引用的ConfigMap
+	WithSecrets     bool              `json:"withSecrets"`    // 同时迁移pod中引用的Secret
+	ImageTag        string            `json:"imageTag"`       // 覆盖所有容器的镜像tag
+	Images          map[string]string `json:"images"`         // 按容器名覆盖完整镜像地址, 优先级高于imageTag
+	Replicas        *int32            `json:"replicas"`
+	DryRun          bool              `json:"dryRun"`
+}
+
+// PromoteItem 单个对象的迁移结果
+type PromoteItem struct {
+	Kind   string           `json:"kind"`
+	Name   string           `json:"name"`
+	Action string           `json:"action"` // create or update
+	Diff   *diffutil.Result `json:"diff,omitempty"`
+	Error  string           `json:"error,omitempty"`
 }
@@ -0,0 +1,61 @@
+package k8s
+
+// httpRouteParent 路由绑定的Gateway, namespace为空时与HTTPRoute相同, sectionName为Gateway中的listener名称
+type httpRouteParent struct {
+	Name        string `json:"name" binding:"required" msg:"Gateway名称不能为空"`
+	Namespace   string `json:"namespace"`
+	SectionName string `json:"sectionName"`
+}
+
+// httpRouteMatch pathType为空时为PathPrefix, path为空时为 /
+type httpRouteMatch struct {
+	Path     string            `json:"path"`
+	PathType string            `json:"pathType" binding:"omitempty,oneof=PathPrefix Exact RegularExpression" msg:"pathType只能是PathPrefix、Exact或RegularExpression"`
+	Method   string            `json:"method" binding:"omitempty,oneof=GET HEAD POST PUT DELETE CONNECT OPTIONS TRACE PATCH" msg:"method不合法"`
+	Headers  map[string]string `json:"headers"` // 请求头精确匹配
+}
+
+// httpRouteBackend 后端Service, namespace与HTTPRoute不同时需要目标namespace中有允许引用的ReferenceGrant
+type httpRouteBackend struct {
+	Service   string `json:"service" binding:"required" msg:"Service名称不能为空"`
+	Namespace string `json:"namespace"`
+	Port      int32  `json:"port" binding:"required,gt=0,lte=65535" msg:"port必须在1-65535之间"`
+	Weight    *int32 `json:"weight" binding:"omitempty,gte=0,lte=1000000" msg:"weight必须在0-1000000之间"`
+}
+
+// httpRouteRule 没有matches时匹配所有请求
+type httpRouteRule struct {
+	Matches  []httpRouteMatch   `json:"matches" binding:"dive"`
+	Backends []httpRouteBackend `json:"backends" binding:"required,min=1,dive" msg:"backends不能为空"`
+}
+
+// HTTPRouteSimpleCreate 简化的HTTPRoute, 更新时rules、hostnames和parentRefs整体替换, labels和annotations与已有的合并
+type HTTPRouteSimpleCreate struct {
+	Name        string            `json:"name" binding:"required" msg:"HTTPRoute名称不能为空"`
+	Namespace   string            `json:"namespace" binding:"required" msg:"Namespace不能为空"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	ParentRefs  []httpRouteParent `json:"parentRefs" binding:"required,min=1,dive" msg:"parentRefs不能为空"`
+	Hostnames   []string          `json:"hostnames"`
+	Rules       []httpRouteRule   `json:"rules" binding:"required,min=1,dive" msg:"rules不能为空"`
+}
+
+// HTTPRouteParentStatus 路由在一个Gateway上的状态, Gateway控制器还没有处理时accepted为false, reason为Pending
+type HTTPRouteParentStatus struct {
+	Gateway        string `json:"gateway"` // namespace/name
+	SectionName    string `json:"sectionName,omitempty"`
+	ControllerName string `json:"controllerName,omitempty"`
+	Accepted       bool   `json:"accepted"`
+	ResolvedRefs   bool   `json:"resolvedRefs"`
+	Reason         string `json:"reason"`
+	Message        string `json:"message"`
+}
+
+// HTTPRouteStatus HTTPRoute绑定的每个Gateway的状态, 所有Gateway都接受并且后端都能解析时ready为true
+type HTTPRouteStatus struct {
+	Name      string                  `json:"name"`
+	Namespace string                  `json:"namespace"`
+	Hostnames []string                `json:"hostnames"`
+	Ready     bool                    `json:"ready"`
+	Parents   []HTTPRouteParentStatus `json:"parents"`
+}
@@ -0,0 +1,55 @@
+package k8s
+
+import "time"
+
+// HelmInstall 安装chart, 使用multipart/form-data提交, 上传chart包(file)和本地chart仓库中的chart二选一
+type HelmInstall struct {
+	Name      string `form:"name" binding:"required" msg:"Release名称不能为空"`
+	Namespace string `form:"namespace" binding:"required" msg:"Namespace不能为空"`
+	Chart     string `form:"chart"`  // 本地chart仓库中的chart目录或.tgz包名称
+	Values    string `form:"values"` // yaml格式
+	Wait      bool   `form:"wait"`
+	Timeout   int    `form:"timeout,default=300" binding:"min=1" msg:"超时时间必须大于0"` // 单位秒
+}
+
+// HelmUpgrade 升级release, 使用multipart/form-data提交, 上传chart包(file)和本地chart仓库中的chart二选一
+type HelmUpgrade struct {
+	Chart       string `form:"chart"`
+	Values      string `form:"values"`
+	ReuseValues bool   `form:"reuseValues"` // 在上一个版本values的基础上合并
+	Wait        bool   `form:"wait"`
+	Timeout     int    `form:"timeout,default=300" binding:"min=1" msg:"超时时间必须大于0"`
+}
+
+type HelmRollback struct {
+	Revision int  `json:"revision" binding:"gte=0" msg:"版本号不能小于0"` // 为0时回滚到上一个版本
+	Wait     bool `json:"wait"`
+	Timeout  int  `json:"timeout" binding:"omitempty,min=1" msg:"超时时间必须大于0"` // 单位秒, 为0时使用300
+}
+
+type HelmRelease struct {
+	Name         string    `json:"name"`
+	Namespace    string    `json:"namespace"`
+	Revision     int       `json:"revision"`
+	Status       string    `json:"status"`
+	Chart        string    `json:"chart"`
+	ChartVersion string    `json:"chartVersion"`
+	AppVersion   string    `json:"appVersion"`
+	Updated      time.Time `json:"updated"`
+	Description  string    `json:"description"`
+}
+
+type HelmReleaseDetail struct {
+	HelmRelease
+	Notes    string         `json:"notes"`
+	Manifest string         `json:"manifest"`
+	Values   map[string]any `json:"values"`
+}
+
+type HelmChart struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	AppVersion  string `json:"appVersion"`
+	Description string `json:"description"`
+	Path        string `json:"path"` // 安装时chart字段的值
+}
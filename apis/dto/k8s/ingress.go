@@ -1,22 +1,63 @@
 package k8s
 
+// ruleConfig 旧版本的单规则配置, 所有host使用同一个path和后端, 新的请求使用rules
 type ruleConfig struct {
 	Hosts       []string `json:"hosts"`
-	Path        string   `json:"path,default='/'"`
+	Path        string   `json:"path"`
 	Service     string   `json:"service" binding:"required" msg:"Service名称不能为空"`
 	ServicePort int32    `json:"servicePort"`
 }
 
+// ingressBackend 后端Service, servicePort和servicePortName二选一
+type ingressBackend struct {
+	Service         string `json:"service" binding:"required" msg:"Service名称不能为空"`
+	ServicePort     int32  `json:"servicePort" binding:"omitempty,gt=0,lte=65535" msg:"servicePort必须在1-65535之间"`
+	ServicePortName string `json:"servicePortName"`
+}
+
+// ingressPath pathType为空时为Prefix, path为空时为 /
+type ingressPath struct {
+	Path     string `json:"path"`
+	PathType string `json:"pathType" binding:"omitempty,oneof=Prefix Exact ImplementationSpecific" msg:"pathType只能是Prefix、Exact或ImplementationSpecific"`
+	ingressBackend
+}
+
+// ingressRule host为空时匹配所有host
+type ingressRule struct {
+	Host  string        `json:"host"`
+	Paths []ingressPath `json:"paths" binding:"required,min=1,dive" msg:"paths不能为空"`
+}
+
 type tlsConfig struct {
 	Hosts      []string `json:"host"`
 	SecretName string   `json:"secretName"`
 }
 
+// IngressSimpleCreate rules、defaultBackend和旧版本的rule至少需要一个, rule会转换为rules
 type IngressSimpleCreate struct {
 	Name             string            `json:"name" binding:"required" msg:"Ingress名称不能为空"`
 	Namespace        string            `json:"namespace" binding:"required" msg:"Namespace不能为空"`
-	Annotations      map[string]string `json:"annotations"`
+	Labels           map[string]string `json:"labels"`
+	Annotations      map[string]string `json:"annotations"` // 更新时与已有的注解合并, 不会删除其他工具添加的注解
 	IngressClassName string            `json:"ingressClassName"`
-	Rule             ruleConfig        `json:"rule" binding:"required" msg:"规则不能为空"`
+	Rules            []ingressRule     `json:"rules" binding:"dive"`
+	DefaultBackend   *ingressBackend   `json:"defaultBackend"`
+	Rule             *ruleConfig       `json:"rule"`
 	Tls              []tlsConfig       `json:"tls"`
 }
+
+// IngressValidation Ingress的检查结果, 有error级别的问题时valid为false
+type IngressValidation struct {
+	Name      string              `json:"name"`
+	Namespace string              `json:"namespace"`
+	Valid     bool                `json:"valid"`
+	Findings  []DiagnosticFinding `json:"findings"`
+}
+
+// IngressLint 集群中Ingress的检查结果, items只包含有问题的Ingress
+type IngressLint struct {
+	Total    int                 `json:"total"`
+	Errors   int                 `json:"errors"`
+	Warnings int                 `json:"warnings"`
+	Items    []IngressValidation `json:"items"`
+}
@@ -0,0 +1,71 @@
+package k8s
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"time"
+)
+
+// ContainerMetrics 容器的资源使用量, CPU单位为millicore, 内存单位为字节, request和limit为0表示没有设置
+type ContainerMetrics struct {
+	Name          string `json:"name"`
+	CPUUsage      int64  `json:"cpuUsage"`
+	CPURequest    int64  `json:"cpuRequest"`
+	CPULimit      int64  `json:"cpuLimit"`
+	MemoryUsage   int64  `json:"memoryUsage"`
+	MemoryRequest int64  `json:"memoryRequest"`
+	MemoryLimit   int64  `json:"memoryLimit"`
+}
+
+// PodMetrics Pod的资源使用量, 来自metrics.k8s.io, timestamp和window是metrics-server的采样时间和窗口
+type PodMetrics struct {
+	Timestamp   time.Time          `json:"timestamp"`
+	Window      string             `json:"window"`
+	CPUUsage    int64              `json:"cpuUsage"`
+	MemoryUsage int64              `json:"memoryUsage"`
+	Containers  []ContainerMetrics `json:"containers"`
+}
+
+// PodWithMetrics Pod和资源使用量, metrics-server不可用时metrics为null
+type PodWithMetrics struct {
+	corev1.Pod `json:",inline"`
+	Metrics    *PodMetrics `json:"metrics"`
+}
+
+// WorkloadMetrics 工作负载所有Pod资源使用量的合计, request和limit只统计有监控数据的Pod
+type WorkloadMetrics struct {
+	Pods          int   `json:"pods"` // 有监控数据的Pod数量
+	CPUUsage      int64 `json:"cpuUsage"`
+	CPURequest    int64 `json:"cpuRequest"`
+	CPULimit      int64 `json:"cpuLimit"`
+	MemoryUsage   int64 `json:"memoryUsage"`
+	MemoryRequest int64 `json:"memoryRequest"`
+	MemoryLimit   int64 `json:"memoryLimit"`
+}
+
+// DeploymentWithMetrics Deployment和资源使用量, metrics-server不可用时metrics为null
+type DeploymentWithMetrics struct {
+	appsv1.Deployment `json:",inline"`
+	Metrics           *WorkloadMetrics `json:"metrics"`
+}
+
+// NodeUsage 节点的资源使用量, 百分比相对于allocatable
+type NodeUsage struct {
+	CPUUsage      int64   `json:"cpuUsage"`
+	MemoryUsage   int64   `json:"memoryUsage"`
+	CPUPercent    float64 `json:"cpuPercent"`
+	MemoryPercent float64 `json:"memoryPercent"`
+}
+
+// NodeMetrics 节点的可分配资源和使用量, metrics-server不可用时usage为null
+type NodeMetrics struct {
+	Name              string            `json:"name"`
+	Ready             bool              `json:"ready"`
+	Unschedulable     bool              `json:"unschedulable"`
+	Labels            map[string]string `json:"labels"`
+	KubeletVersion    string            `json:"kubeletVersion"`
+	CPUAllocatable    int64             `json:"cpuAllocatable"`
+	MemoryAllocatable int64             `json:"memoryAllocatable"`
+	PodsAllocatable   int64             `json:"podsAllocatable"`
+	Usage             *NodeUsage        `json:"usage"`
+}
@@ -1 +1,193 @@
 package k8s
+
+import (
+	"errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
+)
+
+// PodLogQuery 日志查询参数, sinceSeconds和sinceTime只能二选一
+type PodLogQuery struct {
+	Container    string `form:"containerName"`
+	Follow       bool   `form:"follow"`
+	Previous     bool   `form:"previous"` // 上一个容器实例的日志
+	Timestamps   bool   `form:"timestamps"`
+	TailLines    *int64 `form:"tailLines" binding:"omitempty,gte=0" msg:"tailLines不能小于0"`
+	SinceSeconds *int64 `form:"sinceSeconds" binding:"omitempty,gt=0" msg:"sinceSeconds必须大于0"`
+	SinceTime    string `form:"sinceTime" binding:"omitempty,datetime=2006-01-02T15:04:05Z07:00" msg:"sinceTime必须是RFC3339格式"`
+	LimitBytes   *int64 `form:"limitBytes" binding:"omitempty,gt=0" msg:"limitBytes必须大于0"`
+}
+
+func (q *PodLogQuery) ToPodLogOptions() (*corev1.PodLogOptions, error) {
+	if q.SinceSeconds != nil && q.SinceTime != "" {
+		return nil, errors.New("sinceSeconds和sinceTime只能二选一")
+	}
+
+	option := &corev1.PodLogOptions{
+		Container:    q.Container,
+		Follow:       q.Follow,
+		Previous:     q.Previous,
+		Timestamps:   q.Timestamps,
+		TailLines:    q.TailLines,
+		SinceSeconds: q.SinceSeconds,
+		LimitBytes:   q.LimitBytes,
+	}
+	if q.SinceTime != "" {
+		sinceTime, err := time.Parse(time.RFC3339, q.SinceTime)
+		if err != nil {
+			return nil, errors.New("sinceTime必须是RFC3339格式")
+		}
+		option.SinceTime = &metav1.Time{Time: sinceTime}
+	}
+	return option, nil
+}
+
+// AggregatedLogQuery 聚合日志查询参数, kind+name和labelSelector二选一
+type AggregatedLogQuery struct {
+	Kind          string `form:"kind" binding:"omitempty,oneof=deployment statefulset" msg:"kind只能是deployment或statefulset"`
+	Name          string `form:"name" binding:"required_with=Kind" msg:"name不能为空"`
+	LabelSelector string `form:"labelSelector" binding:"required_without=Kind" msg:"kind和labelSelector不能同时为空"`
+	Container     string `form:"containerName"` // 为空时读取所有容器
+	Follow        bool   `form:"follow"`
+	Timestamps    bool   `form:"timestamps"`
+	TailLines     *int64 `form:"tailLines" binding:"omitempty,gte=0" msg:"tailLines不能小于0"`
+	SinceSeconds  *int64 `form:"sinceSeconds" binding:"omitempty,gt=0" msg:"sinceSeconds必须大于0"`
+	Filter        string `form:"filter"` // 正则表达式, 只返回匹配的行
+}
+
+// DiagnosticBundleQuery 诊断包参数, kind+name和labelSelector二选一
+type DiagnosticBundleQuery struct {
+	Kind          string `form:"kind" binding:"omitempty,oneof=deployment statefulset" msg:"kind只能是deployment或statefulset"`
+	Name          string `form:"name" binding:"required_with=Kind" msg:"name不能为空"`
+	LabelSelector string `form:"labelSelector" binding:"required_without=Kind" msg:"kind和labelSelector不能同时为空"`
+	TailLines     *int64 `form:"tailLines" binding:"omitempty,gte=0" msg:"tailLines不能小于0"`
+	SinceSeconds  *int64 `form:"sinceSeconds" binding:"omitempty,gt=0" msg:"sinceSeconds必须大于0"`
+	LimitBytes    *int64 `form:"limitBytes" binding:"omitempty,gt=0" msg:"limitBytes必须大于0"` // 每个日志文件的大小上限
+}
+
+// PodFileQuery 容器文件路径, containerName为空时使用第1个容器
+type PodFileQuery struct {
+	Container string `form:"containerName"`
+	Path      string `form:"path" binding:"required,startswith=/" required_err:"path不能为空" startswith_err:"path必须是绝对路径"`
+}
+
+// PodFileUpload 上传文件到容器的path目录下, archive为true时请求体是tar包, 解压到path目录; 否则请求体是单个文件的内容
+type PodFileUpload struct {
+	PodFileQuery
+	FileName string `form:"fileName" binding:"required_without=Archive,excludes=/" required_without_err:"fileName不能为空" excludes_err:"fileName不能包含/"`
+	Archive  bool   `form:"archive"`
+}
+
+// PodFile 容器中的文件, 由ls -l的输出解析
+type PodFile struct {
+	Name    string `json:"name"`
+	Mode    string `json:"mode"`
+	IsDir   bool   `json:"isDir"`
+	Owner   string `json:"owner"`
+	Group   string `json:"group"`
+	Size    int64  `json:"size"`
+	ModTime string `json:"modTime"`
+	Link    string `json:"link,omitempty"` // 符号链接的目标
+}
+
+// PodDebug 在Pod中添加临时调试容器, targetContainer不为空时与该容器共享进程命名空间
+type PodDebug struct {
+	Image           string `json:"image"` // 为空时使用配置的默认镜像
+	TargetContainer string `json:"targetContainer"`
+	Shell           string `json:"shell"`
+}
+
+// NodeDebug 在节点上启动特权调试Pod, 节点的根目录挂载在/host
+type NodeDebug struct {
+	Image string `json:"image"` // 为空时使用配置的默认镜像
+	Shell string `json:"shell"`
+}
+
+// DebugTerminal 调试容器的终端会话, id和token与exec接口相同, 用于建立SockJS连接
+type DebugTerminal struct {
+	ID            string `json:"id"`
+	Token         string `json:"token"`
+	Namespace     string `json:"namespace"`
+	PodName       string `json:"podName"`
+	ContainerName string `json:"containerName"`
+}
+
+// PodExec 在容器中执行非交互命令, command直接执行, 需要管道等功能时使用 ["sh", "-c", "..."]
+type PodExec struct {
+	Container string   `json:"containerName"` // 为空时使用第1个容器
+	Command   []string `json:"command" binding:"required,min=1" msg:"command不能为空"`
+	Stdin     string   `json:"stdin"`
+	Timeout   int      `json:"timeout" binding:"omitempty,gt=0" msg:"timeout必须大于0"` // 超时时间, 单位秒
+}
+
+// PodExecResult 命令执行结果, 命令无法执行或超时时exitCode为-1, 原因在error中
+type PodExecResult struct {
+	PodName   string `json:"podName"`
+	Container string `json:"containerName"`
+	Stdout    string `json:"stdout"`
+	Stderr    string `json:"stderr"`
+	ExitCode  int    `json:"exitCode"`
+	Error     string `json:"error,omitempty"`
+	Truncated bool   `json:"truncated"` // 输出超过大小上限, 超过的部分被丢弃
+	Duration  int64  `json:"duration"`  // 执行时间, 单位毫秒
+}
+
+// BatchExecResult 在多个Pod中执行命令的汇总结果, exitCode为0的算作成功
+type BatchExecResult struct {
+	Total     int             `json:"total"`
+	Succeeded int             `json:"succeeded"`
+	Failed    int             `json:"failed"`
+	Items     []PodExecResult `json:"items"`
+}
+
+// PodBulkOperation 批量操作namespace下的Pod, labelSelector和status至少指定一个, 同时指定时需要都匹配
+type PodBulkOperation struct {
+	Action        string `json:"action" binding:"required,oneof=delete forceDelete evict" msg:"action只能是delete、forceDelete或evict"`
+	LabelSelector string `json:"labelSelector" binding:"required_without=Status" msg:"labelSelector和status不能同时为空"`
+	Status        string `json:"status"` // 与kubectl get pod的STATUS列相同, 如Evicted、CrashLoopBackOff、Completed、Terminating
+	DryRun        bool   `json:"dryRun"` // 只返回匹配的Pod, 不执行操作
+}
+
+// PodOperationResult 单个Pod的操作结果
+type PodOperationResult struct {
+	PodName string `json:"podName"`
+	Status  string `json:"status"` // 操作前的状态
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// PodBulkResult 批量操作的汇总结果
+type PodBulkResult struct {
+	Total     int                  `json:"total"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	DryRun    bool                 `json:"dryRun"`
+	Items     []PodOperationResult `json:"items"`
+}
+
+// DiagnosticFinding 诊断发现的问题, severity为error、warning或info
+type DiagnosticFinding struct {
+	Severity    string `json:"severity"`
+	Category    string `json:"category"` // Scheduling、Image、Crash、Config、Storage、Probe、Node、Event等
+	Container   string `json:"container,omitempty"`
+	Reason      string `json:"reason"`
+	Message     string `json:"message"`     // Kubernetes返回的原始信息
+	Explanation string `json:"explanation"` // 可能的原因和处理建议
+}
+
+// PodDiagnosis Pod的诊断结果, 没有error和warning级别的问题时healthy为true
+type PodDiagnosis struct {
+	PodName  string              `json:"podName"`
+	Status   string              `json:"status"`
+	Healthy  bool                `json:"healthy"`
+	Findings []DiagnosticFinding `json:"findings"`
+}
+
+// DeploymentDiagnosis Deployment的诊断结果, findings为Deployment本身的问题, pods为每个Pod的诊断结果
+type DeploymentDiagnosis struct {
+	Name     string              `json:"name"`
+	Healthy  bool                `json:"healthy"`
+	Findings []DiagnosticFinding `json:"findings"`
+	Pods     []PodDiagnosis      `json:"pods"`
+}
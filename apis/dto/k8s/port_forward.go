@@ -0,0 +1,31 @@
+package k8s
+
+import "time"
+
+// PortForwardRecordQuery 端口转发审计记录查询条件, podName为模糊匹配
+type PortForwardRecordQuery struct {
+	ClusterName string    `form:"clusterName"`
+	Namespace   string    `form:"namespace"`
+	PodName     string    `form:"podName"`
+	Username    string    `form:"username"`
+	StartTime   time.Time `form:"startTime" time_format:"2006-01-02T15:04:05Z07:00"` // 会话开始时间范围
+	EndTime     time.Time `form:"endTime" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit       int       `form:"limit,default=10" binding:"gt=0" msg:"limit必须大于0"`
+	Page        int       `form:"page,default=1" binding:"gt=0" msg:"page必须大于0"`
+	UserID      uint      `form:"-"` // 非管理员只能查询自己的记录, 为0时不限制
+}
+
+// PortForwardSession 当前打开的端口转发会话
+type PortForwardSession struct {
+	SessionID   string    `json:"sessionId"`
+	UserID      uint      `json:"userId"`
+	Username    string    `json:"username"`
+	ClusterName string    `json:"clusterName"`
+	Namespace   string    `json:"namespace"`
+	PodName     string    `json:"podName"`
+	Port        int32     `json:"port"`
+	Target      string    `json:"target"`
+	ProxyPath   string    `json:"proxyPath"` // 反向代理地址, 请求需要带上Authorization
+	StartedAt   time.Time `json:"startedAt"`
+	ExpiresAt   time.Time `json:"expiresAt"` // 达到最长时间的时间
+}
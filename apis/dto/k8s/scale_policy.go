@@ -0,0 +1,11 @@
+package k8s
+
+type ScalePolicyCreate struct {
+	Name            string `json:"name" binding:"required" msg:"策略名称不能为空"`
+	Namespace       string `json:"namespace" binding:"required" msg:"Namespace不能为空"`
+	DeploymentName  string `json:"deploymentName"`
+	ScaleSchedule   string `json:"scaleSchedule" binding:"required,cron" msg:"缩容时间不能为空" cron_err:"缩容时间不是合法的cron表达式"`
+	ScaleReplicas   int32  `json:"scaleReplicas" binding:"gte=0" msg:"缩容后的副本数不能小于0"`
+	RestoreSchedule string `json:"restoreSchedule" binding:"omitempty,cron" msg:"恢复时间不是合法的cron表达式"`
+	Enabled         bool   `json:"enabled"`
+}
@@ -1,17 +1,70 @@
 package k8s
 
+import corev1 "k8s.io/api/core/v1"
+
 type ports struct {
 	Name          string `json:"name"`
 	Protocol      string `json:"protocol"`
-	ContainerPort int32  `json:"containerPort"`
+	ContainerPort int32  `json:"containerPort"` // Service端口
+	TargetPort    string `json:"targetPort"`    // 容器端口号或端口名称, 为空时与containerPort相同
+	NodePort      int32  `json:"nodePort"`      // NodePort和LoadBalancer类型固定的节点端口, 为0时自动分配, 更新时保留已分配的端口
 }
 
+// SvcSimpleCreate 简化的Service, type为空时为ClusterIP。
+// headless只能用于ClusterIP类型; ExternalName类型不需要selector和ports
 type SvcSimpleCreate struct {
-	Name           string            `json:"name" binding:"required" msg:"Service名称不能为空"`
-	Namespace      string            `json:"namespace" binding:"required" msg:"Namespace不能为空"`
-	Labels         map[string]string `json:"labels"`
-	DeploymentName string            `json:"deploymentName"`
-	Selector       map[string]string `json:"selector"`
-	Type           string            `json:"-"` // 预留 默认,ClusterIP
-	Ports          []ports           `json:"ports"`
+	Name                     string            `json:"name" binding:"required" msg:"Service名称不能为空"`
+	Namespace                string            `json:"namespace" binding:"required" msg:"Namespace不能为空"`
+	Labels                   map[string]string `json:"labels"`
+	Annotations              map[string]string `json:"annotations"` // 例如LoadBalancer的云厂商注解, 更新时与已有的注解合并
+	DeploymentName           string            `json:"deploymentName"`
+	Selector                 map[string]string `json:"selector"`
+	Type                     string            `json:"type" binding:"omitempty,oneof=ClusterIP NodePort LoadBalancer ExternalName" msg:"type只能是ClusterIP、NodePort、LoadBalancer或ExternalName"`
+	Headless                 bool              `json:"headless"`
+	ExternalName             string            `json:"externalName" binding:"required_if=Type ExternalName" msg:"ExternalName类型的externalName不能为空"`
+	ExternalTrafficPolicy    string            `json:"externalTrafficPolicy" binding:"omitempty,oneof=Cluster Local" msg:"externalTrafficPolicy只能是Cluster或Local"`
+	LoadBalancerSourceRanges []string          `json:"loadBalancerSourceRanges" binding:"omitempty,dive,cidr" msg:"loadBalancerSourceRanges必须是CIDR格式"`
+	SessionAffinity          string            `json:"sessionAffinity" binding:"omitempty,oneof=None ClientIP" msg:"sessionAffinity只能是None或ClientIP"`
+	SessionAffinityTimeout   int32             `json:"sessionAffinityTimeout" binding:"omitempty,gt=0,lte=86400" msg:"sessionAffinityTimeout必须在1-86400之间"` // ClientIP时有效, 单位秒, 默认10800
+	Ports                    []ports           `json:"ports"`
+}
+
+// SvcDetail Service详情, endpoints从EndpointSlice解析, findings为selector和端口的检查结果
+type SvcDetail struct {
+	Service   *corev1.Service     `json:"service"`
+	Healthy   bool                `json:"healthy"`
+	Ready     int                 `json:"ready"`
+	NotReady  int                 `json:"notReady"`
+	Endpoints []SvcEndpoint       `json:"endpoints"`
+	Findings  []DiagnosticFinding `json:"findings"`
+	Ingresses []SvcIngressRef     `json:"ingresses"`
+}
+
+// SvcEndpoint EndpointSlice中的一个地址, 后端是Pod时podName为Pod名称
+type SvcEndpoint struct {
+	Address       string            `json:"address"`
+	Ready         bool              `json:"ready"`
+	Serving       bool              `json:"serving"`
+	Terminating   bool              `json:"terminating"`
+	PodName       string            `json:"podName,omitempty"`
+	PodStatus     string            `json:"podStatus,omitempty"`
+	NodeName      string            `json:"nodeName,omitempty"`
+	Zone          string            `json:"zone,omitempty"`
+	EndpointSlice string            `json:"endpointSlice"`
+	Ports         []SvcEndpointPort `json:"ports"`
+}
+
+type SvcEndpointPort struct {
+	Name     string `json:"name"`
+	Port     int32  `json:"port"`
+	Protocol string `json:"protocol"`
+}
+
+// SvcIngressRef 引用Service的Ingress规则, 默认后端的host和path为空
+type SvcIngressRef struct {
+	Name    string `json:"name"`
+	Host    string `json:"host"`
+	Path    string `json:"path"`
+	Port    string `json:"port"`
+	Default bool   `json:"default"`
 }
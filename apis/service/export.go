@@ -1,14 +1,21 @@
 package service
 
 import (
+	"soul/apis/service/k8s/application"
+	"soul/apis/service/k8s/apptemplate"
 	"soul/apis/service/k8s/cluster"
 	"soul/apis/service/k8s/deployment"
+	"soul/apis/service/k8s/gateway"
+	"soul/apis/service/k8s/helm"
 	"soul/apis/service/k8s/ingress"
 	"soul/apis/service/k8s/namespace"
+	"soul/apis/service/k8s/node"
 	"soul/apis/service/k8s/pod"
 	"soul/apis/service/k8s/prometheus"
+	"soul/apis/service/k8s/scalepolicy"
 	"soul/apis/service/k8s/secret"
 	"soul/apis/service/k8s/svc"
+	"soul/apis/service/k8s/terminalrecord"
 	"soul/apis/service/system/dbInitializer"
 	"soul/apis/service/system/user"
 )
@@ -24,4 +31,14 @@ var (
 	K8sSecret                   secret.Secret
 	K8sCluster                  cluster.Cluster
 	K8sPrometheusServiceMonitor prometheus.ServiceMonitor
+	K8sScalePolicy              scalepolicy.ScalePolicy
+	K8sApplication              application.Application
+	K8sAppTemplate              apptemplate.AppTemplate
+	K8sHelm                     helm.Helm
+	K8sTerminalRecord           terminalrecord.TerminalRecord
+	K8sNode                     node.Node
+	K8sGatewayClass             gateway.GatewayClass
+	K8sGateway                  gateway.Gateway
+	K8sHTTPRoute                gateway.HTTPRoute
+	K8sReferenceGrant           gateway.ReferenceGrant
 )
@@ -0,0 +1,395 @@
+package application
+
+import (
+	"context"
+	"errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	ingressv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"soul/apis/dao"
+	"soul/apis/dto"
+	"soul/apis/service/k8s/deployment"
+	"soul/apis/service/k8s/ingress"
+	"soul/apis/service/k8s/svc"
+	"soul/global"
+	log "soul/internal/logger"
+	"soul/model"
+)
+
+const (
+	statusRunning     = "运行中"
+	statusProgressing = "更新中"
+	statusAbnormal    = "异常"
+	statusMissing     = "Deployment不存在"
+)
+
+type Application struct{}
+
+type deploymentStatus struct {
+	Name              string                       `json:"name"`
+	Replicas          int32                        `json:"replicas"`
+	ReadyReplicas     int32                        `json:"readyReplicas"`
+	AvailableReplicas int32                        `json:"availableReplicas"`
+	UpdatedReplicas   int32                        `json:"updatedReplicas"`
+	Images            []string                     `json:"images"`
+	Conditions        []appsv1.DeploymentCondition `json:"conditions"`
+}
+
+type serviceStatus struct {
+	Name      string               `json:"name"`
+	Type      corev1.ServiceType   `json:"type"`
+	ClusterIP string               `json:"clusterIP"`
+	Ports     []corev1.ServicePort `json:"ports"`
+}
+
+type ingressStatus struct {
+	Name      string   `json:"name"`
+	Hosts     []string `json:"hosts"`
+	Addresses []string `json:"addresses"`
+}
+
+// Detail 应用及其下所有资源的状态
+type Detail struct {
+	model.K8sApplication
+	Status      string             `json:"status"`
+	Deployments []deploymentStatus `json:"deployments"`
+	Services    []serviceStatus    `json:"services"`
+	Ingresses   []ingressStatus    `json:"ingresses"`
+}
+
+// Summary 应用列表项
+type Summary struct {
+	model.K8sApplication
+	Status string `json:"status"`
+}
+
+// CreateApplication 依次创建Deployment、Service和Ingress, 任意一步失败时删除已创建的资源
+func (a *Application) CreateApplication(clusterName string, create *dto.K8sApplicationCreate) (*model.K8sApplication, error) {
+	if dao.K8sApplication.GetApplication(clusterName, create.Namespace, create.Name) != nil {
+		return nil, errors.New("应用已存在")
+	}
+
+	labels := map[string]string{global.K8sAppLabel: create.Name}
+	svcCreate := create.ToSvcSimpleCreate(labels)
+	ingressCreate, err := create.ToIngressSimpleCreate(labels, svcCreate)
+	if err != nil {
+		return nil, err
+	}
+
+	app := &model.K8sApplication{
+		Name:        create.Name,
+		ClusterName: clusterName,
+		Namespace:   create.Namespace,
+		Description: create.Description,
+	}
+
+	d := &deployment.Deployment{}
+	if err = d.CreateDeployment(clusterName, &create.DeploymentCreate); err != nil {
+		return nil, err
+	}
+	app.DeploymentName = create.Name
+
+	if svcCreate != nil {
+		s := &svc.Svc{}
+		if err = s.CreateSimpleSvc(clusterName, svcCreate); err != nil {
+			a.rollback(clusterName, app)
+			return nil, err
+		}
+		app.ServiceName = svcCreate.Name
+	}
+
+	if ingressCreate != nil {
+		i := &ingress.Ingress{}
+		if err = i.CreateSimpleIngress(clusterName, ingressCreate, false); err != nil {
+			a.rollback(clusterName, app)
+			return nil, err
+		}
+		app.IngressName = ingressCreate.Name
+	}
+
+	if err = dao.K8sApplication.CreateApplication(app); err != nil {
+		a.rollback(clusterName, app)
+		return nil, err
+	}
+
+	return app, nil
+}
+
+// UpdateApplication 更新应用下的Deployment, 按需创建、更新或删除Service和Ingress
+func (a *Application) UpdateApplication(clusterName string, create *dto.K8sApplicationCreate) (*model.K8sApplication, error) {
+	app := dao.K8sApplication.GetApplication(clusterName, create.Namespace, create.Name)
+	if app == nil {
+		return nil, errors.New("应用不存在")
+	}
+
+	labels := map[string]string{global.K8sAppLabel: create.Name}
+	svcCreate := create.ToSvcSimpleCreate(labels)
+	ingressCreate, err := create.ToIngressSimpleCreate(labels, svcCreate)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &deployment.Deployment{}
+	if err = d.UpdateDeployment(clusterName, &create.DeploymentCreate); err != nil {
+		return nil, err
+	}
+
+	// 新增的Service需要在Ingress之前创建, 删除Service需要在删除Ingress之后
+	s := &svc.Svc{}
+	svcCreated := false
+	if svcCreate != nil && app.ServiceName == "" {
+		if err = s.CreateSimpleSvc(clusterName, svcCreate); err != nil {
+			return nil, err
+		}
+		app.ServiceName = svcCreate.Name
+		svcCreated = true
+	}
+
+	i := &ingress.Ingress{}
+	switch {
+	case ingressCreate == nil && app.IngressName != "":
+		err = i.DeleteIngressByName(clusterName, app.IngressName, app.Namespace)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		app.IngressName = ""
+	case ingressCreate != nil && app.IngressName != "":
+		if err = i.UpdateSimpleIngress(clusterName, ingressCreate, false); err != nil {
+			return nil, err
+		}
+	case ingressCreate != nil:
+		if err = i.CreateSimpleIngress(clusterName, ingressCreate, false); err != nil {
+			return nil, err
+		}
+		app.IngressName = ingressCreate.Name
+	}
+
+	switch {
+	case svcCreate == nil && app.ServiceName != "":
+		err = s.DeleteSvcByName(clusterName, app.ServiceName, app.Namespace)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		app.ServiceName = ""
+	case svcCreate != nil && !svcCreated:
+		if err = s.UpdateSimpleSvc(clusterName, svcCreate); err != nil {
+			return nil, err
+		}
+	}
+
+	app.Description = create.Description
+	if err = dao.K8sApplication.UpdateApplication(app); err != nil {
+		return nil, err
+	}
+	return app, nil
+}
+
+// rollback 删除创建应用过程中已经创建的资源
+func (a *Application) rollback(clusterName string, app *model.K8sApplication) {
+	client := global.K8s.Use(clusterName).ClientSet
+	opt := metav1.DeleteOptions{}
+
+	if app.IngressName != "" {
+		if err := client.NetworkingV1().Ingresses(app.Namespace).Delete(context.TODO(), app.IngressName, opt); err != nil {
+			log.Error("回滚应用%s的Ingress失败: %s", app.Name, err.Error())
+		}
+	}
+	if app.ServiceName != "" {
+		if err := client.CoreV1().Services(app.Namespace).Delete(context.TODO(), app.ServiceName, opt); err != nil {
+			log.Error("回滚应用%s的Service失败: %s", app.Name, err.Error())
+		}
+	}
+	if app.DeploymentName != "" {
+		if err := client.AppsV1().Deployments(app.Namespace).Delete(context.TODO(), app.DeploymentName, opt); err != nil {
+			log.Error("回滚应用%s的Deployment失败: %s", app.Name, err.Error())
+		}
+	}
+}
+
+func (a *Application) GetApplicationList(clusterName, namespace string) ([]Summary, error) {
+	apps := dao.K8sApplication.ListApplication(clusterName, namespace)
+
+	// 一次性获取所有Deployment, 避免逐个查询. 应用只包含记录中的Deployment, 不按标签匹配,
+	// 用户自己创建的Deployment也可能带有应用标签
+	deployments, err := global.K8s.Use(clusterName).ClientSet.AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	deploymentMap := map[string]appsv1.Deployment{}
+	for _, item := range deployments.Items {
+		deploymentMap[item.Namespace+"/"+item.Name] = item
+	}
+
+	summaries := make([]Summary, 0, len(apps))
+	for _, app := range apps {
+		var appDeployments []appsv1.Deployment
+		if item, ok := deploymentMap[app.Namespace+"/"+app.DeploymentName]; ok && app.DeploymentName != "" {
+			appDeployments = append(appDeployments, item)
+		}
+		summaries = append(summaries, Summary{
+			K8sApplication: app,
+			Status:         aggregateStatus(appDeployments),
+		})
+	}
+	return summaries, nil
+}
+
+// GetApplicationByName 获取应用以及应用记录中的Deployment、Service、Ingress的状态, 集群中已经不存在的资源不返回
+func (a *Application) GetApplicationByName(clusterName, namespace, name string) (*Detail, error) {
+	app := dao.K8sApplication.GetApplication(clusterName, namespace, name)
+	if app == nil {
+		return nil, errors.New("应用不存在")
+	}
+
+	client := global.K8s.Use(clusterName).ClientSet
+	opt := metav1.GetOptions{}
+
+	var deployments []appsv1.Deployment
+	if app.DeploymentName != "" {
+		item, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), app.DeploymentName, opt)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			deployments = append(deployments, *item)
+		}
+	}
+
+	detail := &Detail{
+		K8sApplication: *app,
+		Status:         aggregateStatus(deployments),
+		Deployments:    make([]deploymentStatus, 0, len(deployments)),
+		Services:       []serviceStatus{},
+		Ingresses:      []ingressStatus{},
+	}
+	for _, item := range deployments {
+		detail.Deployments = append(detail.Deployments, toDeploymentStatus(item))
+	}
+
+	if app.ServiceName != "" {
+		item, err := client.CoreV1().Services(namespace).Get(context.TODO(), app.ServiceName, opt)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			detail.Services = append(detail.Services, serviceStatus{
+				Name:      item.Name,
+				Type:      item.Spec.Type,
+				ClusterIP: item.Spec.ClusterIP,
+				Ports:     item.Spec.Ports,
+			})
+		}
+	}
+
+	if app.IngressName != "" {
+		item, err := client.NetworkingV1().Ingresses(namespace).Get(context.TODO(), app.IngressName, opt)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			detail.Ingresses = append(detail.Ingresses, toIngressStatus(*item))
+		}
+	}
+
+	return detail, nil
+}
+
+// DeleteApplication 依次删除应用记录中的Ingress、Service、Deployment, 然后删除应用记录.
+// 只删除应用创建的资源, 不按标签删除, 避免误删带有相同标签的其它资源
+func (a *Application) DeleteApplication(clusterName, namespace, name string) error {
+	app := dao.K8sApplication.GetApplication(clusterName, namespace, name)
+	if app == nil {
+		return errors.New("应用不存在")
+	}
+
+	client := global.K8s.Use(clusterName).ClientSet
+	deleteOpt := metav1.DeleteOptions{}
+
+	if app.IngressName != "" {
+		err := client.NetworkingV1().Ingresses(namespace).Delete(context.TODO(), app.IngressName, deleteOpt)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	if app.ServiceName != "" {
+		err := client.CoreV1().Services(namespace).Delete(context.TODO(), app.ServiceName, deleteOpt)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	if app.DeploymentName != "" {
+		err := client.AppsV1().Deployments(namespace).Delete(context.TODO(), app.DeploymentName, deleteOpt)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	// 由模板创建的应用同时删除实例记录
+	if err := dao.K8sAppTemplate.DeleteTemplateInstance(clusterName, namespace, name); err != nil {
+		return err
+	}
+	return dao.K8sApplication.DeleteApplicationById(app.ID.ID)
+}
+
+// aggregateStatus 根据应用下所有Deployment的副本状态计算应用状态
+func aggregateStatus(deployments []appsv1.Deployment) string {
+	if len(deployments) == 0 {
+		return statusMissing
+	}
+
+	status := statusRunning
+	for _, item := range deployments {
+		var replicas int32 = 1
+		if item.Spec.Replicas != nil {
+			replicas = *item.Spec.Replicas
+		}
+		for _, condition := range item.Status.Conditions {
+			if condition.Type == appsv1.DeploymentProgressing && condition.Status == corev1.ConditionFalse {
+				return statusAbnormal
+			}
+		}
+		if item.Status.UpdatedReplicas < replicas || item.Status.AvailableReplicas < replicas || item.Status.Replicas > replicas {
+			status = statusProgressing
+		}
+	}
+	return status
+}
+
+func toDeploymentStatus(item appsv1.Deployment) deploymentStatus {
+	var replicas int32 = 1
+	if item.Spec.Replicas != nil {
+		replicas = *item.Spec.Replicas
+	}
+	var images []string
+	for _, c := range item.Spec.Template.Spec.Containers {
+		images = append(images, c.Image)
+	}
+	return deploymentStatus{
+		Name:              item.Name,
+		Replicas:          replicas,
+		ReadyReplicas:     item.Status.ReadyReplicas,
+		AvailableReplicas: item.Status.AvailableReplicas,
+		UpdatedReplicas:   item.Status.UpdatedReplicas,
+		Images:            images,
+		Conditions:        item.Status.Conditions,
+	}
+}
+
+func toIngressStatus(item ingressv1.Ingress) ingressStatus {
+	status := ingressStatus{Name: item.Name, Hosts: []string{}, Addresses: []string{}}
+	for _, rule := range item.Spec.Rules {
+		if rule.Host != "" {
+			status.Hosts = append(status.Hosts, rule.Host)
+		}
+	}
+	for _, lb := range item.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			status.Addresses = append(status.Addresses, lb.IP)
+		} else if lb.Hostname != "" {
+			status.Addresses = append(status.Addresses, lb.Hostname)
+		}
+	}
+	return status
+}
@@ -0,0 +1,109 @@
+package apptemplate
+
+import (
+	"errors"
+	"reflect"
+	"soul/apis/dao"
+	"soul/apis/dto"
+	"soul/model"
+)
+
+type AppTemplate struct{}
+
+func (a *AppTemplate) ListAppTemplate() []model.K8sAppTemplate {
+	return dao.K8sAppTemplate.ListAppTemplate()
+}
+
+func (a *AppTemplate) GetAppTemplateById(id uint) (*model.K8sAppTemplate, error) {
+	template := dao.K8sAppTemplate.GetAppTemplateById(id)
+	if template == nil {
+		return nil, errors.New("模板不存在")
+	}
+	return template, nil
+}
+
+func (a *AppTemplate) ListAppTemplateVersion(id uint) ([]model.K8sAppTemplateVersion, error) {
+	if _, err := a.GetAppTemplateById(id); err != nil {
+		return nil, err
+	}
+	return dao.K8sAppTemplate.ListAppTemplateVersion(id), nil
+}
+
+func (a *AppTemplate) CreateAppTemplate(create dto.K8sAppTemplateCreate) (*model.K8sAppTemplate, error) {
+	if dao.K8sAppTemplate.GetAppTemplateByName(create.Name) != nil {
+		return nil, errors.New("模板名称已存在")
+	}
+
+	parameters := toParameters(create.Parameters)
+	if err := checkParameters(parameters, create.Content); err != nil {
+		return nil, err
+	}
+
+	template := &model.K8sAppTemplate{
+		Name:        create.Name,
+		Description: create.Description,
+		Version:     1,
+		Parameters:  parameters,
+		Content:     create.Content,
+	}
+	if err := dao.K8sAppTemplate.CreateAppTemplate(template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// UpdateAppTemplate 更新模板, 参数定义或模板内容有变化时生成新版本, 已有实例不受影响
+func (a *AppTemplate) UpdateAppTemplate(id uint, create dto.K8sAppTemplateCreate) (*model.K8sAppTemplate, error) {
+	template, err := a.GetAppTemplateById(id)
+	if err != nil {
+		return nil, err
+	}
+	if exist := dao.K8sAppTemplate.GetAppTemplateByName(create.Name); exist != nil && exist.ID.ID != id {
+		return nil, errors.New("模板名称已存在")
+	}
+
+	parameters := toParameters(create.Parameters)
+	if err = checkParameters(parameters, create.Content); err != nil {
+		return nil, err
+	}
+
+	newVersion := template.Content != create.Content || !reflect.DeepEqual(template.Parameters, parameters)
+	if newVersion {
+		template.Version++
+	}
+	template.Name = create.Name
+	template.Description = create.Description
+	template.Parameters = parameters
+	template.Content = create.Content
+
+	if err = dao.K8sAppTemplate.UpdateAppTemplate(template, newVersion); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (a *AppTemplate) DeleteAppTemplate(id uint) error {
+	if _, err := a.GetAppTemplateById(id); err != nil {
+		return err
+	}
+	if dao.K8sAppTemplate.CountTemplateInstance(id) != 0 {
+		return errors.New("模板已被实例化, 请先删除由该模板创建的应用")
+	}
+	return dao.K8sAppTemplate.DeleteAppTemplateById(id)
+}
+
+func toParameters(params []dto.K8sTemplateParameter) []model.K8sTemplateParameter {
+	parameters := make([]model.K8sTemplateParameter, 0, len(params))
+	for _, p := range params {
+		parameters = append(parameters, model.K8sTemplateParameter{
+			Name:        p.Name,
+			Label:       p.Label,
+			Description: p.Description,
+			Type:        p.Type,
+			Required:    p.Required,
+			Default:     p.Default,
+			Options:     p.Options,
+		})
+	}
+	return parameters
+}
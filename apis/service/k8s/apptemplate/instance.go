@@ -0,0 +1,125 @@
+package apptemplate
+
+import (
+	"errors"
+	"soul/apis/dao"
+	"soul/apis/dto"
+	"soul/apis/service/k8s/application"
+	log "soul/internal/logger"
+	"soul/model"
+)
+
+// getVersion 获取模板的指定版本, version为0时返回最新版本
+func getVersion(templateId, version uint) (*model.K8sAppTemplateVersion, error) {
+	template := dao.K8sAppTemplate.GetAppTemplateById(templateId)
+	if template == nil {
+		return nil, errors.New("模板不存在")
+	}
+	if version == 0 {
+		version = template.Version
+	}
+
+	templateVersion := dao.K8sAppTemplate.GetAppTemplateVersion(templateId, version)
+	if templateVersion == nil {
+		return nil, errors.New("模板版本不存在")
+	}
+	return templateVersion, nil
+}
+
+func (a *AppTemplate) ListTemplateInstance(clusterName, namespace string) []model.K8sTemplateInstance {
+	return dao.K8sAppTemplate.ListTemplateInstance(clusterName, namespace)
+}
+
+func (a *AppTemplate) GetTemplateInstance(clusterName, namespace, name string) (*model.K8sTemplateInstance, error) {
+	instance := dao.K8sAppTemplate.GetTemplateInstance(clusterName, namespace, name)
+	if instance == nil {
+		return nil, errors.New("模板实例不存在")
+	}
+	return instance, nil
+}
+
+// Instantiate 渲染模板并创建应用, dryRun时只返回渲染结果
+func (a *AppTemplate) Instantiate(clusterName string, create dto.K8sTemplateInstanceCreate, dryRun bool) (any, error) {
+	templateVersion, err := getVersion(create.TemplateID, create.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := resolveValues(templateVersion.Parameters, create.Values)
+	if err != nil {
+		return nil, err
+	}
+	appCreate, err := renderApplication(templateVersion.Content, values, create.Name, create.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return appCreate, nil
+	}
+
+	app := &application.Application{}
+	if _, err = app.CreateApplication(clusterName, appCreate); err != nil {
+		return nil, err
+	}
+
+	instance := &model.K8sTemplateInstance{
+		TemplateID:      templateVersion.TemplateID,
+		TemplateVersion: templateVersion.Version,
+		ClusterName:     clusterName,
+		Namespace:       create.Namespace,
+		Name:            create.Name,
+		Values:          values,
+	}
+	if err = dao.K8sAppTemplate.CreateTemplateInstance(instance); err != nil {
+		// 没有实例记录的应用无法升级, 删除刚创建的应用
+		if deleteErr := app.DeleteApplication(clusterName, create.Namespace, create.Name); deleteErr != nil {
+			log.Error(deleteErr.Error())
+		}
+		return nil, err
+	}
+	return instance, nil
+}
+
+// Upgrade 使用指定版本的模板和合并后的参数重新渲染并更新应用, dryRun时只返回渲染结果
+func (a *AppTemplate) Upgrade(clusterName, namespace, name string, upgrade dto.K8sTemplateInstanceUpgrade, dryRun bool) (any, error) {
+	instance, err := a.GetTemplateInstance(clusterName, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	templateVersion, err := getVersion(instance.TemplateID, upgrade.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := map[string]any{}
+	for k, v := range instance.Values {
+		merged[k] = v
+	}
+	for k, v := range upgrade.Values {
+		merged[k] = v
+	}
+
+	values, err := resolveValues(templateVersion.Parameters, merged)
+	if err != nil {
+		return nil, err
+	}
+	appCreate, err := renderApplication(templateVersion.Content, values, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return appCreate, nil
+	}
+
+	app := &application.Application{}
+	if _, err = app.UpdateApplication(clusterName, appCreate); err != nil {
+		return nil, err
+	}
+
+	instance.TemplateVersion = templateVersion.Version
+	instance.Values = values
+	if err = dao.K8sAppTemplate.UpdateTemplateInstance(instance); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
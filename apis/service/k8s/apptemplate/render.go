@@ -0,0 +1,255 @@
+package apptemplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin/binding"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"math"
+	"regexp"
+	"soul/apis/dto"
+	"soul/model"
+	"soul/utils/httputil"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var (
+	paramNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	imageRegexp     = regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*(:[0-9]+)?(/[a-z0-9]+([._-][a-z0-9]+)*)*(:[\w][\w.-]{0,127})?(@sha256:[a-f0-9]{64})?$`)
+)
+
+// sampleValues 校验模板时, 必填且没有默认值的参数使用的示例值
+var sampleValues = map[string]any{
+	"string": "sample",
+	"int":    int64(1),
+	"bool":   true,
+	"image":  "nginx:latest",
+	"domain": "example.com",
+	"cpu":    "100m",
+	"memory": "128Mi",
+}
+
+var funcMap = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// jsonString 模板中的字符串参数, 输出时按json字符串转义(不含引号),
+// 避免参数值中的引号、换行等字符破坏渲染结果或注入额外的字段
+type jsonString string
+
+func (s jsonString) String() string {
+	data, _ := json.Marshal(string(s))
+	return string(data[1 : len(data)-1])
+}
+
+// escapeValues 将字符串参数转换为 jsonString, 其它类型原样返回
+func escapeValues(values map[string]any) map[string]any {
+	escaped := make(map[string]any, len(values))
+	for k, v := range values {
+		if s, ok := v.(string); ok {
+			escaped[k] = jsonString(s)
+			continue
+		}
+		escaped[k] = v
+	}
+	return escaped
+}
+
+// checkParameters 校验参数定义, 并使用默认值或示例值试渲染一次模板
+func checkParameters(parameters []model.K8sTemplateParameter, content string) error {
+	names := map[string]struct{}{}
+	for _, p := range parameters {
+		if !paramNameRegexp.MatchString(p.Name) {
+			return fmt.Errorf("参数名%s只能包含字母、数字和下划线, 且不能以数字开头", p.Name)
+		}
+		if _, ok := names[p.Name]; ok {
+			return fmt.Errorf("参数%s重复定义", p.Name)
+		}
+		names[p.Name] = struct{}{}
+
+		if len(p.Options) != 0 && p.Type != "string" {
+			return fmt.Errorf("参数%s: 只有string类型的参数可以设置可选值", p.Name)
+		}
+		if p.Default != nil {
+			if _, err := convertValue(p, p.Default); err != nil {
+				return fmt.Errorf("参数%s的默认值不合法: %s", p.Name, err.Error())
+			}
+		}
+	}
+
+	values := map[string]any{}
+	for _, p := range parameters {
+		switch {
+		case p.Default != nil:
+			values[p.Name] = p.Default
+		case len(p.Options) != 0:
+			values[p.Name] = p.Options[0]
+		default:
+			values[p.Name] = sampleValues[p.Type]
+		}
+	}
+	values, err := resolveValues(parameters, values)
+	if err != nil {
+		return err
+	}
+	if _, err = render(content, values); err != nil {
+		return err
+	}
+	return nil
+}
+
+// resolveValues 按参数定义校验并转换参数值, 未填写的使用默认值, 未定义的参数会被忽略
+func resolveValues(parameters []model.K8sTemplateParameter, values map[string]any) (map[string]any, error) {
+	resolved := map[string]any{}
+	var errs []string
+	for _, p := range parameters {
+		value, ok := values[p.Name]
+		if !ok || value == nil || value == "" {
+			value = p.Default
+		}
+		if value == nil {
+			if p.Required {
+				errs = append(errs, fmt.Sprintf("参数%s不能为空", p.Name))
+				continue
+			}
+			resolved[p.Name] = zeroValue(p.Type)
+			continue
+		}
+
+		converted, err := convertValue(p, value)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("参数%s%s", p.Name, err.Error()))
+			continue
+		}
+		resolved[p.Name] = converted
+	}
+	if len(errs) != 0 {
+		return nil, errors.New(strings.Join(errs, ","))
+	}
+	return resolved, nil
+}
+
+func zeroValue(paramType string) any {
+	switch paramType {
+	case "int":
+		return int64(0)
+	case "bool":
+		return false
+	default:
+		return ""
+	}
+}
+
+// convertValue 校验参数值是否符合参数类型, json中的数字会被转换为int64
+func convertValue(p model.K8sTemplateParameter, value any) (any, error) {
+	switch p.Type {
+	case "int":
+		switch v := value.(type) {
+		case float64:
+			if v != math.Trunc(v) {
+				return nil, errors.New("必须是整数")
+			}
+			return int64(v), nil
+		case int64:
+			return v, nil
+		case int:
+			return int64(v), nil
+		case string:
+			i, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, errors.New("必须是整数")
+			}
+			return i, nil
+		}
+		return nil, errors.New("必须是整数")
+	case "bool":
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, errors.New("必须是true或false")
+			}
+			return b, nil
+		}
+		return nil, errors.New("必须是true或false")
+	}
+
+	v, ok := value.(string)
+	if !ok {
+		return nil, errors.New("必须是字符串")
+	}
+	switch p.Type {
+	case "string":
+		if len(p.Options) != 0 {
+			for _, option := range p.Options {
+				if v == option {
+					return v, nil
+				}
+			}
+			return nil, fmt.Errorf("只能是%s", strings.Join(p.Options, "、"))
+		}
+	case "image":
+		if !imageRegexp.MatchString(v) {
+			return nil, errors.New("不是合法的镜像地址")
+		}
+	case "domain":
+		if msgs := validation.IsDNS1123Subdomain(strings.TrimPrefix(v, "*.")); len(msgs) != 0 {
+			return nil, errors.New("不是合法的域名")
+		}
+	case "cpu", "memory":
+		if _, err := resource.ParseQuantity(v); err != nil {
+			return nil, fmt.Errorf("不是合法的%s资源数量", p.Type)
+		}
+	}
+	return v, nil
+}
+
+// render 渲染模板, 结果为json格式的 dto.K8sApplicationCreate. 字符串参数默认按json转义, 使用json函数时输出带引号的json字符串
+func render(content string, values map[string]any) (*dto.K8sApplicationCreate, error) {
+	tpl, err := template.New("app").Option("missingkey=error").Funcs(funcMap).Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("模板语法错误: %s", err.Error())
+	}
+
+	var buf bytes.Buffer
+	if err = tpl.Execute(&buf, escapeValues(values)); err != nil {
+		return nil, fmt.Errorf("模板渲染失败: %s", err.Error())
+	}
+
+	// 默认值与 deployment.CreateDeployment 保持一致
+	appCreate := &dto.K8sApplicationCreate{
+		DeploymentCreate: dto.K8sDeploymentCreate{
+			Replicas:             1,
+			RevisionHistoryLimit: 10,
+		},
+	}
+	if err = json.Unmarshal(buf.Bytes(), appCreate); err != nil {
+		return nil, fmt.Errorf("模板渲染结果不是合法的json: %s", err.Error())
+	}
+	return appCreate, nil
+}
+
+// renderApplication 渲染模板并校验渲染结果, 应用名称和Namespace以实例为准
+func renderApplication(content string, values map[string]any, name, namespace string) (*dto.K8sApplicationCreate, error) {
+	appCreate, err := render(content, values)
+	if err != nil {
+		return nil, err
+	}
+	appCreate.Name = name
+	appCreate.Namespace = namespace
+
+	if err = binding.Validator.ValidateStruct(appCreate); err != nil {
+		return nil, errors.New("模板渲染结果校验失败: " + httputil.ParseValidateError(err, appCreate).Error())
+	}
+	return appCreate, nil
+}
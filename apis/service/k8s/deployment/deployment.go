@@ -7,7 +7,6 @@ import (
 	"fmt"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -15,6 +14,7 @@ import (
 	"soul/apis/dto"
 	"soul/apis/service/k8s"
 	"soul/global"
+	"soul/utils/diffutil"
 	"soul/utils/httputil"
 	"time"
 )
@@ -86,16 +86,48 @@ func (d *Deployment) GetDeploymentList(clusterName, filterName, namespace string
 	}, nil
 }
 
-func (d *Deployment) GetDeploymentPods(clusterName, name, namespace string) (pods *corev1.PodList, err error) {
+// GetDeploymentSelector 获取Deployment的Pod标签选择器
+func (d *Deployment) GetDeploymentSelector(clusterName, name, namespace string) (string, error) {
 	deployment, err := d.GetDeploymentByName(clusterName, name, namespace)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	// 将map转换为类似这种形式 app=client-go-deploy,name
+	return metav1.FormatLabelSelector(deployment.Spec.Selector), nil
+}
+
+// GetDeploymentDetail 获取Deployment和所有Pod资源使用量的合计, metrics-server不可用时metrics为空
+func (d *Deployment) GetDeploymentDetail(ctx context.Context, clusterName, name, namespace string) (*dto.K8sDeploymentWithMetrics, error) {
+	deployment, err := global.K8s.Use(clusterName).ClientSet.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
 	selector := metav1.FormatLabelSelector(deployment.Spec.Selector)
 
-	pods, err = global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{
+	detail := &dto.K8sDeploymentWithMetrics{Deployment: *deployment}
+	metrics := k8s.ListPodMetrics(ctx, clusterName, namespace, selector)
+	if metrics == nil {
+		return detail, nil
+	}
+	pods, err := global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	detail.Metrics = metrics.Sum(pods.Items)
+	return detail, nil
+}
+
+// GetDeploymentPods Deployment的Pod列表, 每个Pod带有资源使用量, metrics-server不可用时metrics为空
+func (d *Deployment) GetDeploymentPods(clusterName, name, namespace string) ([]dto.K8sPodWithMetrics, error) {
+	selector, err := d.GetDeploymentSelector(clusterName, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{
 		LabelSelector: selector,
 	})
 
@@ -103,25 +135,116 @@ func (d *Deployment) GetDeploymentPods(clusterName, name, namespace string) (pod
 		return nil, err
 	}
 
-	return pods, nil
+	metrics := k8s.ListPodMetrics(context.TODO(), clusterName, namespace, selector)
+	items := make([]dto.K8sPodWithMetrics, 0, len(pods.Items))
+	for i := range pods.Items {
+		items = append(items, dto.K8sPodWithMetrics{Pod: pods.Items[i], Metrics: metrics.Of(&pods.Items[i])})
+	}
+	return items, nil
 }
 
 func (d *Deployment) CreateDeployment(clusterName string, deploymentCreate *dto.K8sDeploymentCreate) (err error) {
-	deploymentCreate.RevisionHistoryLimit = 10
-	deploymentCreate.Strategy.MaxUnavailable = "20%"
-	deploymentCreate.Strategy.MaxSurge = "20%"
-	maxUnavailable := intstr.Parse(deploymentCreate.Strategy.MaxUnavailable)
-	maxSurge := intstr.Parse(deploymentCreate.Strategy.MaxSurge)
-	deploymentCreate.Label["handovercloud.soulchild.cn/app"] = deploymentCreate.Name
-
-	// 组装containerPort
-	var containerPort []corev1.ContainerPort
-	for _, item := range deploymentCreate.ContainerPort {
-		containerPort = append(containerPort, corev1.ContainerPort{
-			Name:          item.Name,
-			ContainerPort: item.Port,
-			Protocol:      corev1.Protocol(item.Protocol),
-		})
+	deployment, err := d.createToDeployment(deploymentCreate)
+	if err != nil {
+		return err
+	}
+
+	_, err = global.K8s.Use(clusterName).ClientSet.AppsV1().Deployments(deploymentCreate.Namespace).Create(context.TODO(), deployment, metav1.CreateOptions{
+		FieldManager: global.K8sManager,
+	})
+	if err != nil {
+		return err
+	}
+	return
+}
+
+// UpdateDeployment 使用 dto.K8sDeploymentCreate 生成的对象整体替换集群中的Deployment
+func (d *Deployment) UpdateDeployment(clusterName string, deploymentCreate *dto.K8sDeploymentCreate) (err error) {
+	deployment, err := d.createToDeployment(deploymentCreate)
+	if err != nil {
+		return err
+	}
+
+	// selector创建后不可修改, 沿用集群中的selector并保证Pod模板带有这些标签
+	deployments := global.K8s.Use(clusterName).ClientSet.AppsV1().Deployments(deploymentCreate.Namespace)
+	live, err := deployments.Get(context.TODO(), deploymentCreate.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if live.Spec.Selector != nil {
+		deployment.Spec.Selector = live.Spec.Selector
+		templateLabels := make(map[string]string, len(deployment.Spec.Template.Labels))
+		for k, v := range deployment.Spec.Template.Labels {
+			templateLabels[k] = v
+		}
+		for k, v := range live.Spec.Selector.MatchLabels {
+			templateLabels[k] = v
+		}
+		deployment.Spec.Template.Labels = templateLabels
+	}
+
+	_, err = deployments.Update(context.TODO(), deployment, metav1.UpdateOptions{
+		FieldManager: global.K8sManager,
+	})
+	if err != nil {
+		return err
+	}
+	return
+}
+
+// createToDeployment 将 dto.K8sDeploymentCreate 转换为 appsv1.Deployment
+func (d *Deployment) createToDeployment(deploymentCreate *dto.K8sDeploymentCreate) (*appsv1.Deployment, error) {
+	deploymentCreate.SetDefaults()
+	if len(deploymentCreate.Containers) == 0 {
+		return nil, errors.New("至少需要一个容器, containers和image不能同时为空")
+	}
+	deploymentCreate.Label[global.K8sAppLabel] = deploymentCreate.Name
+
+	var containers, initContainers []corev1.Container
+	for _, item := range deploymentCreate.Containers {
+		container, err := item.ToContainer()
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, container)
+	}
+	for _, item := range deploymentCreate.InitContainers {
+		container, err := item.ToContainer()
+		if err != nil {
+			return nil, err
+		}
+		initContainers = append(initContainers, container)
+	}
+
+	var volumes []corev1.Volume
+	for _, item := range deploymentCreate.Volumes {
+		volume, err := item.ToVolume()
+		if err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, volume)
+	}
+
+	var tolerations []corev1.Toleration
+	for _, item := range deploymentCreate.Tolerations {
+		tolerations = append(tolerations, item.ToToleration())
+	}
+
+	var imagePullSecrets []corev1.LocalObjectReference
+	for _, name := range deploymentCreate.ImagePullSecrets {
+		imagePullSecrets = append(imagePullSecrets, corev1.LocalObjectReference{Name: name})
+	}
+
+	strategy := appsv1.DeploymentStrategy{
+		Type: appsv1.DeploymentStrategyType(deploymentCreate.Strategy.Type),
+	}
+	if strategy.Type == appsv1.RollingUpdateDeploymentStrategyType {
+		maxUnavailable := intstr.Parse(deploymentCreate.Strategy.MaxUnavailable)
+		maxSurge := intstr.Parse(deploymentCreate.Strategy.MaxSurge)
+		strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{
+			MaxUnavailable: &maxUnavailable,
+			MaxSurge:       &maxSurge,
+		}
 	}
 
 	deployment := &appsv1.Deployment{
@@ -143,103 +266,24 @@ func (d *Deployment) CreateDeployment(clusterName string, deploymentCreate *dto.
 					//Annotations:                nil,
 				},
 				Spec: corev1.PodSpec{
-					Volumes:        nil,
-					InitContainers: nil,
-					Containers: []corev1.Container{
-						{
-							Name:  deploymentCreate.Name,
-							Image: deploymentCreate.Image,
-							Ports: containerPort,
-							Resources: corev1.ResourceRequirements{
-								Limits: corev1.ResourceList{
-									corev1.ResourceCPU:    resource.MustParse(deploymentCreate.Cpu),
-									corev1.ResourceMemory: resource.MustParse(deploymentCreate.Memory),
-								},
-								Requests: corev1.ResourceList{
-									corev1.ResourceCPU:    resource.MustParse(deploymentCreate.Cpu),
-									corev1.ResourceMemory: resource.MustParse(deploymentCreate.Memory),
-								},
-							},
-						},
-					},
-					RestartPolicy: corev1.RestartPolicyAlways,
-					ImagePullSecrets: []corev1.LocalObjectReference{
-						{Name: deploymentCreate.ImagePullSecret},
-					},
-				},
-			},
-			Strategy: appsv1.DeploymentStrategy{
-				Type: appsv1.RollingUpdateDeploymentStrategyType,
-				RollingUpdate: &appsv1.RollingUpdateDeployment{
-					MaxUnavailable: &maxUnavailable,
-					MaxSurge:       &maxSurge,
+					Volumes:          volumes,
+					InitContainers:   initContainers,
+					Containers:       containers,
+					RestartPolicy:    corev1.RestartPolicyAlways,
+					NodeSelector:     deploymentCreate.NodeSelector,
+					ImagePullSecrets: imagePullSecrets,
+					Affinity:         deploymentCreate.Affinity,
+					Tolerations:      tolerations,
 				},
 			},
+			Strategy:                strategy,
 			MinReadySeconds:         0,
 			RevisionHistoryLimit:    &deploymentCreate.RevisionHistoryLimit,
 			ProgressDeadlineSeconds: nil, // 如果n秒后还没更新好则停止滚动更新，deployment不会主动再发出更新操作
 		},
 	}
 
-	if deploymentCreate.ContainerPort != nil && deploymentCreate.HttpHealthCheck.HttpHealthPath != "" {
-		if deploymentCreate.HttpHealthCheck.HttpHealthPort == "" {
-			deploymentCreate.HttpHealthCheck.HttpHealthPort = deploymentCreate.ContainerPort[0].Name
-		}
-		deployment.Spec.Template.Spec.Containers[0].ReadinessProbe = &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
-				HTTPGet: &corev1.HTTPGetAction{
-					Path:   deploymentCreate.HttpHealthCheck.HttpHealthPath,
-					Port:   intstr.Parse(deploymentCreate.HttpHealthCheck.HttpHealthPort),
-					Scheme: "HTTP",
-				},
-			},
-			InitialDelaySeconds:           10,
-			TimeoutSeconds:                2,
-			PeriodSeconds:                 10,
-			SuccessThreshold:              1,
-			FailureThreshold:              3,
-			TerminationGracePeriodSeconds: nil, // 检查到异常时给程序优雅停止的时间，需要启用ProbeTerminationGracePeriod特性
-		}
-		deployment.Spec.Template.Spec.Containers[0].LivenessProbe = &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
-				HTTPGet: &corev1.HTTPGetAction{
-					Path:   deploymentCreate.HttpHealthCheck.HttpHealthPath,
-					Port:   intstr.Parse(deploymentCreate.HttpHealthCheck.HttpHealthPort),
-					Scheme: "HTTP",
-				},
-			},
-			InitialDelaySeconds:           10,
-			TimeoutSeconds:                2,
-			PeriodSeconds:                 10,
-			SuccessThreshold:              1,
-			FailureThreshold:              3,
-			TerminationGracePeriodSeconds: nil, // 检查到异常时给程序优雅停止的时间，需要启用ProbeTerminationGracePeriod特性
-		}
-		deployment.Spec.Template.Spec.Containers[0].StartupProbe = &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
-				HTTPGet: &corev1.HTTPGetAction{
-					Path:   deploymentCreate.HttpHealthCheck.HttpHealthPath,
-					Port:   intstr.Parse(deploymentCreate.HttpHealthCheck.HttpHealthPort),
-					Scheme: "HTTP",
-				},
-			},
-			// 给予105s的启动时间, or (10+3) * 10 + 5 = 135 ?
-			InitialDelaySeconds:           5,
-			TimeoutSeconds:                3,
-			PeriodSeconds:                 10,
-			SuccessThreshold:              1,
-			FailureThreshold:              10,
-			TerminationGracePeriodSeconds: nil, // 检查到异常时给程序优雅停止的时间，需要启用ProbeTerminationGracePeriod特性
-		}
-	}
-
-	_, err = global.K8s.Use(clusterName).ClientSet.AppsV1().Deployments(deploymentCreate.Namespace).Create(context.TODO(), deployment, metav1.CreateOptions{
-		FieldManager: global.K8sManager,
-	})
-	if err != nil {
-		return err
-	}
-	return
+	return deployment, nil
 }
 
 func (d *Deployment) ScaleDeployment(clusterName, deploymentName, namespace string, scaleNum int32) (err error) {
@@ -339,10 +383,9 @@ func (d *Deployment) RestartDeployment(clusterName, deploymentName string, names
 }
 
 func (d *Deployment) UpdateK8sDeployment(clusterName, content string) (err error) {
-	deploy := &appsv1.Deployment{}
-	err = json.Unmarshal([]byte(content), deploy)
+	deploy, err := d.parseK8sDeployment(content)
 	if err != nil {
-		return errors.New("反序列化失败,请检查yaml。" + err.Error())
+		return err
 	}
 
 	//deploy.ObjectMeta.ManagedFields = []metav1.ManagedFieldsEntry{
@@ -358,3 +401,34 @@ func (d *Deployment) UpdateK8sDeployment(clusterName, content string) (err error
 	}
 	return nil
 }
+
+// DiffK8sDeployment 使用 dry-run 模拟更新, 返回集群中的对象和更新后对象的差异
+func (d *Deployment) DiffK8sDeployment(clusterName, content string) (*diffutil.Result, error) {
+	deploy, err := d.parseK8sDeployment(content)
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := d.GetDeploymentByName(clusterName, deploy.Name, deploy.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := global.K8s.Use(clusterName).ClientSet.AppsV1().Deployments(deploy.Namespace).Update(context.TODO(), deploy, metav1.UpdateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	})
+	if err != nil {
+		return nil, errors.New("更新Deployment失败," + err.Error())
+	}
+
+	return k8s.DiffObject("deployment", deploy.Name, live, result)
+}
+
+func (d *Deployment) parseK8sDeployment(content string) (*appsv1.Deployment, error) {
+	deploy := &appsv1.Deployment{}
+	err := json.Unmarshal([]byte(content), deploy)
+	if err != nil {
+		return nil, errors.New("反序列化失败,请检查yaml。" + err.Error())
+	}
+	return deploy, nil
+}
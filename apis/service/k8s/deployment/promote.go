@@ -0,0 +1,337 @@
+package deployment
+
+import (
+	"context"
+	"errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"soul/apis/dto"
+	"soul/apis/service/k8s"
+	"soul/global"
+	"strings"
+)
+
+var (
+	deploymentGVR = appsv1.SchemeGroupVersion.WithResource("deployments")
+	serviceGVR    = corev1.SchemeGroupVersion.WithResource("services")
+	configMapGVR  = corev1.SchemeGroupVersion.WithResource("configmaps")
+	secretGVR     = corev1.SchemeGroupVersion.WithResource("secrets")
+	ingressGVR    = networkingv1.SchemeGroupVersion.WithResource("ingresses")
+)
+
+type promoteObject struct {
+	gvr schema.GroupVersionResource
+	gvk schema.GroupVersionKind
+	obj runtime.Object
+}
+
+// PromoteDeployment 将Deployment及其关联的Service、Ingress、ConfigMap、Secret复制到目标集群/Namespace
+func (d *Deployment) PromoteDeployment(clusterName, deploymentName, namespace string, promote *dto.K8sDeploymentPromote) ([]dto.K8sPromoteItem, error) {
+	if global.K8s.Get(promote.TargetCluster) == nil {
+		return nil, errors.New("目标集群不存在")
+	}
+	if promote.TargetCluster == clusterName && promote.TargetNamespace == namespace {
+		return nil, errors.New("目标集群和Namespace不能与源相同")
+	}
+
+	objects, err := d.collectPromoteObjects(clusterName, deploymentName, namespace, promote)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []dto.K8sPromoteItem
+	for _, object := range objects {
+		items = append(items, d.applyPromoteObject(promote.TargetCluster, promote.TargetNamespace, object, promote.DryRun))
+	}
+	return items, nil
+}
+
+// collectPromoteObjects 获取需要迁移的对象, 并清理集群相关的元数据
+func (d *Deployment) collectPromoteObjects(clusterName, deploymentName, namespace string, promote *dto.K8sDeploymentPromote) ([]promoteObject, error) {
+	clientSet := global.K8s.Use(clusterName).ClientSet
+
+	deployment, err := d.GetDeploymentByName(clusterName, deploymentName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	deployment.Status = appsv1.DeploymentStatus{}
+	cleanObjectMeta(&deployment.ObjectMeta)
+	delete(deployment.Annotations, "deployment.kubernetes.io/revision")
+	overrideImages(deployment.Spec.Template.Spec.InitContainers, promote)
+	overrideImages(deployment.Spec.Template.Spec.Containers, promote)
+	if promote.Replicas != nil {
+		deployment.Spec.Replicas = promote.Replicas
+	}
+
+	var objects []promoteObject
+
+	// ConfigMap和Secret需要先于Deployment创建
+	configMaps, secrets := referencedConfigsAndSecrets(&deployment.Spec.Template.Spec)
+	if promote.WithConfigMaps {
+		for _, name := range configMaps {
+			cm, err := clientSet.CoreV1().ConfigMaps(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			if err != nil {
+				if k8serrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			cleanObjectMeta(&cm.ObjectMeta)
+			objects = append(objects, promoteObject{configMapGVR, corev1.SchemeGroupVersion.WithKind("ConfigMap"), cm})
+		}
+	}
+	if promote.WithSecrets {
+		for _, name := range secrets {
+			secret, err := clientSet.CoreV1().Secrets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+			if err != nil {
+				if k8serrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			// ServiceAccount的token由目标集群自动生成
+			if secret.Type == corev1.SecretTypeServiceAccountToken {
+				continue
+			}
+			cleanObjectMeta(&secret.ObjectMeta)
+			objects = append(objects, promoteObject{secretGVR, corev1.SchemeGroupVersion.WithKind("Secret"), secret})
+		}
+	}
+
+	objects = append(objects, promoteObject{deploymentGVR, appsv1.SchemeGroupVersion.WithKind("Deployment"), deployment})
+
+	if !promote.WithService && !promote.WithIngress {
+		return objects, nil
+	}
+
+	// 查找selector匹配Deployment的Service
+	services, err := clientSet.CoreV1().Services(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	serviceNames := map[string]bool{}
+	podLabels := labels.Set(deployment.Spec.Template.Labels)
+	for i := range services.Items {
+		svc := &services.Items[i]
+		if len(svc.Spec.Selector) == 0 || !labels.SelectorFromSet(svc.Spec.Selector).Matches(podLabels) {
+			continue
+		}
+		serviceNames[svc.Name] = true
+		if promote.WithService {
+			svc.Status = corev1.ServiceStatus{}
+			cleanObjectMeta(&svc.ObjectMeta)
+			cleanServiceSpec(&svc.Spec)
+			objects = append(objects, promoteObject{serviceGVR, corev1.SchemeGroupVersion.WithKind("Service"), svc})
+		}
+	}
+
+	if !promote.WithIngress || len(serviceNames) == 0 {
+		return objects, nil
+	}
+
+	// 查找引用了上述Service的Ingress
+	ingresses, err := clientSet.NetworkingV1().Ingresses(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range ingresses.Items {
+		ing := &ingresses.Items[i]
+		if !ingressReferencesServices(ing, serviceNames) {
+			continue
+		}
+		ing.Status = networkingv1.IngressStatus{}
+		cleanObjectMeta(&ing.ObjectMeta)
+		objects = append(objects, promoteObject{ingressGVR, networkingv1.SchemeGroupVersion.WithKind("Ingress"), ing})
+	}
+
+	return objects, nil
+}
+
+// applyPromoteObject 在目标集群创建或更新对象, dryRun时只返回差异
+func (d *Deployment) applyPromoteObject(targetCluster, targetNamespace string, object promoteObject, dryRun bool) dto.K8sPromoteItem {
+	accessor, _ := object.obj.(metav1.Object)
+	item := dto.K8sPromoteItem{Kind: object.gvk.Kind, Name: accessor.GetName()}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object.obj)
+	if err != nil {
+		item.Error = err.Error()
+		return item
+	}
+	desired := &unstructured.Unstructured{Object: content}
+	desired.SetGroupVersionKind(object.gvk)
+	desired.SetNamespace(targetNamespace)
+	unstructured.RemoveNestedField(desired.Object, "status")
+
+	resource := global.K8s.Use(targetCluster).DynamicClient.Resource(object.gvr).Namespace(targetNamespace)
+
+	var dryRunOpt []string
+	if dryRun {
+		dryRunOpt = []string{metav1.DryRunAll}
+	}
+
+	live, err := resource.Get(context.TODO(), desired.GetName(), metav1.GetOptions{})
+	var result *unstructured.Unstructured
+	switch {
+	case k8serrors.IsNotFound(err):
+		live = nil
+		item.Action = "create"
+		result, err = resource.Create(context.TODO(), desired, metav1.CreateOptions{DryRun: dryRunOpt, FieldManager: global.K8sManager})
+	case err != nil:
+		item.Error = err.Error()
+		return item
+	default:
+		item.Action = "update"
+		desired.SetResourceVersion(live.GetResourceVersion())
+		result, err = resource.Update(context.TODO(), desired, metav1.UpdateOptions{DryRun: dryRunOpt, FieldManager: global.K8sManager})
+	}
+	if err != nil {
+		item.Error = err.Error()
+		return item
+	}
+
+	if dryRun {
+		item.Diff, err = k8s.DiffObject(strings.ToLower(object.gvk.Kind), item.Name, live, result)
+		if err != nil {
+			item.Error = err.Error()
+		}
+	}
+	return item
+}
+
+// cleanObjectMeta 清除集群相关的元数据
+func cleanObjectMeta(meta *metav1.ObjectMeta) {
+	meta.ResourceVersion = ""
+	meta.UID = ""
+	meta.SelfLink = ""
+	meta.Generation = 0
+	meta.CreationTimestamp = metav1.Time{}
+	meta.ManagedFields = nil
+	meta.OwnerReferences = nil
+	delete(meta.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
+}
+
+// cleanServiceSpec 清除由集群分配的ClusterIP和NodePort
+func cleanServiceSpec(spec *corev1.ServiceSpec) {
+	if spec.ClusterIP != corev1.ClusterIPNone {
+		spec.ClusterIP = ""
+		spec.ClusterIPs = nil
+	}
+	spec.HealthCheckNodePort = 0
+	for i := range spec.Ports {
+		spec.Ports[i].NodePort = 0
+	}
+}
+
+// overrideImages 覆盖容器镜像, images优先级高于imageTag
+func overrideImages(containers []corev1.Container, promote *dto.K8sDeploymentPromote) {
+	for i := range containers {
+		if image, ok := promote.Images[containers[i].Name]; ok && image != "" {
+			containers[i].Image = image
+			continue
+		}
+		if promote.ImageTag != "" {
+			containers[i].Image = replaceImageTag(containers[i].Image, promote.ImageTag)
+		}
+	}
+}
+
+// replaceImageTag 替换镜像tag, 例如 harbor.local:5000/app:v1 -> harbor.local:5000/app:v2
+func replaceImageTag(image, tag string) string {
+	if i := strings.Index(image, "@"); i != -1 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image + ":" + tag
+}
+
+// referencedConfigsAndSecrets 获取pod中引用的ConfigMap和Secret名称
+func referencedConfigsAndSecrets(spec *corev1.PodSpec) (configMaps []string, secrets []string) {
+	cmSet, secretSet := map[string]bool{}, map[string]bool{}
+
+	addConfigMap := func(name string) {
+		if name != "" && !cmSet[name] {
+			cmSet[name] = true
+			configMaps = append(configMaps, name)
+		}
+	}
+	addSecret := func(name string) {
+		if name != "" && !secretSet[name] {
+			secretSet[name] = true
+			secrets = append(secrets, name)
+		}
+	}
+
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil {
+			addConfigMap(volume.ConfigMap.Name)
+		}
+		if volume.Secret != nil {
+			addSecret(volume.Secret.SecretName)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					addConfigMap(source.ConfigMap.Name)
+				}
+				if source.Secret != nil {
+					addSecret(source.Secret.Name)
+				}
+			}
+		}
+	}
+
+	for _, pullSecret := range spec.ImagePullSecrets {
+		addSecret(pullSecret.Name)
+	}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				addConfigMap(envFrom.ConfigMapRef.Name)
+			}
+			if envFrom.SecretRef != nil {
+				addSecret(envFrom.SecretRef.Name)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				addConfigMap(env.ValueFrom.ConfigMapKeyRef.Name)
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				addSecret(env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+	return
+}
+
+// ingressReferencesServices Ingress的后端是否引用了services中的任意一个
+func ingressReferencesServices(ing *networkingv1.Ingress, services map[string]bool) bool {
+	if ing.Spec.DefaultBackend != nil && ing.Spec.DefaultBackend.Service != nil && services[ing.Spec.DefaultBackend.Service.Name] {
+		return true
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil && services[path.Backend.Service.Name] {
+				return true
+			}
+		}
+	}
+	return false
+}
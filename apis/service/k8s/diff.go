@@ -0,0 +1,110 @@
+package k8s
+
+import (
+	"encoding/base64"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"reflect"
+	"soul/utils/diffutil"
+)
+
+// DiffObject 对比集群中的对象和 dry-run 返回的对象, live为nil时代表对象不存在
+func DiffObject(kind, name string, live, desired runtime.Object) (*diffutil.Result, error) {
+	liveContent, err := cleanForDiff(live)
+	if err != nil {
+		return nil, err
+	}
+	desiredContent, err := cleanForDiff(desired)
+	if err != nil {
+		return nil, err
+	}
+	if kind == "secret" {
+		redactSecretData(liveContent, desiredContent)
+	}
+	return diffutil.Diff(fmt.Sprintf("%s/%s", kind, name), liveContent, desiredContent)
+}
+
+// cleanForDiff 去掉由apiserver维护、不需要展示给用户的字段
+func cleanForDiff(obj runtime.Object) (map[string]any, error) {
+	if obj == nil || reflect.ValueOf(obj).IsNil() {
+		return nil, nil
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	// Unstructured对象转换时不会复制, 避免修改调用方的对象
+	content = runtime.DeepCopyJSON(content)
+	for _, field := range []string{"resourceVersion", "uid", "generation", "creationTimestamp", "managedFields", "selfLink"} {
+		unstructured.RemoveNestedField(content, "metadata", field)
+	}
+	unstructured.RemoveNestedField(content, "status")
+	return content, nil
+}
+
+const (
+	secretValueUnchanged = "<unchanged>"
+	secretValueChanged   = "<changed>"
+)
+
+// redactSecretData 将Secret的data和stringData替换为占位符, 只保留键名以及值是否变化.
+// live为nil时(创建)所有的值都是changed
+func redactSecretData(live, desired map[string]any) {
+	liveValues := secretValues(live)
+	if live != nil {
+		redacted := make(map[string]any, len(liveValues))
+		for key := range liveValues {
+			redacted[key] = secretValueUnchanged
+		}
+		setSecretData(live, redacted)
+	}
+	if desired != nil {
+		desiredValues := secretValues(desired)
+		redacted := make(map[string]any, len(desiredValues))
+		for key, value := range desiredValues {
+			redacted[key] = secretValueChanged
+			if old, ok := liveValues[key]; ok && old == value {
+				redacted[key] = secretValueUnchanged
+			}
+		}
+		setSecretData(desired, redacted)
+	}
+}
+
+// secretValues 返回Secret中每个key的明文, stringData优先于data
+func secretValues(content map[string]any) map[string]string {
+	values := map[string]string{}
+	if content == nil {
+		return values
+	}
+	data, _, _ := unstructured.NestedMap(content, "data")
+	for key, value := range data {
+		encoded, _ := value.(string)
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			decoded = []byte(encoded)
+		}
+		values[key] = string(decoded)
+	}
+	stringData, _, _ := unstructured.NestedMap(content, "stringData")
+	for key, value := range stringData {
+		values[key], _ = value.(string)
+	}
+	return values
+}
+
+// setSecretData 用占位符替换data, 去掉stringData和kubectl apply记录的配置(其中同样包含明文)
+func setSecretData(content, redacted map[string]any) {
+	unstructured.RemoveNestedField(content, "stringData")
+	unstructured.RemoveNestedField(content, "data")
+	if len(redacted) != 0 {
+		content["data"] = redacted
+	}
+	unstructured.RemoveNestedField(content, "metadata", "annotations", corev1.LastAppliedConfigAnnotation)
+	if annotations, found, _ := unstructured.NestedMap(content, "metadata", "annotations"); found && len(annotations) == 0 {
+		unstructured.RemoveNestedField(content, "metadata", "annotations")
+	}
+}
@@ -0,0 +1,20 @@
+package k8s
+
+import "soul/apis/dto"
+
+// 诊断结果的严重程度
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Healthy 没有error和warning级别的问题时返回true
+func Healthy(findings []dto.K8sDiagnosticFinding) bool {
+	for _, finding := range findings {
+		if finding.Severity != SeverityInfo {
+			return false
+		}
+	}
+	return true
+}
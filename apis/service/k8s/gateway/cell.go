@@ -0,0 +1,46 @@
+package gateway
+
+import (
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+	"time"
+)
+
+type gatewayClassCell v1beta1.GatewayClass
+
+func (g gatewayClassCell) GetCreation() time.Time {
+	return g.CreationTimestamp.Time
+}
+
+func (g gatewayClassCell) GetName() string {
+	return g.Name
+}
+
+type gatewayCell v1beta1.Gateway
+
+func (g gatewayCell) GetCreation() time.Time {
+	return g.CreationTimestamp.Time
+}
+
+func (g gatewayCell) GetName() string {
+	return g.Name
+}
+
+type httpRouteCell v1beta1.HTTPRoute
+
+func (h httpRouteCell) GetCreation() time.Time {
+	return h.CreationTimestamp.Time
+}
+
+func (h httpRouteCell) GetName() string {
+	return h.Name
+}
+
+type referenceGrantCell v1beta1.ReferenceGrant
+
+func (r referenceGrantCell) GetCreation() time.Time {
+	return r.CreationTimestamp.Time
+}
+
+func (r referenceGrantCell) GetName() string {
+	return r.Name
+}
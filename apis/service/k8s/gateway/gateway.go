@@ -0,0 +1,33 @@
+package gateway
+
+import (
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+	"soul/apis/service/k8s"
+	"soul/utils/httputil"
+)
+
+type Gateway struct{}
+
+func (g *Gateway) toCells(gateways []v1beta1.Gateway) []k8s.DataCell {
+	cells := make([]k8s.DataCell, len(gateways))
+	for i, item := range gateways {
+		cells[i] = k8s.DataCell(gatewayCell(item))
+	}
+	return cells
+}
+
+func (g *Gateway) GetGatewayByName(clusterName, name, namespace string) (map[string]any, error) {
+	return getObject(clusterName, gatewayGVR, name, namespace)
+}
+
+func (g *Gateway) GetGatewayList(clusterName, filterName, namespace string, limit, page int) (*httputil.PageResp, error) {
+	gatewayList := &v1beta1.GatewayList{}
+	if err := listObjects(clusterName, gatewayGVR, namespace, gatewayList); err != nil {
+		return nil, err
+	}
+	return pageResp(g.toCells(gatewayList.Items), filterName, limit, page), nil
+}
+
+func (g *Gateway) DeleteGatewayByName(clusterName, name, namespace string) (err error) {
+	return deleteObject(clusterName, gatewayGVR, name, namespace)
+}
@@ -0,0 +1,33 @@
+package gateway
+
+import (
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+	"soul/apis/service/k8s"
+	"soul/utils/httputil"
+)
+
+type GatewayClass struct{}
+
+func (g *GatewayClass) toCells(gatewayClasses []v1beta1.GatewayClass) []k8s.DataCell {
+	cells := make([]k8s.DataCell, len(gatewayClasses))
+	for i, item := range gatewayClasses {
+		cells[i] = k8s.DataCell(gatewayClassCell(item))
+	}
+	return cells
+}
+
+func (g *GatewayClass) GetGatewayClassByName(clusterName, name string) (map[string]any, error) {
+	return getObject(clusterName, gatewayClassGVR, name, "")
+}
+
+func (g *GatewayClass) GetGatewayClassList(clusterName, filterName string, limit, page int) (*httputil.PageResp, error) {
+	gatewayClassList := &v1beta1.GatewayClassList{}
+	if err := listObjects(clusterName, gatewayClassGVR, "", gatewayClassList); err != nil {
+		return nil, err
+	}
+	return pageResp(g.toCells(gatewayClassList.Items), filterName, limit, page), nil
+}
+
+func (g *GatewayClass) DeleteGatewayClassByName(clusterName, name string) (err error) {
+	return deleteObject(clusterName, gatewayClassGVR, name, "")
+}
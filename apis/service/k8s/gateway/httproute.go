@@ -0,0 +1,303 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sort"
+	"soul/apis/dto"
+	"soul/apis/service/k8s"
+	"soul/global"
+	"soul/utils/diffutil"
+	"soul/utils/httputil"
+)
+
+type HTTPRoute struct{}
+
+func (h *HTTPRoute) toCells(httpRoutes []v1beta1.HTTPRoute) []k8s.DataCell {
+	cells := make([]k8s.DataCell, len(httpRoutes))
+	for i, item := range httpRoutes {
+		cells[i] = k8s.DataCell(httpRouteCell(item))
+	}
+	return cells
+}
+
+func (h *HTTPRoute) GetHTTPRouteByName(clusterName, name, namespace string) (map[string]any, error) {
+	return getObject(clusterName, httpRouteGVR, name, namespace)
+}
+
+func (h *HTTPRoute) GetHTTPRouteList(clusterName, filterName, namespace string, limit, page int) (*httputil.PageResp, error) {
+	httpRouteList := &v1beta1.HTTPRouteList{}
+	if err := listObjects(clusterName, httpRouteGVR, namespace, httpRouteList); err != nil {
+		return nil, err
+	}
+	return pageResp(h.toCells(httpRouteList.Items), filterName, limit, page), nil
+}
+
+func (h *HTTPRoute) DeleteHTTPRouteByName(clusterName, name, namespace string) (err error) {
+	return deleteObject(clusterName, httpRouteGVR, name, namespace)
+}
+
+func (h *HTTPRoute) CreateSimpleHTTPRoute(clusterName string, httpRouteSimpleCreate *dto.K8sHTTPRouteSimpleCreate) (err error) {
+	route := h.simpleHTTPRouteToHTTPRoute(httpRouteSimpleCreate)
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(route)
+	if err != nil {
+		return err
+	}
+	// 状态由Gateway控制器写入, 空的status.parents会被序列化为null
+	delete(content, "status")
+
+	_, err = global.K8s.Use(clusterName).DynamicClient.
+		Resource(httpRouteGVR).
+		Namespace(route.Namespace).
+		Create(context.TODO(), &unstructured.Unstructured{Object: content}, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return
+}
+
+// UpdateSimpleHTTPRoute 与Ingress一样使用JSON merge patch更新, 保留其他工具添加的labels和annotations
+func (h *HTTPRoute) UpdateSimpleHTTPRoute(clusterName string, httpRouteSimpleCreate *dto.K8sHTTPRouteSimpleCreate) (err error) {
+	_, err = h.patchSimpleHTTPRoute(clusterName, httpRouteSimpleCreate, false)
+	return err
+}
+
+// DiffSimpleHTTPRoute 使用 dry-run 模拟更新, 返回集群中的对象和更新后对象的差异
+func (h *HTTPRoute) DiffSimpleHTTPRoute(clusterName string, httpRouteSimpleCreate *dto.K8sHTTPRouteSimpleCreate) (*diffutil.Result, error) {
+	live, err := global.K8s.Use(clusterName).DynamicClient.
+		Resource(httpRouteGVR).
+		Namespace(httpRouteSimpleCreate.Namespace).
+		Get(context.TODO(), httpRouteSimpleCreate.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := h.patchSimpleHTTPRoute(clusterName, httpRouteSimpleCreate, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return k8s.DiffObject("httproute", live.GetName(), live, result)
+}
+
+func (h *HTTPRoute) patchSimpleHTTPRoute(clusterName string, httpRouteSimpleCreate *dto.K8sHTTPRouteSimpleCreate, dryRun bool) (*unstructured.Unstructured, error) {
+	route := h.simpleHTTPRouteToHTTPRoute(httpRouteSimpleCreate)
+
+	metadata := map[string]any{"annotations": route.Annotations}
+	if route.Labels != nil {
+		metadata["labels"] = route.Labels
+	}
+	data, err := json.Marshal(map[string]any{
+		"metadata": metadata,
+		"spec": map[string]any{
+			"parentRefs": route.Spec.ParentRefs,
+			"hostnames":  route.Spec.Hostnames,
+			"rules":      route.Spec.Rules,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	opt := metav1.PatchOptions{FieldManager: global.K8sManager}
+	if dryRun {
+		opt.DryRun = []string{metav1.DryRunAll}
+	}
+	return global.K8s.Use(clusterName).DynamicClient.
+		Resource(httpRouteGVR).
+		Namespace(route.Namespace).
+		Patch(context.TODO(), route.Name, types.MergePatchType, data, opt)
+}
+
+// GetHTTPRouteStatus 返回HTTPRoute在每个parentRef上的状态, Gateway控制器还没有写入状态的parentRef显示为Pending
+func (h *HTTPRoute) GetHTTPRouteStatus(clusterName, name, namespace string) (*dto.K8sHTTPRouteStatus, error) {
+	content, err := getObject(clusterName, httpRouteGVR, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	route := &v1beta1.HTTPRoute{}
+	if err = toStruct(content, route); err != nil {
+		return nil, err
+	}
+
+	status := &dto.K8sHTTPRouteStatus{
+		Name:      route.Name,
+		Namespace: route.Namespace,
+		Hostnames: make([]string, 0, len(route.Spec.Hostnames)),
+		Ready:     len(route.Spec.ParentRefs) > 0,
+		Parents:   make([]dto.K8sHTTPRouteParentStatus, 0, len(route.Spec.ParentRefs)),
+	}
+	for _, hostname := range route.Spec.Hostnames {
+		status.Hostnames = append(status.Hostnames, string(hostname))
+	}
+
+	for _, parentRef := range route.Spec.ParentRefs {
+		parent := dto.K8sHTTPRouteParentStatus{
+			Gateway:     parentKey(parentRef, route.Namespace),
+			SectionName: sectionName(parentRef),
+			Reason:      "Pending",
+			Message:     "Gateway控制器还没有处理该路由, 检查Gateway是否存在以及它的GatewayClass是否有控制器",
+		}
+
+		// 一个parentRef可能被多个控制器处理, 都接受时才认为已接受
+		var parentStatuses []v1beta1.RouteParentStatus
+		for _, item := range route.Status.Parents {
+			if parentKey(item.ParentRef, route.Namespace) == parent.Gateway && sectionName(item.ParentRef) == parent.SectionName {
+				parentStatuses = append(parentStatuses, item)
+			}
+		}
+		for i, item := range parentStatuses {
+			accepted := meta.FindStatusCondition(item.Conditions, string(v1beta1.RouteConditionAccepted))
+			resolvedRefs := meta.FindStatusCondition(item.Conditions, string(v1beta1.RouteConditionResolvedRefs))
+			if i == 0 {
+				parent.Accepted, parent.ResolvedRefs = true, true
+			}
+			parent.ControllerName = string(item.ControllerName)
+			parent.Accepted = parent.Accepted && accepted != nil && accepted.Status == metav1.ConditionTrue
+			// 没有ResolvedRefs条件时按已解析处理
+			parent.ResolvedRefs = parent.ResolvedRefs && (resolvedRefs == nil || resolvedRefs.Status == metav1.ConditionTrue)
+
+			switch {
+			case accepted != nil && accepted.Status != metav1.ConditionTrue:
+				parent.Reason, parent.Message = accepted.Reason, accepted.Message
+			case resolvedRefs != nil && resolvedRefs.Status != metav1.ConditionTrue:
+				parent.Reason, parent.Message = resolvedRefs.Reason, resolvedRefs.Message
+			case accepted != nil && parent.Accepted:
+				parent.Reason, parent.Message = accepted.Reason, accepted.Message
+			}
+			if accepted != nil && accepted.ObservedGeneration < route.Generation {
+				parent.Message = "状态还没有更新到最新的配置: " + parent.Message
+			}
+		}
+
+		status.Ready = status.Ready && parent.Accepted && parent.ResolvedRefs
+		status.Parents = append(status.Parents, parent)
+	}
+
+	sort.SliceStable(status.Parents, func(i, j int) bool {
+		return status.Parents[i].Gateway < status.Parents[j].Gateway
+	})
+	return status, nil
+}
+
+func (h *HTTPRoute) simpleHTTPRouteToHTTPRoute(httpRouteSimpleCreate *dto.K8sHTTPRouteSimpleCreate) *v1beta1.HTTPRoute {
+	annotations := map[string]string{}
+	for k, v := range httpRouteSimpleCreate.Annotations {
+		annotations[k] = v
+	}
+	annotations["created-by"] = global.K8sManager
+
+	route := &v1beta1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1beta1.SchemeGroupVersion.String(),
+			Kind:       "HTTPRoute",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        httpRouteSimpleCreate.Name,
+			Namespace:   httpRouteSimpleCreate.Namespace,
+			Labels:      httpRouteSimpleCreate.Labels,
+			Annotations: annotations,
+		},
+	}
+
+	for _, item := range httpRouteSimpleCreate.ParentRefs {
+		parentRef := v1beta1.ParentReference{Name: v1beta1.ObjectName(item.Name)}
+		if item.Namespace != "" {
+			namespace := v1beta1.Namespace(item.Namespace)
+			parentRef.Namespace = &namespace
+		}
+		if item.SectionName != "" {
+			section := v1beta1.SectionName(item.SectionName)
+			parentRef.SectionName = &section
+		}
+		route.Spec.ParentRefs = append(route.Spec.ParentRefs, parentRef)
+	}
+
+	for _, hostname := range httpRouteSimpleCreate.Hostnames {
+		route.Spec.Hostnames = append(route.Spec.Hostnames, v1beta1.Hostname(hostname))
+	}
+
+	for _, item := range httpRouteSimpleCreate.Rules {
+		rule := v1beta1.HTTPRouteRule{}
+		for _, m := range item.Matches {
+			rule.Matches = append(rule.Matches, toHTTPRouteMatch(m.Path, m.PathType, m.Method, m.Headers))
+		}
+		for _, b := range item.Backends {
+			port := v1beta1.PortNumber(b.Port)
+			backendRef := v1beta1.HTTPBackendRef{
+				BackendRef: v1beta1.BackendRef{
+					BackendObjectReference: v1beta1.BackendObjectReference{
+						Name: v1beta1.ObjectName(b.Service),
+						Port: &port,
+					},
+					Weight: b.Weight,
+				},
+			}
+			if b.Namespace != "" {
+				namespace := v1beta1.Namespace(b.Namespace)
+				backendRef.Namespace = &namespace
+			}
+			rule.BackendRefs = append(rule.BackendRefs, backendRef)
+		}
+		route.Spec.Rules = append(route.Spec.Rules, rule)
+	}
+
+	return route
+}
+
+// toHTTPRouteMatch path为空时为 /, pathType为空时为PathPrefix
+func toHTTPRouteMatch(path, pathType, method string, headers map[string]string) v1beta1.HTTPRouteMatch {
+	if path == "" {
+		path = "/"
+	}
+	pt := v1beta1.PathMatchPathPrefix
+	if pathType != "" {
+		pt = v1beta1.PathMatchType(pathType)
+	}
+	match := v1beta1.HTTPRouteMatch{
+		Path: &v1beta1.HTTPPathMatch{Type: &pt, Value: &path},
+	}
+	if method != "" {
+		m := v1beta1.HTTPMethod(method)
+		match.Method = &m
+	}
+
+	// map的顺序不固定, 按名称排序避免每次更新都产生差异
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		headerType := v1beta1.HeaderMatchExact
+		match.Headers = append(match.Headers, v1beta1.HTTPHeaderMatch{
+			Type:  &headerType,
+			Name:  v1beta1.HTTPHeaderName(name),
+			Value: headers[name],
+		})
+	}
+	return match
+}
+
+// parentKey parentRef没有namespace时与路由相同
+func parentKey(parentRef v1beta1.ParentReference, routeNamespace string) string {
+	namespace := routeNamespace
+	if parentRef.Namespace != nil {
+		namespace = string(*parentRef.Namespace)
+	}
+	return namespace + "/" + string(parentRef.Name)
+}
+
+func sectionName(parentRef v1beta1.ParentReference) string {
+	if parentRef.SectionName == nil {
+		return ""
+	}
+	return string(*parentRef.SectionName)
+}
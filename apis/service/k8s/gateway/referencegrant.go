@@ -0,0 +1,33 @@
+package gateway
+
+import (
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+	"soul/apis/service/k8s"
+	"soul/utils/httputil"
+)
+
+type ReferenceGrant struct{}
+
+func (r *ReferenceGrant) toCells(referenceGrants []v1beta1.ReferenceGrant) []k8s.DataCell {
+	cells := make([]k8s.DataCell, len(referenceGrants))
+	for i, item := range referenceGrants {
+		cells[i] = k8s.DataCell(referenceGrantCell(item))
+	}
+	return cells
+}
+
+func (r *ReferenceGrant) GetReferenceGrantByName(clusterName, name, namespace string) (map[string]any, error) {
+	return getObject(clusterName, referenceGrantGVR, name, namespace)
+}
+
+func (r *ReferenceGrant) GetReferenceGrantList(clusterName, filterName, namespace string, limit, page int) (*httputil.PageResp, error) {
+	referenceGrantList := &v1beta1.ReferenceGrantList{}
+	if err := listObjects(clusterName, referenceGrantGVR, namespace, referenceGrantList); err != nil {
+		return nil, err
+	}
+	return pageResp(r.toCells(referenceGrantList.Items), filterName, limit, page), nil
+}
+
+func (r *ReferenceGrant) DeleteReferenceGrantByName(clusterName, name, namespace string) (err error) {
+	return deleteObject(clusterName, referenceGrantGVR, name, namespace)
+}
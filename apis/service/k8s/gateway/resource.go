@@ -0,0 +1,82 @@
+package gateway
+
+import (
+	"context"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
+	"soul/apis/service/k8s"
+	"soul/global"
+	"soul/utils/httputil"
+)
+
+// Gateway API 的CRD, 没有生成的客户端, 与ServiceMonitor一样通过动态客户端读写
+var (
+	gatewayClassGVR   = v1beta1.SchemeGroupVersion.WithResource("gatewayclasses")
+	gatewayGVR        = v1beta1.SchemeGroupVersion.WithResource("gateways")
+	httpRouteGVR      = v1beta1.SchemeGroupVersion.WithResource("httproutes")
+	referenceGrantGVR = v1beta1.SchemeGroupVersion.WithResource("referencegrants")
+)
+
+func toStruct(unStructObj map[string]any, obj any) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(unStructObj, obj)
+}
+
+// getObject namespace为空时获取集群级别的资源
+func getObject(clusterName string, gvr schema.GroupVersionResource, name, namespace string) (map[string]any, error) {
+	unStructObj, err := global.K8s.Use(clusterName).DynamicClient.
+		Resource(gvr).
+		Namespace(namespace).
+		Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return unStructObj.UnstructuredContent(), nil
+}
+
+// listObjects 获取资源列表并转换为list对应的类型, namespace为空时获取所有namespace
+func listObjects(clusterName string, gvr schema.GroupVersionResource, namespace string, list any) error {
+	objects, err := global.K8s.Use(clusterName).DynamicClient.
+		Resource(gvr).
+		Namespace(namespace).
+		List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	return toStruct(objects.UnstructuredContent(), list)
+}
+
+func deleteObject(clusterName string, gvr schema.GroupVersionResource, name, namespace string) error {
+	return global.K8s.Use(clusterName).DynamicClient.
+		Resource(gvr).
+		Namespace(namespace).
+		Delete(context.TODO(), name, metav1.DeleteOptions{})
+}
+
+func pageResp(cells []k8s.DataCell, filterName string, limit, page int) *httputil.PageResp {
+	selectableData := k8s.DataSelect{
+		GenericDataList: cells,
+		DataSelect: &k8s.DataSelectQuery{
+			Filter: &k8s.FilterQuery{
+				Name: filterName,
+			},
+			Paginate: &k8s.PaginateQuery{
+				Limit: limit,
+				Page:  page,
+			},
+		},
+	}
+
+	total := len(selectableData.Filter().GenericDataList)
+	data := selectableData.Sort().Paginate()
+
+	return &httputil.PageResp{
+		Limit: limit,
+		Page:  page,
+		Total: total,
+		Items: data.GenericDataList,
+	}
+}
@@ -0,0 +1,51 @@
+package helm
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// restClientGetter 使用已有集群的rest.Config实现genericclioptions.RESTClientGetter, 供helm使用
+type restClientGetter struct {
+	namespace string
+	config    *rest.Config
+	discovery discovery.CachedDiscoveryInterface
+}
+
+func newRestClientGetter(config *rest.Config, namespace string) (*restClientGetter, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &restClientGetter{
+		namespace: namespace,
+		config:    config,
+		discovery: memory.NewMemCacheClient(discoveryClient),
+	}, nil
+}
+
+func (r *restClientGetter) ToRESTConfig() (*rest.Config, error) {
+	return rest.CopyConfig(r.config), nil
+}
+
+func (r *restClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	return r.discovery, nil
+}
+
+func (r *restClientGetter) ToRESTMapper() (meta.RESTMapper, error) {
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(r.discovery)
+	return restmapper.NewShortcutExpander(mapper, r.discovery), nil
+}
+
+// ToRawKubeConfigLoader helm只通过它获取默认namespace
+func (r *restClientGetter) ToRawKubeConfigLoader() clientcmd.ClientConfig {
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{},
+		&clientcmd.ConfigOverrides{Context: clientcmdapi.Context{Namespace: r.namespace}},
+	)
+}
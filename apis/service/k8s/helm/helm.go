@@ -0,0 +1,317 @@
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/release"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"sort"
+	"soul/apis/dto"
+	"soul/global"
+	log "soul/internal/logger"
+	"strings"
+	"time"
+)
+
+// defaultRollbackTimeout 回滚未指定超时时间时使用的默认值, 与安装和升级的默认值一致
+const defaultRollbackTimeout = 300 * time.Second
+
+type Helm struct{}
+
+// newConfiguration 创建helm action配置, release信息存储在namespace下的secret中
+func newConfiguration(clusterName, namespace string) (*action.Configuration, error) {
+	getter, err := newRestClientGetter(global.K8s.Use(clusterName).Config, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &action.Configuration{}
+	err = cfg.Init(getter, namespace, "secret", func(format string, v ...any) {
+		log.Debug(format, v...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// ListChart 获取本地chart仓库中的chart
+func (h *Helm) ListChart() ([]dto.K8sHelmChart, error) {
+	chartDir := global.Config.Helm.ChartDir
+	entries, err := os.ReadDir(chartDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []dto.K8sHelmChart{}, nil
+		}
+		return nil, err
+	}
+
+	charts := make([]dto.K8sHelmChart, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && !strings.HasSuffix(entry.Name(), ".tgz") {
+			continue
+		}
+		ch, err := loader.Load(filepath.Join(chartDir, entry.Name()))
+		if err != nil {
+			log.Debug("加载chart %s 失败: %s", entry.Name(), err.Error())
+			continue
+		}
+		charts = append(charts, dto.K8sHelmChart{
+			Name:        ch.Metadata.Name,
+			Version:     ch.Metadata.Version,
+			AppVersion:  ch.Metadata.AppVersion,
+			Description: ch.Metadata.Description,
+			Path:        entry.Name(),
+		})
+	}
+	return charts, nil
+}
+
+// loadChart 从上传的chart包或本地chart仓库加载chart
+func loadChart(chartName string, file *multipart.FileHeader) (*chart.Chart, error) {
+	if file != nil {
+		if !strings.HasSuffix(file.Filename, ".tgz") && !strings.HasSuffix(file.Filename, ".tar.gz") {
+			return nil, errors.New("chart包必须是.tgz格式")
+		}
+		if maxSize := global.Config.Helm.MaxChartSize << 20; maxSize > 0 && file.Size > maxSize {
+			return nil, fmt.Errorf("chart包不能超过%dM", global.Config.Helm.MaxChartSize)
+		}
+		f, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return loader.LoadArchive(f)
+	}
+
+	if chartName == "" {
+		return nil, errors.New("请上传chart包或选择本地chart")
+	}
+
+	// 只允许加载chart仓库目录下的chart
+	chartDir, err := filepath.Abs(global.Config.Helm.ChartDir)
+	if err != nil {
+		return nil, err
+	}
+	chartPath := filepath.Join(chartDir, filepath.Clean("/"+chartName))
+	if !strings.HasPrefix(chartPath, chartDir+string(filepath.Separator)) {
+		return nil, errors.New("chart路径不合法")
+	}
+	return loader.Load(chartPath)
+}
+
+func parseValues(values string) (map[string]any, error) {
+	if strings.TrimSpace(values) == "" {
+		return map[string]any{}, nil
+	}
+	vals, err := chartutil.ReadValues([]byte(values))
+	if err != nil {
+		return nil, errors.New("values格式错误, " + err.Error())
+	}
+	return vals, nil
+}
+
+func toRelease(rel *release.Release) dto.K8sHelmRelease {
+	info := dto.K8sHelmRelease{
+		Name:      rel.Name,
+		Namespace: rel.Namespace,
+		Revision:  rel.Version,
+	}
+	if rel.Info != nil {
+		info.Status = rel.Info.Status.String()
+		info.Updated = rel.Info.LastDeployed.Time
+		info.Description = rel.Info.Description
+	}
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		info.Chart = rel.Chart.Metadata.Name
+		info.ChartVersion = rel.Chart.Metadata.Version
+		info.AppVersion = rel.Chart.Metadata.AppVersion
+	}
+	return info
+}
+
+func toReleaseDetail(rel *release.Release) *dto.K8sHelmReleaseDetail {
+	detail := &dto.K8sHelmReleaseDetail{
+		HelmRelease: toRelease(rel),
+		Manifest:    rel.Manifest,
+		Values:      rel.Config,
+	}
+	if rel.Info != nil {
+		detail.Notes = rel.Info.Notes
+	}
+	return detail
+}
+
+// ListRelease 从helm release secret中获取release列表, namespace为空时获取所有namespace
+func (h *Helm) ListRelease(clusterName, namespace string) ([]dto.K8sHelmRelease, error) {
+	cfg, err := newConfiguration(clusterName, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	client := action.NewList(cfg)
+	client.All = true
+	client.AllNamespaces = namespace == ""
+	client.SetStateMask()
+
+	releases, err := client.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.K8sHelmRelease, 0, len(releases))
+	for _, rel := range releases {
+		items = append(items, toRelease(rel))
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		return items[i].Name < items[j].Name
+	})
+	return items, nil
+}
+
+func (h *Helm) GetRelease(clusterName, namespace, name string) (*dto.K8sHelmReleaseDetail, error) {
+	cfg, err := newConfiguration(clusterName, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := action.NewGet(cfg).Run(name)
+	if err != nil {
+		return nil, err
+	}
+	return toReleaseDetail(rel), nil
+}
+
+// GetReleaseHistory 获取release的所有版本, 按版本倒序
+func (h *Helm) GetReleaseHistory(clusterName, namespace, name string) ([]dto.K8sHelmRelease, error) {
+	cfg, err := newConfiguration(clusterName, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	client := action.NewHistory(cfg)
+	client.Max = 256
+	releases, err := client.Run(name)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]dto.K8sHelmRelease, 0, len(releases))
+	for _, rel := range releases {
+		items = append(items, toRelease(rel))
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Revision > items[j].Revision
+	})
+	return items, nil
+}
+
+// GetReleaseValues 获取release的values, all为true时包含chart默认值, revision为0时获取最新版本
+func (h *Helm) GetReleaseValues(clusterName, namespace, name string, revision int, all bool) (map[string]any, error) {
+	cfg, err := newConfiguration(clusterName, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	client := action.NewGetValues(cfg)
+	client.Version = revision
+	client.AllValues = all
+	return client.Run(name)
+}
+
+func (h *Helm) InstallRelease(clusterName string, install dto.K8sHelmInstall, file *multipart.FileHeader) (*dto.K8sHelmReleaseDetail, error) {
+	ch, err := loadChart(install.Chart, file)
+	if err != nil {
+		return nil, err
+	}
+	values, err := parseValues(install.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := newConfiguration(clusterName, install.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	client := action.NewInstall(cfg)
+	client.ReleaseName = install.Name
+	client.Namespace = install.Namespace
+	client.Wait = install.Wait
+	client.Timeout = time.Duration(install.Timeout) * time.Second
+
+	rel, err := client.Run(ch, values)
+	if err != nil {
+		return nil, err
+	}
+	return toReleaseDetail(rel), nil
+}
+
+func (h *Helm) UpgradeRelease(clusterName, namespace, name string, upgrade dto.K8sHelmUpgrade, file *multipart.FileHeader) (*dto.K8sHelmReleaseDetail, error) {
+	ch, err := loadChart(upgrade.Chart, file)
+	if err != nil {
+		return nil, err
+	}
+	values, err := parseValues(upgrade.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := newConfiguration(clusterName, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	client := action.NewUpgrade(cfg)
+	client.Namespace = namespace
+	client.ReuseValues = upgrade.ReuseValues
+	client.Wait = upgrade.Wait
+	client.Timeout = time.Duration(upgrade.Timeout) * time.Second
+
+	rel, err := client.Run(name, ch, values)
+	if err != nil {
+		return nil, err
+	}
+	return toReleaseDetail(rel), nil
+}
+
+// RollbackRelease 回滚到指定版本, revision为0时回滚到上一个版本
+func (h *Helm) RollbackRelease(clusterName, namespace, name string, rollback dto.K8sHelmRollback) error {
+	cfg, err := newConfiguration(clusterName, namespace)
+	if err != nil {
+		return err
+	}
+
+	client := action.NewRollback(cfg)
+	client.Version = rollback.Revision
+	client.Wait = rollback.Wait
+	client.Timeout = defaultRollbackTimeout
+	if rollback.Timeout > 0 {
+		client.Timeout = time.Duration(rollback.Timeout) * time.Second
+	}
+	return client.Run(name)
+}
+
+func (h *Helm) UninstallRelease(clusterName, namespace, name string, keepHistory bool) (string, error) {
+	cfg, err := newConfiguration(clusterName, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	client := action.NewUninstall(cfg)
+	client.KeepHistory = keepHistory
+	resp, err := client.Run(name)
+	if err != nil {
+		return "", err
+	}
+	return resp.Info, nil
+}
@@ -2,13 +2,19 @@ package ingress
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	ingressv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 	"soul/apis/dto"
 	"soul/apis/service/k8s"
 	"soul/global"
+	"soul/utils/diffutil"
 	"soul/utils/httputil"
+	"strings"
 )
 
 type Ingress struct{}
@@ -75,8 +81,15 @@ func (i *Ingress) DeleteIngressByName(clusterName, name, namespace string) (err
 	return nil
 }
 
-func (i *Ingress) CreateSimpleIngress(clusterName string, ingressSimpleCreate *dto.K8sIngressSimpleCreate) (err error) {
-	ing := i.simpleIngressToIngress(ingressSimpleCreate)
+// CreateSimpleIngress 创建前检查后端、TLS和host冲突, 有error级别的问题时返回ValidationError, force为true时跳过检查
+func (i *Ingress) CreateSimpleIngress(clusterName string, ingressSimpleCreate *dto.K8sIngressSimpleCreate, force bool) (err error) {
+	ing, err := i.simpleIngressToIngress(ingressSimpleCreate)
+	if err != nil {
+		return err
+	}
+	if err = i.validateBeforeApply(clusterName, ing, force); err != nil {
+		return err
+	}
 
 	_, err = global.K8s.Use(clusterName).ClientSet.NetworkingV1().Ingresses(ing.Namespace).Create(context.TODO(), ing, metav1.CreateOptions{})
 	if err != nil {
@@ -86,81 +99,199 @@ func (i *Ingress) CreateSimpleIngress(clusterName string, ingressSimpleCreate *d
 	return
 }
 
-func (i *Ingress) UpdateSimpleIngress(clusterName string, ingressSimpleCreate *dto.K8sIngressSimpleCreate) (err error) {
-	ing := i.simpleIngressToIngress(ingressSimpleCreate)
-	_, err = global.K8s.Use(clusterName).ClientSet.NetworkingV1().Ingresses(ing.Namespace).Update(context.TODO(), ing, metav1.UpdateOptions{})
+// UpdateSimpleIngress 使用JSON merge patch更新, rules、tls和defaultBackend整体替换, labels和annotations与已有的合并。
+// 与创建一样先检查, force为true时跳过检查
+func (i *Ingress) UpdateSimpleIngress(clusterName string, ingressSimpleCreate *dto.K8sIngressSimpleCreate, force bool) (err error) {
+	ing, err := i.simpleIngressToIngress(ingressSimpleCreate)
 	if err != nil {
 		return err
 	}
+	if !force {
+		if err = inheritLiveClass(context.TODO(), clusterName, ing); err != nil {
+			return err
+		}
+	}
+	if err = i.validateBeforeApply(clusterName, ing, force); err != nil {
+		return err
+	}
+	_, err = i.patchSimpleIngress(clusterName, ingressSimpleCreate, false)
+	return err
+}
 
-	return
+// DiffSimpleIngress 使用 dry-run 模拟更新, 返回集群中的对象和更新后对象的差异
+func (i *Ingress) DiffSimpleIngress(clusterName string, ingressSimpleCreate *dto.K8sIngressSimpleCreate) (*diffutil.Result, error) {
+	live, err := i.GetIngressByName(clusterName, ingressSimpleCreate.Name, ingressSimpleCreate.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := i.patchSimpleIngress(clusterName, ingressSimpleCreate, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return k8s.DiffObject("ingress", live.Name, live, result)
 }
 
-func (i *Ingress) simpleIngressToIngress(ingressSimpleCreate *dto.K8sIngressSimpleCreate) *ingressv1.Ingress {
+func (i *Ingress) patchSimpleIngress(clusterName string, ingressSimpleCreate *dto.K8sIngressSimpleCreate, dryRun bool) (*ingressv1.Ingress, error) {
+	ing, err := i.simpleIngressToIngress(ingressSimpleCreate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 值为nil的字段序列化为null, merge patch会删除这些字段; ingressClassName为空时保留原来的值, labels和annotations按key合并
+	spec := map[string]any{
+		"rules":          ing.Spec.Rules,
+		"tls":            ing.Spec.TLS,
+		"defaultBackend": ing.Spec.DefaultBackend,
+	}
+	if ing.Spec.IngressClassName != nil {
+		spec["ingressClassName"] = ing.Spec.IngressClassName
+	}
+	metadata := map[string]any{"annotations": ing.Annotations}
+	if ing.Labels != nil {
+		metadata["labels"] = ing.Labels
+	}
+	data, err := json.Marshal(map[string]any{
+		"metadata": metadata,
+		"spec":     spec,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	opt := metav1.PatchOptions{FieldManager: global.K8sManager}
+	if dryRun {
+		opt.DryRun = []string{metav1.DryRunAll}
+	}
+	return global.K8s.Use(clusterName).ClientSet.NetworkingV1().Ingresses(ing.Namespace).Patch(context.TODO(), ing.Name, types.MergePatchType, data, opt)
+}
+
+func (i *Ingress) simpleIngressToIngress(ingressSimpleCreate *dto.K8sIngressSimpleCreate) (*ingressv1.Ingress, error) {
+	annotations := map[string]string{}
+	for k, v := range ingressSimpleCreate.Annotations {
+		annotations[k] = v
+	}
+	annotations["created-by"] = global.K8sManager
+
 	var rules []ingressv1.IngressRule
-	var tlses []ingressv1.IngressTLS
+	for _, item := range ingressSimpleCreate.Rules {
+		rule := ingressv1.IngressRule{
+			Host: item.Host,
+			IngressRuleValue: ingressv1.IngressRuleValue{
+				HTTP: &ingressv1.HTTPIngressRuleValue{},
+			},
+		}
+		for _, p := range item.Paths {
+			path, err := toIngressPath(p.Path, p.PathType, p.Service, p.ServicePort, p.ServicePortName)
+			if err != nil {
+				return nil, fmt.Errorf("host %q: %w", item.Host, err)
+			}
+			rule.HTTP.Paths = append(rule.HTTP.Paths, path)
+		}
+		rules = append(rules, rule)
+	}
 
-	// 默认值
-	pathType := ingressv1.PathTypePrefix
-	if ingressSimpleCreate.Annotations != nil {
-		ingressSimpleCreate.Annotations["created-by"] = global.K8sManager
-	} else {
-		ingressSimpleCreate.Annotations = map[string]string{"created-by": global.K8sManager}
-	}
-
-	rule := ingressv1.IngressRule{
-		IngressRuleValue: ingressv1.IngressRuleValue{
-			HTTP: &ingressv1.HTTPIngressRuleValue{Paths: []ingressv1.HTTPIngressPath{
-				{
-					Path:     ingressSimpleCreate.Rule.Path,
-					PathType: &pathType,
-					Backend: ingressv1.IngressBackend{
-						Service: &ingressv1.IngressServiceBackend{
-							Name: ingressSimpleCreate.Rule.Service,
-							Port: ingressv1.ServiceBackendPort{
-								Number: ingressSimpleCreate.Rule.ServicePort,
-							},
-						},
-					},
+	// 兼容旧版本的rule, 将hosts生成多个IngressRule
+	if legacy := ingressSimpleCreate.Rule; legacy != nil {
+		path, err := toIngressPath(legacy.Path, "", legacy.Service, legacy.ServicePort, "")
+		if err != nil {
+			return nil, err
+		}
+		hosts := legacy.Hosts
+		if len(hosts) == 0 {
+			hosts = []string{""}
+		}
+		for _, host := range hosts {
+			rules = append(rules, ingressv1.IngressRule{
+				Host: host,
+				IngressRuleValue: ingressv1.IngressRuleValue{
+					HTTP: &ingressv1.HTTPIngressRuleValue{Paths: []ingressv1.HTTPIngressPath{path}},
 				},
-			}},
-		},
+			})
+		}
 	}
 
-	// 将hosts生成多个IngressRule和TLS
-	if len(ingressSimpleCreate.Rule.Hosts) != 0 {
-		for _, host := range ingressSimpleCreate.Rule.Hosts {
-			rule.Host = host
-			rules = append(rules, rule)
+	var defaultBackend *ingressv1.IngressBackend
+	if b := ingressSimpleCreate.DefaultBackend; b != nil {
+		backend, err := toIngressBackend(b.Service, b.ServicePort, b.ServicePortName)
+		if err != nil {
+			return nil, fmt.Errorf("defaultBackend: %w", err)
 		}
-	} else {
-		rules = append(rules, rule)
+		defaultBackend = &backend
+	}
+
+	if len(rules) == 0 && defaultBackend == nil {
+		return nil, errors.New("rules和defaultBackend不能同时为空")
 	}
 
 	// 生成tls配置
-	if ingressSimpleCreate.Tls != nil {
-		for _, item := range ingressSimpleCreate.Tls {
-			tls := ingressv1.IngressTLS{
-				Hosts:      item.Hosts,
-				SecretName: item.SecretName,
-			}
-			tlses = append(tlses, tls)
-		}
+	var tlses []ingressv1.IngressTLS
+	for _, item := range ingressSimpleCreate.Tls {
+		tlses = append(tlses, ingressv1.IngressTLS{
+			Hosts:      item.Hosts,
+			SecretName: item.SecretName,
+		})
+	}
+
+	var ingressClassName *string
+	if ingressSimpleCreate.IngressClassName != "" {
+		ingressClassName = pointer.String(ingressSimpleCreate.IngressClassName)
 	}
 
 	ing := &ingressv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        ingressSimpleCreate.Name,
 			Namespace:   ingressSimpleCreate.Namespace,
-			Labels:      map[string]string{},
-			Annotations: ingressSimpleCreate.Annotations,
+			Labels:      ingressSimpleCreate.Labels,
+			Annotations: annotations,
 		},
 		Spec: ingressv1.IngressSpec{
-			IngressClassName: pointer.String(ingressSimpleCreate.IngressClassName),
+			IngressClassName: ingressClassName,
+			DefaultBackend:   defaultBackend,
 			Rules:            rules,
 			TLS:              tlses,
 		},
 	}
 
-	return ing
+	return ing, nil
+}
+
+// toIngressPath path为空时为 /, pathType为空时为Prefix
+func toIngressPath(path, pathType, service string, port int32, portName string) (ingressv1.HTTPIngressPath, error) {
+	if path == "" {
+		path = "/"
+	}
+	pt := ingressv1.PathTypePrefix
+	if pathType != "" {
+		pt = ingressv1.PathType(pathType)
+	}
+	if pt != ingressv1.PathTypeImplementationSpecific && !strings.HasPrefix(path, "/") {
+		return ingressv1.HTTPIngressPath{}, fmt.Errorf("path %q 必须以 / 开头", path)
+	}
+
+	backend, err := toIngressBackend(service, port, portName)
+	if err != nil {
+		return ingressv1.HTTPIngressPath{}, fmt.Errorf("path %q: %w", path, err)
+	}
+	return ingressv1.HTTPIngressPath{
+		Path:     path,
+		PathType: &pt,
+		Backend:  backend,
+	}, nil
+}
+
+func toIngressBackend(service string, port int32, portName string) (ingressv1.IngressBackend, error) {
+	if (port == 0) == (portName == "") {
+		return ingressv1.IngressBackend{}, fmt.Errorf("Service %s 的servicePort和servicePortName必须且只能设置一个", service)
+	}
+	return ingressv1.IngressBackend{
+		Service: &ingressv1.IngressServiceBackend{
+			Name: service,
+			Port: ingressv1.ServiceBackendPort{
+				Name:   portName,
+				Number: port,
+			},
+		},
+	}, nil
 }
@@ -0,0 +1,579 @@
+package ingress
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	ingressv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+	"soul/apis/dto"
+	"soul/apis/service/k8s"
+	"soul/global"
+	"strings"
+	"time"
+)
+
+// certExpiringWithin 证书在该时间内过期时给出警告
+const certExpiringWithin = 30 * 24 * time.Hour
+
+const (
+	legacyClassAnnotation  = "kubernetes.io/ingress.class"
+	defaultClassAnnotation = "ingressclass.kubernetes.io/is-default-class"
+)
+
+// ValidationError 校验发现error级别的问题, 创建和更新没有执行
+type ValidationError struct {
+	Validation *dto.K8sIngressValidation
+}
+
+func (e *ValidationError) Error() string {
+	var reasons []string
+	for _, finding := range e.Validation.Findings {
+		if finding.Severity == k8s.SeverityError {
+			reasons = append(reasons, finding.Message)
+		}
+	}
+	return "Ingress校验未通过: " + strings.Join(reasons, "; ")
+}
+
+// validator 检查一个或多个Ingress, 同一次检查中Service、Secret和IngressClass的查询结果会被缓存
+type validator struct {
+	ctx         context.Context
+	clusterName string
+
+	all          []ingressv1.Ingress // 集群中所有的Ingress, 用于检查host和path冲突
+	allNamespace string              // 没有权限列出整个集群的Ingress时, all只包含该namespace的Ingress
+	allForbidden bool                // 没有权限列出Ingress, 不检查冲突
+	classes      []ingressv1.IngressClass
+	classesRead  bool                       // 没有权限列出IngressClass时为false, 不检查IngressClass
+	services     map[string]*corev1.Service // key为 namespace/name, 值为nil表示不存在
+	secrets      map[string]*corev1.Secret
+}
+
+// newValidator 没有权限列出整个集群的Ingress时退回到namespace范围, 没有权限列出IngressClass时跳过相关检查,
+// 这两种情况都会在结果中给出info级别的提示
+func newValidator(ctx context.Context, clusterName, namespace string) (*validator, error) {
+	v := &validator{
+		ctx:         ctx,
+		clusterName: clusterName,
+		services:    map[string]*corev1.Service{},
+		secrets:     map[string]*corev1.Secret{},
+	}
+	clientSet := global.K8s.Use(clusterName).ClientSet
+
+	ingresses, err := clientSet.NetworkingV1().Ingresses("").List(ctx, metav1.ListOptions{})
+	if k8serrors.IsForbidden(err) && namespace != "" {
+		v.allNamespace = namespace
+		ingresses, err = clientSet.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	}
+	switch {
+	case k8serrors.IsForbidden(err):
+		v.allForbidden = true
+	case err != nil:
+		return nil, err
+	default:
+		v.all = ingresses.Items
+	}
+
+	classes, err := clientSet.NetworkingV1().IngressClasses().List(ctx, metav1.ListOptions{})
+	switch {
+	case k8serrors.IsForbidden(err):
+	case err != nil:
+		return nil, err
+	default:
+		v.classes = classes.Items
+		v.classesRead = true
+	}
+	return v, nil
+}
+
+// ValidateSimpleIngress 检查将要创建或更新的Ingress, 不会修改集群
+func (i *Ingress) ValidateSimpleIngress(ctx context.Context, clusterName string, ingressSimpleCreate *dto.K8sIngressSimpleCreate) (*dto.K8sIngressValidation, error) {
+	ing, err := i.simpleIngressToIngress(ingressSimpleCreate)
+	if err != nil {
+		return nil, err
+	}
+	// Ingress已经存在时按更新处理
+	if err = inheritLiveClass(ctx, clusterName, ing); err != nil {
+		return nil, err
+	}
+	v, err := newValidator(ctx, clusterName, ing.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return v.validate(ing)
+}
+
+// LintIngresses 检查namespace下所有的Ingress, namespace为空时检查整个集群, host和path冲突始终在整个集群范围内检查
+func (i *Ingress) LintIngresses(ctx context.Context, clusterName, namespace string) (*dto.K8sIngressLint, error) {
+	v, err := newValidator(ctx, clusterName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if v.allForbidden {
+		return nil, errors.New("没有权限列出Ingress")
+	}
+
+	lint := &dto.K8sIngressLint{Items: []dto.K8sIngressValidation{}}
+	for j := range v.all {
+		ing := &v.all[j]
+		if namespace != "" && ing.Namespace != namespace {
+			continue
+		}
+		lint.Total++
+		result, err := v.validate(ing)
+		if err != nil {
+			return nil, err
+		}
+		if len(result.Findings) == 0 {
+			continue
+		}
+		for _, finding := range result.Findings {
+			switch finding.Severity {
+			case k8s.SeverityError:
+				lint.Errors++
+			case k8s.SeverityWarning:
+				lint.Warnings++
+			}
+		}
+		lint.Items = append(lint.Items, *result)
+	}
+
+	sort.Slice(lint.Items, func(a, b int) bool {
+		if lint.Items[a].Valid != lint.Items[b].Valid {
+			return !lint.Items[a].Valid
+		}
+		return lint.Items[a].Namespace+"/"+lint.Items[a].Name < lint.Items[b].Namespace+"/"+lint.Items[b].Name
+	})
+	return lint, nil
+}
+
+// validateBeforeApply 创建和更新前检查, 有error级别的问题时返回ValidationError, force为true时不检查
+func (i *Ingress) validateBeforeApply(clusterName string, ing *ingressv1.Ingress, force bool) error {
+	if force {
+		return nil
+	}
+	v, err := newValidator(context.TODO(), clusterName, ing.Namespace)
+	if err != nil {
+		return err
+	}
+	result, err := v.validate(ing)
+	if err != nil {
+		return err
+	}
+	if !result.Valid {
+		return &ValidationError{Validation: result}
+	}
+	return nil
+}
+
+// inheritLiveClass 更新时没有指定ingressClassName会保留集群中的值, 校验时同样使用集群中的IngressClass
+func inheritLiveClass(ctx context.Context, clusterName string, ing *ingressv1.Ingress) error {
+	if ing.Spec.IngressClassName != nil || ing.Annotations[legacyClassAnnotation] != "" {
+		return nil
+	}
+	live, err := global.K8s.Use(clusterName).ClientSet.NetworkingV1().Ingresses(ing.Namespace).Get(ctx, ing.Name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	ing.Spec.IngressClassName = live.Spec.IngressClassName
+	if class := live.Annotations[legacyClassAnnotation]; class != "" {
+		if ing.Annotations == nil {
+			ing.Annotations = map[string]string{}
+		}
+		ing.Annotations[legacyClassAnnotation] = class
+	}
+	return nil
+}
+
+func (v *validator) validate(ing *ingressv1.Ingress) (*dto.K8sIngressValidation, error) {
+	result := &dto.K8sIngressValidation{
+		Name:      ing.Name,
+		Namespace: ing.Namespace,
+		Findings:  []dto.K8sDiagnosticFinding{},
+	}
+	add := func(finding dto.K8sDiagnosticFinding) {
+		result.Findings = append(result.Findings, finding)
+	}
+
+	if v.classesRead {
+		v.checkClass(ing, add)
+	} else {
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityInfo,
+			Category:    "IngressClass",
+			Reason:      "IngressClassForbidden",
+			Message:     "没有权限列出IngressClass, 跳过IngressClass检查",
+			Explanation: "当前集群凭证没有IngressClass的list权限, 无法确认IngressClass是否存在以及默认的IngressClass。",
+		})
+	}
+	if err := v.checkBackends(ing, add); err != nil {
+		return nil, err
+	}
+	if err := v.checkTLS(ing, add); err != nil {
+		return nil, err
+	}
+	switch {
+	case v.allForbidden:
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityInfo,
+			Category:    "Conflict",
+			Reason:      "ConflictCheckSkipped",
+			Message:     "没有权限列出Ingress, 跳过host和path冲突检查",
+			Explanation: "当前集群凭证没有Ingress的list权限。",
+		})
+	case v.allNamespace != "":
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityInfo,
+			Category:    "Conflict",
+			Reason:      "ConflictCheckNamespaced",
+			Message:     fmt.Sprintf("没有权限列出整个集群的Ingress, 只检查了namespace %s 中的冲突", v.allNamespace),
+			Explanation: "其它namespace中声明了相同host和path的Ingress不会被发现。",
+		})
+		v.checkConflicts(ing, add)
+	default:
+		v.checkConflicts(ing, add)
+	}
+
+	result.Valid = true
+	for _, finding := range result.Findings {
+		if finding.Severity == k8s.SeverityError {
+			result.Valid = false
+			break
+		}
+	}
+	return result, nil
+}
+
+// checkClass 指定的IngressClass不存在, 或者没有指定并且集群中没有默认的IngressClass时, Ingress不会被任何控制器处理
+func (v *validator) checkClass(ing *ingressv1.Ingress, add func(dto.K8sDiagnosticFinding)) {
+	if ing.Spec.IngressClassName != nil {
+		for _, class := range v.classes {
+			if class.Name == *ing.Spec.IngressClassName {
+				return
+			}
+		}
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityWarning,
+			Category:    "IngressClass",
+			Reason:      "IngressClassNotFound",
+			Message:     fmt.Sprintf("IngressClass %s 不存在", *ing.Spec.IngressClassName),
+			Explanation: "没有对应IngressClass的Ingress不会被任何Ingress控制器处理, 检查ingressClassName是否正确以及控制器是否已经安装。",
+		})
+		return
+	}
+	if ing.Annotations[legacyClassAnnotation] != "" {
+		return
+	}
+	if v.defaultClass() != "" {
+		return
+	}
+	add(dto.K8sDiagnosticFinding{
+		Severity:    k8s.SeverityWarning,
+		Category:    "IngressClass",
+		Reason:      "NoIngressClass",
+		Message:     "没有指定ingressClassName, 集群中也没有默认的IngressClass",
+		Explanation: "设置ingressClassName, 或者给一个IngressClass添加注解 ingressclass.kubernetes.io/is-default-class=true。",
+	})
+}
+
+// checkBackends 检查后端Service和端口是否存在, 端口需要是Service的port或端口名称
+func (v *validator) checkBackends(ing *ingressv1.Ingress, add func(dto.K8sDiagnosticFinding)) error {
+	type backendRef struct {
+		location string
+		backend  *ingressv1.IngressBackend
+	}
+	var refs []backendRef
+	if ing.Spec.DefaultBackend != nil {
+		refs = append(refs, backendRef{"defaultBackend", ing.Spec.DefaultBackend})
+	}
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for j := range rule.HTTP.Paths {
+			refs = append(refs, backendRef{hostPath(rule.Host, rule.HTTP.Paths[j].Path), &rule.HTTP.Paths[j].Backend})
+		}
+	}
+
+	for _, ref := range refs {
+		if ref.backend.Service == nil {
+			continue
+		}
+		backend := ref.backend.Service
+		svc, err := v.service(ing.Namespace, backend.Name)
+		if err != nil {
+			return err
+		}
+		if svc == nil {
+			add(dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "Backend",
+				Reason:      "ServiceNotFound",
+				Message:     fmt.Sprintf("%s 的后端Service %s 不存在", ref.location, backend.Name),
+				Explanation: "Ingress只能引用同一个namespace中的Service, 请求会返回503。",
+			})
+			continue
+		}
+		if svc.Spec.Type == corev1.ServiceTypeExternalName || hasPort(svc, backend.Port) {
+			continue
+		}
+		port := backend.Port.Name
+		if port == "" {
+			port = fmt.Sprint(backend.Port.Number)
+		}
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityError,
+			Category:    "Backend",
+			Reason:      "ServicePortNotFound",
+			Message:     fmt.Sprintf("%s 的后端Service %s 没有端口%s", ref.location, backend.Name, port),
+			Explanation: "Ingress后端的端口需要是Service的port或端口名称, 而不是容器端口。",
+		})
+	}
+	return nil
+}
+
+// checkTLS 检查TLS Secret是否存在、类型是否正确、证书是否覆盖hosts以及是否过期
+func (v *validator) checkTLS(ing *ingressv1.Ingress, add func(dto.K8sDiagnosticFinding)) error {
+	ruleHosts := map[string]bool{}
+	for _, rule := range ing.Spec.Rules {
+		ruleHosts[rule.Host] = true
+	}
+
+	for _, tls := range ing.Spec.TLS {
+		for _, host := range tls.Hosts {
+			if !ruleHosts[host] {
+				add(dto.K8sDiagnosticFinding{
+					Severity:    k8s.SeverityInfo,
+					Category:    "TLS",
+					Reason:      "TLSHostNotInRules",
+					Message:     fmt.Sprintf("TLS中的host %s 没有对应的规则", host),
+					Explanation: "只有rules中的host会使用这个证书, 检查host是否拼写错误。",
+				})
+			}
+		}
+		// 没有secretName时使用Ingress控制器的默认证书
+		if tls.SecretName == "" {
+			continue
+		}
+
+		secret, err := v.secret(ing.Namespace, tls.SecretName)
+		if err != nil {
+			return err
+		}
+		if secret == nil {
+			add(dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "TLS",
+				Reason:      "TLSSecretNotFound",
+				Message:     fmt.Sprintf("TLS Secret %s 不存在", tls.SecretName),
+				Explanation: "TLS Secret需要和Ingress在同一个namespace, Secret不存在时Ingress控制器会使用默认的自签名证书。",
+			})
+			continue
+		}
+		if secret.Type != corev1.SecretTypeTLS {
+			add(dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "TLS",
+				Reason:      "InvalidTLSSecret",
+				Message:     fmt.Sprintf("Secret %s 的类型是%s, 不是%s", tls.SecretName, secret.Type, corev1.SecretTypeTLS),
+				Explanation: "TLS Secret需要包含tls.crt和tls.key, 可以使用创建TLS Secret的接口重新创建。",
+			})
+			continue
+		}
+
+		cert, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+		if err != nil {
+			add(dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "TLS",
+				Reason:      "InvalidCertificate",
+				Message:     fmt.Sprintf("Secret %s 中的证书无法解析: %s", tls.SecretName, err.Error()),
+				Explanation: "tls.crt需要是PEM格式的证书, 证书链中第一个证书为服务器证书。",
+			})
+			continue
+		}
+
+		now := time.Now()
+		switch {
+		case now.After(cert.NotAfter):
+			add(dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "TLS",
+				Reason:      "CertificateExpired",
+				Message:     fmt.Sprintf("Secret %s 中的证书已于 %s 过期", tls.SecretName, cert.NotAfter.Format("2006-01-02 15:04:05")),
+				Explanation: "浏览器会拒绝过期的证书, 需要更新Secret中的证书。",
+			})
+		case cert.NotAfter.Sub(now) < certExpiringWithin:
+			add(dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityWarning,
+				Category:    "TLS",
+				Reason:      "CertificateExpiringSoon",
+				Message:     fmt.Sprintf("Secret %s 中的证书将于 %s 过期", tls.SecretName, cert.NotAfter.Format("2006-01-02 15:04:05")),
+				Explanation: "证书即将过期, 请及时续期。",
+			})
+		}
+
+		for _, host := range tls.Hosts {
+			if err = cert.VerifyHostname(host); err != nil {
+				add(dto.K8sDiagnosticFinding{
+					Severity:    k8s.SeverityError,
+					Category:    "TLS",
+					Reason:      "CertificateHostMismatch",
+					Message:     fmt.Sprintf("Secret %s 中的证书不包含host %s, 证书的域名为: %s", tls.SecretName, host, strings.Join(cert.DNSNames, ", ")),
+					Explanation: "证书的SAN需要包含TLS中的每个host, 通配符证书只匹配一级子域名。",
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// checkConflicts 同一个IngressClass中host、path和pathType都相同的规则只有一个会生效
+func (v *validator) checkConflicts(ing *ingressv1.Ingress, add func(dto.K8sDiagnosticFinding)) {
+	class := v.ingressClass(ing)
+	seen := map[string]bool{}
+	for _, key := range pathKeys(ing) {
+		if seen[key] {
+			add(dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "Conflict",
+				Reason:      "DuplicatePath",
+				Message:     fmt.Sprintf("规则 %s 重复", key),
+				Explanation: "同一个Ingress中相同host和path的规则只有一个会生效, 删除重复的规则。",
+			})
+		}
+		seen[key] = true
+	}
+
+	for j := range v.all {
+		other := &v.all[j]
+		if other.Namespace == ing.Namespace && other.Name == ing.Name {
+			continue
+		}
+		if v.ingressClass(other) != class {
+			continue
+		}
+		for _, key := range pathKeys(other) {
+			if !seen[key] {
+				continue
+			}
+			add(dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "Conflict",
+				Reason:      "HostPathConflict",
+				Message:     fmt.Sprintf("规则 %s 与Ingress %s/%s 冲突", key, other.Namespace, other.Name),
+				Explanation: "多个Ingress声明了相同的host和path时, 由Ingress控制器决定哪个生效(通常是创建时间最早的), 请求可能被转发到错误的Service。",
+			})
+			// 每个key只报告一次
+			seen[key] = false
+		}
+	}
+}
+
+func (v *validator) service(namespace, name string) (*corev1.Service, error) {
+	key := namespace + "/" + name
+	if svc, ok := v.services[key]; ok {
+		return svc, nil
+	}
+	svc, err := global.K8s.Use(v.clusterName).ClientSet.CoreV1().Services(namespace).Get(v.ctx, name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		svc, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	v.services[key] = svc
+	return svc, nil
+}
+
+func (v *validator) secret(namespace, name string) (*corev1.Secret, error) {
+	key := namespace + "/" + name
+	if secret, ok := v.secrets[key]; ok {
+		return secret, nil
+	}
+	secret, err := global.K8s.Use(v.clusterName).ClientSet.CoreV1().Secrets(namespace).Get(v.ctx, name, metav1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		secret, err = nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	v.secrets[key] = secret
+	return secret, nil
+}
+
+func hasPort(svc *corev1.Service, port ingressv1.ServiceBackendPort) bool {
+	for _, servicePort := range svc.Spec.Ports {
+		if port.Name != "" && servicePort.Name == port.Name {
+			return true
+		}
+		if port.Name == "" && servicePort.Port == port.Number {
+			return true
+		}
+	}
+	return false
+}
+
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("没有找到PEM格式的证书")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// ingressClass 优先使用ingressClassName, 其次是旧版本的注解, 都没有时使用默认的IngressClass,
+// 这样没有指定class的Ingress与显式指定默认class的Ingress会被当作同一个class比较
+func (v *validator) ingressClass(ing *ingressv1.Ingress) string {
+	if ing.Spec.IngressClassName != nil {
+		return *ing.Spec.IngressClassName
+	}
+	if class := ing.Annotations[legacyClassAnnotation]; class != "" {
+		return class
+	}
+	return v.defaultClass()
+}
+
+// defaultClass 默认的IngressClass名称, 没有或者无法读取时为空
+func (v *validator) defaultClass() string {
+	for _, class := range v.classes {
+		if class.Annotations[defaultClassAnnotation] == "true" {
+			return class.Name
+		}
+	}
+	return ""
+}
+
+// pathKeys 规则的 host+path, pathType不同时分别作为不同的规则
+func pathKeys(ing *ingressv1.Ingress) []string {
+	var keys []string
+	for _, rule := range ing.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			pathType := string(ingressv1.PathTypeImplementationSpecific)
+			if path.PathType != nil {
+				pathType = string(*path.PathType)
+			}
+			keys = append(keys, fmt.Sprintf("%s(%s)", hostPath(rule.Host, path.Path), pathType))
+		}
+	}
+	return keys
+}
+
+func hostPath(host, path string) string {
+	if host == "" {
+		host = "*"
+	}
+	return host + path
+}
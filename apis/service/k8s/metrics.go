@@ -0,0 +1,170 @@
+package k8s
+
+import (
+	"context"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"soul/apis/dto"
+	"soul/global"
+	log "soul/internal/logger"
+)
+
+// metrics-server 提供的 metrics.k8s.io API, 没有引入 k8s.io/metrics, 通过动态客户端读取
+var (
+	podMetricsGVR  = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+	nodeMetricsGVR = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "nodes"}
+)
+
+type podMetrics struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Timestamp         metav1.Time        `json:"timestamp"`
+	Window            metav1.Duration    `json:"window"`
+	Containers        []containerMetrics `json:"containers"`
+}
+
+type containerMetrics struct {
+	Name  string              `json:"name"`
+	Usage corev1.ResourceList `json:"usage"`
+}
+
+type nodeMetrics struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Usage             corev1.ResourceList `json:"usage"`
+}
+
+// PodMetricsMap Pod的监控数据, key为 namespace/name
+type PodMetricsMap map[string]*podMetrics
+
+// ListPodMetrics 获取namespace下Pod的监控数据, namespace为空时获取所有namespace。
+// metrics-server没有安装或不可用时返回nil, 调用方按没有监控数据处理
+func ListPodMetrics(ctx context.Context, clusterName, namespace, labelSelector string) PodMetricsMap {
+	list, err := global.K8s.Use(clusterName).DynamicClient.Resource(podMetricsGVR).Namespace(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		logMetricsError(clusterName, err)
+		return nil
+	}
+
+	metrics := make(PodMetricsMap, len(list.Items))
+	for i := range list.Items {
+		m := &podMetrics{}
+		if err = fromUnstructured(&list.Items[i], m); err != nil {
+			log.Debug("解析Pod监控数据失败: %s", err.Error())
+			continue
+		}
+		metrics[m.Namespace+"/"+m.Name] = m
+	}
+	return metrics
+}
+
+// GetPodMetrics 获取单个Pod的资源使用量, 没有监控数据时返回nil
+func GetPodMetrics(ctx context.Context, clusterName string, pod *corev1.Pod) *dto.K8sPodMetrics {
+	obj, err := global.K8s.Use(clusterName).DynamicClient.Resource(podMetricsGVR).Namespace(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+	if err != nil {
+		logMetricsError(clusterName, err)
+		return nil
+	}
+	m := &podMetrics{}
+	if err = fromUnstructured(obj, m); err != nil {
+		log.Debug("解析Pod监控数据失败: %s", err.Error())
+		return nil
+	}
+	return PodMetricsMap{pod.Namespace + "/" + pod.Name: m}.Of(pod)
+}
+
+// Of 将Pod的监控数据和Pod中容器的request、limit合并, 没有监控数据时返回nil
+func (pm PodMetricsMap) Of(pod *corev1.Pod) *dto.K8sPodMetrics {
+	m, ok := pm[pod.Namespace+"/"+pod.Name]
+	if !ok {
+		return nil
+	}
+
+	usages := make(map[string]corev1.ResourceList, len(m.Containers))
+	for _, container := range m.Containers {
+		usages[container.Name] = container.Usage
+	}
+
+	metrics := &dto.K8sPodMetrics{
+		Timestamp:  m.Timestamp.Time,
+		Window:     m.Window.Duration.String(),
+		Containers: make([]dto.K8sContainerMetrics, 0, len(pod.Spec.Containers)),
+	}
+	for _, container := range pod.Spec.Containers {
+		usage := usages[container.Name]
+		c := dto.K8sContainerMetrics{
+			Name:          container.Name,
+			CPUUsage:      usage.Cpu().MilliValue(),
+			CPURequest:    container.Resources.Requests.Cpu().MilliValue(),
+			CPULimit:      container.Resources.Limits.Cpu().MilliValue(),
+			MemoryUsage:   usage.Memory().Value(),
+			MemoryRequest: container.Resources.Requests.Memory().Value(),
+			MemoryLimit:   container.Resources.Limits.Memory().Value(),
+		}
+		metrics.CPUUsage += c.CPUUsage
+		metrics.MemoryUsage += c.MemoryUsage
+		metrics.Containers = append(metrics.Containers, c)
+	}
+	return metrics
+}
+
+// Sum 合计多个Pod的资源使用量, 没有监控数据时返回nil
+func (pm PodMetricsMap) Sum(pods []corev1.Pod) *dto.K8sWorkloadMetrics {
+	if pm == nil {
+		return nil
+	}
+	total := &dto.K8sWorkloadMetrics{}
+	for i := range pods {
+		metrics := pm.Of(&pods[i])
+		if metrics == nil {
+			continue
+		}
+		total.Pods++
+		for _, c := range metrics.Containers {
+			total.CPUUsage += c.CPUUsage
+			total.CPURequest += c.CPURequest
+			total.CPULimit += c.CPULimit
+			total.MemoryUsage += c.MemoryUsage
+			total.MemoryRequest += c.MemoryRequest
+			total.MemoryLimit += c.MemoryLimit
+		}
+	}
+	return total
+}
+
+// ListNodeMetrics 获取所有节点的资源使用量, key为节点名称, metrics-server不可用时返回nil
+func ListNodeMetrics(ctx context.Context, clusterName string) map[string]corev1.ResourceList {
+	list, err := global.K8s.Use(clusterName).DynamicClient.Resource(nodeMetricsGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		logMetricsError(clusterName, err)
+		return nil
+	}
+
+	usages := make(map[string]corev1.ResourceList, len(list.Items))
+	for i := range list.Items {
+		m := &nodeMetrics{}
+		if err = fromUnstructured(&list.Items[i], m); err != nil {
+			log.Debug("解析节点监控数据失败: %s", err.Error())
+			continue
+		}
+		usages[m.Name] = m.Usage
+	}
+	return usages
+}
+
+func fromUnstructured(obj *unstructured.Unstructured, out any) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), out)
+}
+
+// logMetricsError 没有安装metrics-server时返回NotFound, metrics-server异常时返回ServiceUnavailable, 都不是请求本身的错误
+func logMetricsError(clusterName string, err error) {
+	if k8serrors.IsNotFound(err) {
+		log.Debug("集群%s没有监控数据: %s", clusterName, err.Error())
+		return
+	}
+	log.Info("获取集群%s的监控数据失败: %s", clusterName, err.Error())
+}
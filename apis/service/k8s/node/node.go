@@ -0,0 +1,80 @@
+package node
+
+import (
+	"context"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+	"soul/apis/dto"
+	"soul/apis/service/k8s"
+	"soul/global"
+)
+
+type Node struct{}
+
+// GetNodeList 获取所有节点的可分配资源和使用量, metrics-server不可用时usage为空
+func (n *Node) GetNodeList(ctx context.Context, clusterName string) ([]dto.K8sNodeMetrics, error) {
+	nodes, err := global.K8s.Use(clusterName).ClientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(nodes.Items, func(i, j int) bool {
+		return nodes.Items[i].Name < nodes.Items[j].Name
+	})
+
+	usages := k8s.ListNodeMetrics(ctx, clusterName)
+	items := make([]dto.K8sNodeMetrics, 0, len(nodes.Items))
+	for i := range nodes.Items {
+		items = append(items, toNodeMetrics(&nodes.Items[i], usages))
+	}
+	return items, nil
+}
+
+// GetNodeByName 获取节点的可分配资源和使用量, metrics-server不可用时usage为空
+func (n *Node) GetNodeByName(ctx context.Context, clusterName, name string) (*dto.K8sNodeMetrics, error) {
+	node, err := global.K8s.Use(clusterName).ClientSet.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	metrics := toNodeMetrics(node, k8s.ListNodeMetrics(ctx, clusterName))
+	return &metrics, nil
+}
+
+func toNodeMetrics(node *corev1.Node, usages map[string]corev1.ResourceList) dto.K8sNodeMetrics {
+	allocatable := node.Status.Allocatable
+	metrics := dto.K8sNodeMetrics{
+		Name:              node.Name,
+		Unschedulable:     node.Spec.Unschedulable,
+		Labels:            node.Labels,
+		KubeletVersion:    node.Status.NodeInfo.KubeletVersion,
+		CPUAllocatable:    allocatable.Cpu().MilliValue(),
+		MemoryAllocatable: allocatable.Memory().Value(),
+		PodsAllocatable:   allocatable.Pods().Value(),
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			metrics.Ready = condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	usage, ok := usages[node.Name]
+	if !ok {
+		return metrics
+	}
+	metrics.Usage = &dto.K8sNodeUsage{
+		CPUUsage:    usage.Cpu().MilliValue(),
+		MemoryUsage: usage.Memory().Value(),
+	}
+	if metrics.CPUAllocatable > 0 {
+		metrics.Usage.CPUPercent = percent(metrics.Usage.CPUUsage, metrics.CPUAllocatable)
+	}
+	if metrics.MemoryAllocatable > 0 {
+		metrics.Usage.MemoryPercent = percent(metrics.Usage.MemoryUsage, metrics.MemoryAllocatable)
+	}
+	return metrics
+}
+
+// percent 保留两位小数
+func percent(used, total int64) float64 {
+	return float64(used*10000/total) / 100
+}
@@ -0,0 +1,410 @@
+package pod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"regexp"
+	"sort"
+	"soul/apis/dto"
+	"soul/apis/service/k8s/deployment"
+	"soul/global"
+	log "soul/internal/logger"
+	"soul/utils/httputil"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxAggregatedStreams 同时读取的容器日志流上限
+	maxAggregatedStreams = 100
+	// orderDelay follow模式下日志行的等待时间, 在此时间内到达的日志会按时间戳排序后输出
+	orderDelay    = time.Second
+	flushInterval = 200 * time.Millisecond
+	// aggregatedTailLines 未指定tailLines时每个容器读取的行数
+	aggregatedTailLines int64 = 1000
+	// 非follow模式需要缓存所有日志后排序, 超过上限时只保留最新的日志
+	maxAggregatedLines = 50000
+	maxAggregatedBytes = 32 << 20
+)
+
+type logLine struct {
+	time      time.Time
+	pod       string
+	container string
+	text      string
+}
+
+// aggregator 聚合多个Pod、多个容器的日志
+type aggregator struct {
+	ctx         context.Context
+	clusterName string
+	namespace   string
+	query       dto.K8sAggregatedLogQuery
+	filter      *regexp.Regexp
+
+	lines  chan logLine
+	errs   chan error // 读取日志和监听Pod的错误, 只由collector转发到events, events只能由collector发送和关闭
+	events chan httputil.Event
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	active   map[string]bool      // 正在读取的 pod/container
+	lastTime map[string]time.Time // 每个 pod/container 最后一行日志的时间, 容器重启后从此时间继续读取
+}
+
+// StreamAggregatedLog 聚合Deployment、StatefulSet或标签选择器匹配的所有Pod的日志, 每行带有 [pod/container] 前缀。
+// 非follow模式读取完毕后整体按时间戳排序输出; follow模式下持续监听新的Pod, 按时间戳尽量有序地输出
+func (p *Pod) StreamAggregatedLog(ctx context.Context, clusterName, namespace string, query dto.K8sAggregatedLogQuery) (<-chan httputil.Event, error) {
+	selector, err := resolveSelector(clusterName, namespace, query.Kind, query.Name, query.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &aggregator{
+		ctx:         ctx,
+		clusterName: clusterName,
+		namespace:   namespace,
+		query:       query,
+		lines:       make(chan logLine, logBufferLines*4),
+		errs:        make(chan error, maxAggregatedStreams),
+		events:      make(chan httputil.Event, logBufferLines),
+		active:      map[string]bool{},
+		lastTime:    map[string]time.Time{},
+	}
+	if query.Filter != "" {
+		a.filter, err = regexp.Compile(query.Filter)
+		if err != nil {
+			return nil, errors.New("filter不是合法的正则表达式: " + err.Error())
+		}
+	}
+
+	pods, err := global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 && !query.Follow {
+		return nil, errors.New("没有匹配的Pod")
+	}
+
+	for i := range pods.Items {
+		a.startPod(&pods.Items[i], true)
+	}
+
+	if query.Follow {
+		go a.watchPods(selector, pods.ResourceVersion)
+		go a.collectFollow()
+	} else {
+		go func() {
+			a.wg.Wait()
+			close(a.lines)
+		}()
+		go a.collectAll()
+	}
+
+	return a.events, nil
+}
+
+// resolveSelector 将kind+name解析为标签选择器
+func resolveSelector(clusterName, namespace, kind, name, labelSelector string) (string, error) {
+	switch kind {
+	case "deployment":
+		d := &deployment.Deployment{}
+		return d.GetDeploymentSelector(clusterName, name, namespace)
+	case "statefulset":
+		sts, err := global.K8s.Use(clusterName).ClientSet.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		return metav1.FormatLabelSelector(sts.Spec.Selector), nil
+	}
+	return labelSelector, nil
+}
+
+// startPod 为Pod中已启动的容器开始读取日志, initial为true时使用请求中的tailLines/sinceSeconds
+func (a *aggregator) startPod(pod *corev1.Pod, initial bool) {
+	if pod.DeletionTimestamp != nil && !initial {
+		return
+	}
+
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if a.query.Container != "" && status.Name != a.query.Container {
+			continue
+		}
+		// 还没有启动的容器没有日志
+		if status.State.Running == nil && status.State.Terminated == nil {
+			continue
+		}
+		// 已经读取过的容器退出后不会再有新日志, 避免每次Pod更新都重新读取
+		if !initial && status.State.Terminated != nil && a.seen(pod.Name+"/"+status.Name) {
+			continue
+		}
+		a.startContainer(pod.Name, status.Name, initial)
+	}
+}
+
+func (a *aggregator) seen(key string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, ok := a.lastTime[key]
+	return ok
+}
+
+func (a *aggregator) startContainer(podName, containerName string, initial bool) {
+	key := podName + "/" + containerName
+
+	a.mu.Lock()
+	if a.active[key] {
+		a.mu.Unlock()
+		return
+	}
+	if len(a.active) >= maxAggregatedStreams {
+		a.mu.Unlock()
+		log.Debug("聚合日志容器数量超过%d, 忽略 %s", maxAggregatedStreams, key)
+		return
+	}
+	a.active[key] = true
+	lastTime, restarted := a.lastTime[key]
+	a.mu.Unlock()
+	// 没有读取到带时间戳的日志时无法续读, 按初次读取处理
+	resume := restarted && !lastTime.IsZero()
+
+	option := &corev1.PodLogOptions{
+		Container:  containerName,
+		Follow:     a.query.Follow,
+		Timestamps: true, // 用于排序, 输出时按需去掉
+	}
+	switch {
+	case resume:
+		// 容器重启或日志流中断后, 从最后一行日志之后继续读取
+		option.SinceTime = &metav1.Time{Time: lastTime.Add(time.Nanosecond)}
+	case initial:
+		tailLines := aggregatedTailLines
+		if a.query.TailLines != nil {
+			tailLines = *a.query.TailLines
+		}
+		option.TailLines = &tailLines
+		option.SinceSeconds = a.query.SinceSeconds
+	}
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		defer func() {
+			a.mu.Lock()
+			delete(a.active, key)
+			a.mu.Unlock()
+		}()
+
+		stream, err := global.K8s.Use(a.clusterName).ClientSet.CoreV1().Pods(a.namespace).GetLogs(podName, option).Stream(a.ctx)
+		if err != nil {
+			a.sendError(fmt.Errorf("[%s] %s", key, err.Error()))
+			return
+		}
+		defer stream.Close()
+
+		readLines(a.ctx, stream, func(line string) bool {
+			l := parseLogLine(podName, containerName, line)
+			// 没有时间戳的行无法判断是否已经读取过, 不去重
+			if resume && !l.time.IsZero() && !l.time.After(lastTime) {
+				return true
+			}
+			a.mu.Lock()
+			if !l.time.IsZero() {
+				a.lastTime[key] = l.time
+			} else if _, ok := a.lastTime[key]; !ok {
+				a.lastTime[key] = time.Time{}
+			}
+			a.mu.Unlock()
+
+			if a.filter != nil && !a.filter.MatchString(l.text) {
+				return true
+			}
+			select {
+			case a.lines <- l:
+				return true
+			case <-a.ctx.Done():
+				return false
+			}
+		}, func(err error) {
+			a.sendError(fmt.Errorf("[%s] %s", key, err.Error()))
+		})
+	}()
+}
+
+// watchPods 监听新增和重启的Pod, watch超时后重新建立
+func (a *aggregator) watchPods(selector, resourceVersion string) {
+	pods := global.K8s.Use(a.clusterName).ClientSet.CoreV1().Pods(a.namespace)
+	for a.ctx.Err() == nil {
+		watcher, err := pods.Watch(a.ctx, metav1.ListOptions{
+			LabelSelector:   selector,
+			ResourceVersion: resourceVersion,
+		})
+		if err != nil {
+			a.sendError(err)
+			return
+		}
+
+		for event := range watcher.ResultChan() {
+			if event.Type == watch.Error {
+				// resourceVersion过期时从最新版本重新监听
+				resourceVersion = ""
+				break
+			}
+			pod, ok := event.Object.(*corev1.Pod)
+			if !ok {
+				continue
+			}
+			resourceVersion = pod.ResourceVersion
+			if event.Type == watch.Added || event.Type == watch.Modified {
+				a.startPod(pod, false)
+			}
+		}
+		watcher.Stop()
+	}
+}
+
+// collectAll 非follow模式, 读取所有日志后按时间戳排序输出.
+// 缓存的日志超过maxAggregatedLines或maxAggregatedBytes时丢弃最早的日志
+func (a *aggregator) collectAll() {
+	defer close(a.events)
+
+	var (
+		buffer    []logLine
+		size      int
+		truncated bool
+	)
+	for {
+		select {
+		case l, ok := <-a.lines:
+			if !ok {
+				// 所有日志流已经结束, 转发剩余的错误
+				for len(a.errs) > 0 {
+					if !a.emitError(<-a.errs) {
+						return
+					}
+				}
+				if truncated && !a.emitError(fmt.Errorf("日志超过%d行或%dMB, 只返回最新的部分", maxAggregatedLines, maxAggregatedBytes>>20)) {
+					return
+				}
+				a.flush(buffer)
+				return
+			}
+			buffer = append(buffer, l)
+			size += len(l.text)
+			if len(buffer) > maxAggregatedLines || size > maxAggregatedBytes {
+				// 一次丢弃到上限的3/4, 避免每行都重新排序
+				sortLines(buffer)
+				i := 0
+				for len(buffer)-i > maxAggregatedLines*3/4 || size > maxAggregatedBytes*3/4 {
+					size -= len(buffer[i].text)
+					i++
+				}
+				buffer = append(buffer[:0], buffer[i:]...)
+				truncated = true
+			}
+		case err := <-a.errs:
+			if !a.emitError(err) {
+				return
+			}
+		case <-a.ctx.Done():
+			return
+		}
+	}
+}
+
+// collectFollow follow模式, 日志在缓冲区中等待orderDelay后按时间戳排序输出
+func (a *aggregator) collectFollow() {
+	defer close(a.events)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	var buffer []logLine
+	for {
+		select {
+		case l := <-a.lines:
+			buffer = append(buffer, l)
+		case err := <-a.errs:
+			if !a.emitError(err) {
+				return
+			}
+		case <-ticker.C:
+			sortLines(buffer)
+			watermark := time.Now().Add(-orderDelay)
+			i := sort.Search(len(buffer), func(i int) bool {
+				return buffer[i].time.After(watermark)
+			})
+			if !a.flush(buffer[:i]) {
+				return
+			}
+			buffer = append(buffer[:0], buffer[i:]...)
+		case <-a.ctx.Done():
+			return
+		}
+	}
+}
+
+// flush 排序并输出日志, 客户端断开时返回false
+func (a *aggregator) flush(lines []logLine) bool {
+	sortLines(lines)
+	for _, l := range lines {
+		text := l.text
+		if a.query.Timestamps && !l.time.IsZero() {
+			text = l.time.Format(time.RFC3339Nano) + " " + text
+		}
+		select {
+		case a.events <- httputil.Event{Name: "log", Data: fmt.Sprintf("[%s/%s] %s", l.pod, l.container, text)}:
+		case <-a.ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// sendError 由读取日志和监听Pod的goroutine调用, 错误交给collector输出.
+// 这些goroutine可能在collector关闭events之后才结束, 不能直接发送到events
+func (a *aggregator) sendError(err error) {
+	if a.ctx.Err() != nil {
+		return
+	}
+	select {
+	case a.errs <- err:
+	case <-a.ctx.Done():
+	}
+}
+
+// emitError 输出错误, 只能由collector调用, 客户端断开时返回false
+func (a *aggregator) emitError(err error) bool {
+	select {
+	case a.events <- httputil.Event{Name: "error", Data: err.Error()}:
+		return true
+	case <-a.ctx.Done():
+		return false
+	}
+}
+
+func sortLines(lines []logLine) {
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].time.Before(lines[j].time)
+	})
+}
+
+// parseLogLine 解析kubelet添加的RFC3339Nano时间戳
+func parseLogLine(podName, containerName, line string) logLine {
+	l := logLine{pod: podName, container: containerName, text: line}
+	if index := strings.IndexByte(line, ' '); index > 0 {
+		if t, err := time.Parse(time.RFC3339Nano, line[:index]); err == nil {
+			l.time = t
+			l.text = line[index+1:]
+		}
+	}
+	return l
+}
@@ -0,0 +1,248 @@
+package pod
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+	"sort"
+	"soul/apis/dto"
+	"soul/global"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// bundleLogLimitBytes 诊断包中每个日志文件默认的大小上限
+const bundleLogLimitBytes int64 = 10 << 20
+
+// DiagnosticBundle 诊断包, 包含工作负载下所有Pod的YAML、事件、所有容器的日志和上一个实例的日志
+type DiagnosticBundle struct {
+	Name string // 下载的文件名
+
+	clusterName string
+	namespace   string
+	query       dto.K8sDiagnosticBundleQuery
+	object      *corev1.ObjectReference // 工作负载本身, labelSelector方式时为空
+	pods        []corev1.Pod
+
+	tw       *tar.Writer
+	modTime  time.Time
+	failures []string
+}
+
+// GetDiagnosticBundle 解析工作负载并列出Pod, 此时出错可以直接返回错误响应; 打包在WriteTo中进行
+func (p *Pod) GetDiagnosticBundle(ctx context.Context, clusterName, namespace string, query dto.K8sDiagnosticBundleQuery) (*DiagnosticBundle, error) {
+	selector, err := resolveSelector(clusterName, namespace, query.Kind, query.Name, query.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, errors.New("没有匹配的Pod")
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+
+	now := time.Now()
+	bundle := &DiagnosticBundle{
+		clusterName: clusterName,
+		namespace:   namespace,
+		query:       query,
+		pods:        pods.Items,
+		modTime:     now,
+	}
+	if query.Kind != "" {
+		bundle.Name = fmt.Sprintf("%s-%s-%s-%s.tar.gz", clusterName, namespace, query.Name, now.Format("20060102150405"))
+		bundle.object = &corev1.ObjectReference{Kind: kindName(query.Kind), Name: query.Name}
+	} else {
+		bundle.Name = fmt.Sprintf("%s-%s-%s.tar.gz", clusterName, namespace, now.Format("20060102150405"))
+	}
+	return bundle, nil
+}
+
+// WriteTo 将诊断包以tar.gz格式写入w。单个文件获取失败不会中断打包, 失败原因记录在errors.txt中
+func (b *DiagnosticBundle) WriteTo(ctx context.Context, w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	b.tw = tar.NewWriter(gw)
+
+	if b.object != nil {
+		if err := b.writeEvents(ctx, "events.txt", b.object.Kind, b.object.Name); err != nil {
+			return err
+		}
+	}
+
+	for i := range b.pods {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := b.writePod(ctx, &b.pods[i]); err != nil {
+			return err
+		}
+	}
+
+	if len(b.failures) > 0 {
+		if err := b.writeFile("errors.txt", []byte(strings.Join(b.failures, "\n")+"\n")); err != nil {
+			return err
+		}
+	}
+
+	if err := b.tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func (b *DiagnosticBundle) writePod(ctx context.Context, pod *corev1.Pod) error {
+	dir := pod.Name + "/"
+
+	pod.ManagedFields = nil
+	pod.APIVersion = "v1"
+	pod.Kind = "Pod"
+	data, err := yaml.Marshal(pod)
+	if err != nil {
+		b.fail(dir+"pod.yaml", err)
+	} else if err = b.writeFile(dir+"pod.yaml", data); err != nil {
+		return err
+	}
+
+	if err = b.writeEvents(ctx, dir+"events.txt", "Pod", pod.Name); err != nil {
+		return err
+	}
+
+	statuses := map[string]corev1.ContainerStatus{}
+	for _, status := range append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+		statuses[status.Name] = status
+	}
+	for _, container := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+		status, ok := statuses[container.Name]
+		// 还没有启动过的容器没有日志
+		if !ok || (status.State.Running == nil && status.State.Terminated == nil && status.LastTerminationState.Terminated == nil) {
+			continue
+		}
+		if err = b.writeLog(ctx, pod.Name, container.Name, false); err != nil {
+			return err
+		}
+		if status.RestartCount > 0 {
+			if err = b.writeLog(ctx, pod.Name, container.Name, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeLog 日志需要先读到内存中, tar的文件头里要写入文件大小, 因此用limitBytes限制每个文件的大小
+func (b *DiagnosticBundle) writeLog(ctx context.Context, podName, containerName string, previous bool) error {
+	name := podName + "/" + containerName + ".log"
+	if previous {
+		name = podName + "/" + containerName + ".previous.log"
+	}
+
+	limitBytes := bundleLogLimitBytes
+	if b.query.LimitBytes != nil && *b.query.LimitBytes < limitBytes {
+		limitBytes = *b.query.LimitBytes
+	}
+	option := &corev1.PodLogOptions{
+		Container:    containerName,
+		Previous:     previous,
+		Timestamps:   true,
+		TailLines:    b.query.TailLines,
+		SinceSeconds: b.query.SinceSeconds,
+		LimitBytes:   &limitBytes,
+	}
+
+	stream, err := global.K8s.Use(b.clusterName).ClientSet.CoreV1().Pods(b.namespace).GetLogs(podName, option).Stream(ctx)
+	if err != nil {
+		b.fail(name, err)
+		return nil
+	}
+	defer stream.Close()
+
+	buf := new(bytes.Buffer)
+	if _, err = io.Copy(buf, stream); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// 保留已经读取的部分
+		b.fail(name, err)
+	}
+	return b.writeFile(name, buf.Bytes())
+}
+
+func (b *DiagnosticBundle) writeEvents(ctx context.Context, name, kind, objectName string) error {
+	events, err := global.K8s.Use(b.clusterName).ClientSet.CoreV1().Events(b.namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.kind=%s,involvedObject.name=%s", kind, objectName),
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		b.fail(name, err)
+		return nil
+	}
+	sort.Slice(events.Items, func(i, j int) bool {
+		return eventTime(&events.Items[i]).Before(eventTime(&events.Items[j]))
+	})
+
+	buf := new(bytes.Buffer)
+	w := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LAST SEEN\tTYPE\tREASON\tCOUNT\tMESSAGE")
+	for i := range events.Items {
+		event := &events.Items[i]
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", eventTime(event).Format(time.RFC3339), event.Type, event.Reason, event.Count, strings.TrimSpace(event.Message))
+	}
+	w.Flush()
+	return b.writeFile(name, buf.Bytes())
+}
+
+func (b *DiagnosticBundle) writeFile(name string, data []byte) error {
+	err := b.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: b.modTime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = b.tw.Write(data)
+	return err
+}
+
+func (b *DiagnosticBundle) fail(name string, err error) {
+	b.failures = append(b.failures, name+": "+err.Error())
+}
+
+func eventTime(event *corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+func kindName(kind string) string {
+	switch kind {
+	case "deployment":
+		return "Deployment"
+	case "statefulset":
+		return "StatefulSet"
+	}
+	return kind
+}
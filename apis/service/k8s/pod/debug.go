@@ -0,0 +1,257 @@
+package pod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"soul/apis/dto"
+	"soul/global"
+	log "soul/internal/logger"
+	"strings"
+	"time"
+)
+
+// StartDebugTerminal 在Pod中添加临时调试容器并打开终端, 用于没有shell的镜像(如distroless)。
+// 临时容器添加后不能删除, 会一直保留到Pod被删除, 每次调试都会添加一个新的临时容器
+func (p *Pod) StartDebugTerminal(ctx context.Context, clusterName, namespace, podName string, debug dto.K8sPodDebug, userId uint) (*dto.K8sDebugTerminal, error) {
+	pods := global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace)
+	pod, err := pods.Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return nil, fmt.Errorf("Pod状态为%s, 只能调试运行中的Pod", pod.Status.Phase)
+	}
+	if debug.TargetContainer != "" && !hasContainer(pod, debug.TargetContainer) {
+		return nil, fmt.Errorf("Pod中没有容器%s", debug.TargetContainer)
+	}
+
+	containerName := debugContainerName(pod)
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     containerName,
+			Image:                    debugImage(debug.Image),
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		},
+		TargetContainerName: debug.TargetContainer,
+	})
+	if _, err = pods.UpdateEphemeralContainers(ctx, podName, pod, metav1.UpdateOptions{}); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, errors.New("集群不支持临时容器, 需要Kubernetes 1.23及以上版本")
+		}
+		return nil, err
+	}
+
+	if err = waitContainerRunning(ctx, clusterName, namespace, podName, containerName); err != nil {
+		return nil, err
+	}
+
+	sessionID, token, err := startTerminal(clusterName, namespace, podName, containerName, debug.Shell, userId, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.K8sDebugTerminal{
+		ID:            sessionID,
+		Token:         token,
+		Namespace:     namespace,
+		PodName:       podName,
+		ContainerName: containerName,
+	}, nil
+}
+
+// StartNodeDebugTerminal 在节点上启动特权调试Pod并打开终端, Pod使用节点的网络、PID和IPC命名空间,
+// 节点的根目录挂载在/host, 可以通过 chroot /host 进入节点。终端关闭后删除调试Pod
+func (p *Pod) StartNodeDebugTerminal(ctx context.Context, clusterName, nodeName string, debug dto.K8sNodeDebug, userId uint) (*dto.K8sDebugTerminal, error) {
+	clientSet := global.K8s.Use(clusterName).ClientSet
+	if _, err := clientSet.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{}); err != nil {
+		return nil, err
+	}
+
+	namespace := global.Config.Debug.NodeNamespace
+	privileged := true
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "node-debugger-" + truncate(nodeName, 40) + "-",
+			Namespace:    namespace,
+			Labels:       map[string]string{global.K8sNodeDebugLabel: "true"},
+		},
+		Spec: corev1.PodSpec{
+			NodeName:      nodeName,
+			HostNetwork:   true,
+			HostPID:       true,
+			HostIPC:       true,
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{{
+				Name:            "debugger",
+				Image:           debugImage(debug.Image),
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Stdin:           true,
+				TTY:             true,
+				SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+				VolumeMounts:    []corev1.VolumeMount{{Name: "host-root", MountPath: "/host"}},
+			}},
+			Volumes: []corev1.Volume{{
+				Name: "host-root",
+				VolumeSource: corev1.VolumeSource{
+					HostPath: &corev1.HostPathVolumeSource{Path: "/"},
+				},
+			}},
+			// 调试Pod需要能调度到有污点的节点上
+			Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+		},
+	}
+	// 会话有最长时间时, 即使服务异常退出没有删除调试Pod, 超过期限后Pod也会被终止
+	if maxDuration := global.Config.Terminal.MaxDuration; maxDuration > 0 {
+		deadline := int64((global.Config.Debug.StartTimeout + maxDuration + time.Minute) / time.Second)
+		pod.Spec.ActiveDeadlineSeconds = &deadline
+	}
+
+	pod, err := clientSet.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	cleanup := func() {
+		deleteDebugPod(clusterName, namespace, pod.Name)
+	}
+
+	containerName := pod.Spec.Containers[0].Name
+	if err = waitContainerRunning(ctx, clusterName, namespace, pod.Name, containerName); err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	sessionID, token, err := startTerminal(clusterName, namespace, pod.Name, containerName, debug.Shell, userId, cleanup)
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	return &dto.K8sDebugTerminal{
+		ID:            sessionID,
+		Token:         token,
+		Namespace:     namespace,
+		PodName:       pod.Name,
+		ContainerName: containerName,
+	}, nil
+}
+
+// waitContainerRunning 等待普通容器或临时容器进入运行状态, 镜像拉取失败等无法恢复的状态直接返回错误
+func waitContainerRunning(ctx context.Context, clusterName, namespace, podName, containerName string) error {
+	pods := global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace)
+	var lastReason string
+	err := wait.PollUntilContextTimeout(ctx, time.Second, global.Config.Debug.StartTimeout, true, func(ctx context.Context) (bool, error) {
+		pod, err := pods.Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		if pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
+			return false, fmt.Errorf("调试Pod已经结束: %s", pod.Status.Phase)
+		}
+
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.ContainerStatuses...), pod.Status.EphemeralContainerStatuses...)
+		for _, status := range statuses {
+			if status.Name != containerName {
+				continue
+			}
+			switch {
+			case status.State.Running != nil:
+				return true, nil
+			case status.State.Terminated != nil:
+				return false, fmt.Errorf("调试容器已经退出: %s %s", status.State.Terminated.Reason, status.State.Terminated.Message)
+			case status.State.Waiting != nil:
+				lastReason = status.State.Waiting.Reason
+				switch lastReason {
+				case "ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CreateContainerConfigError", "CreateContainerError":
+					return false, fmt.Errorf("调试容器启动失败: %s %s", lastReason, status.State.Waiting.Message)
+				}
+			}
+		}
+		return false, nil
+	})
+	if err != nil && wait.Interrupted(err) {
+		if lastReason != "" {
+			return fmt.Errorf("等待调试容器启动超时, 当前状态: %s", lastReason)
+		}
+		return errors.New("等待调试容器启动超时")
+	}
+	return err
+}
+
+// deleteDebugPod 删除节点调试Pod, 会话已经结束, 使用新的context
+func deleteDebugPod(clusterName, namespace, podName string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	var gracePeriod int64 = 0
+	err := global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).Delete(ctx, podName, metav1.DeleteOptions{
+		GracePeriodSeconds: &gracePeriod,
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		log.Error("删除节点调试Pod %s/%s 失败: %s", namespace, podName, err.Error())
+	}
+}
+
+// CleanNodeDebugPods 删除所有集群中残留的节点调试Pod. 会话只保存在内存中, 服务启动时存在的调试Pod都是上次运行遗留的
+func (p *Pod) CleanNodeDebugPods() {
+	namespace := global.Config.Debug.NodeNamespace
+	var gracePeriod int64 = 0
+	for _, clusterName := range global.K8s.ListName() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).DeleteCollection(ctx, metav1.DeleteOptions{
+			GracePeriodSeconds: &gracePeriod,
+		}, metav1.ListOptions{LabelSelector: global.K8sNodeDebugLabel})
+		cancel()
+		if err != nil && !k8serrors.IsNotFound(err) {
+			log.Error("清理集群 %s 残留的节点调试Pod失败: %s", clusterName, err.Error())
+		}
+	}
+}
+
+// debugContainerName 生成不与Pod中已有容器重名的临时容器名称
+func debugContainerName(pod *corev1.Pod) string {
+	for {
+		name := "debugger-" + utilrand.String(5)
+		if !hasContainer(pod, name) {
+			return name
+		}
+	}
+}
+
+func hasContainer(pod *corev1.Pod, name string) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == name {
+			return true
+		}
+	}
+	for _, container := range pod.Spec.EphemeralContainers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func debugImage(image string) string {
+	if image = strings.TrimSpace(image); image != "" {
+		return image
+	}
+	return global.Config.Debug.Image
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return strings.TrimRight(s[:n], ".-")
+	}
+	return s
+}
@@ -0,0 +1,659 @@
+package pod
+
+import (
+	"context"
+	"fmt"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+	"soul/apis/dto"
+	"soul/apis/service/k8s"
+	"soul/global"
+	"strings"
+	"time"
+)
+
+// stuckTerminatingAfter Pod处于Terminating超过该时间时认为删除卡住
+const stuckTerminatingAfter = 5 * time.Minute
+
+// diagnoser 诊断一个或多个Pod, 同一次诊断中ConfigMap、Secret、PVC、节点的查询结果会被缓存
+type diagnoser struct {
+	ctx         context.Context
+	clusterName string
+	namespace   string
+
+	events map[string][]corev1.Event // key为Pod名称
+	exists map[string]error          // key为 kind/name, 值为查询的错误, nil表示存在
+	pvcs   map[string]*corev1.PersistentVolumeClaim
+	nodes  map[string]*corev1.Node
+}
+
+func newDiagnoser(ctx context.Context, clusterName, namespace string) *diagnoser {
+	return &diagnoser{
+		ctx:         ctx,
+		clusterName: clusterName,
+		namespace:   namespace,
+		events:      map[string][]corev1.Event{},
+		exists:      map[string]error{},
+		pvcs:        map[string]*corev1.PersistentVolumeClaim{},
+		nodes:       map[string]*corev1.Node{},
+	}
+}
+
+// DiagnosePod 分析Pod无法正常运行的原因, 包括调度、容器状态、退出码、事件、引用的ConfigMap/Secret/PVC和镜像拉取密钥
+func (p *Pod) DiagnosePod(ctx context.Context, clusterName, podName, namespace string) (*dto.K8sPodDiagnosis, error) {
+	pod, err := global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	d := newDiagnoser(ctx, clusterName, namespace)
+	if err = d.loadEvents(fmt.Sprintf("involvedObject.kind=Pod,involvedObject.name=%s", podName)); err != nil {
+		return nil, err
+	}
+	diagnosis := d.diagnosePod(pod)
+	return &diagnosis, nil
+}
+
+// DiagnoseDeployment 诊断Deployment本身的状态和它的所有Pod
+func (p *Pod) DiagnoseDeployment(ctx context.Context, clusterName, name, namespace string) (*dto.K8sDeploymentDiagnosis, error) {
+	clientSet := global.K8s.Use(clusterName).ClientSet
+	deployment, err := clientSet.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := clientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].Name < pods.Items[j].Name
+	})
+
+	d := newDiagnoser(ctx, clusterName, namespace)
+	if err = d.loadEvents("involvedObject.kind=Pod"); err != nil {
+		return nil, err
+	}
+
+	diagnosis := &dto.K8sDeploymentDiagnosis{
+		Name:     name,
+		Findings: diagnoseDeployment(deployment, len(pods.Items)),
+		Pods:     make([]dto.K8sPodDiagnosis, 0, len(pods.Items)),
+	}
+	diagnosis.Healthy = k8s.Healthy(diagnosis.Findings)
+	for i := range pods.Items {
+		podDiagnosis := d.diagnosePod(&pods.Items[i])
+		diagnosis.Healthy = diagnosis.Healthy && podDiagnosis.Healthy
+		diagnosis.Pods = append(diagnosis.Pods, podDiagnosis)
+	}
+	return diagnosis, nil
+}
+
+func diagnoseDeployment(deployment *appsv1.Deployment, pods int) []dto.K8sDiagnosticFinding {
+	findings := make([]dto.K8sDiagnosticFinding, 0)
+	for _, condition := range deployment.Status.Conditions {
+		switch {
+		case condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == corev1.ConditionTrue:
+			findings = append(findings, dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "Deployment",
+				Reason:      condition.Reason,
+				Message:     condition.Message,
+				Explanation: "ReplicaSet无法创建Pod, 常见原因是超出了ResourceQuota、违反了LimitRange或准入控制拒绝了Pod, 请根据信息调整配额或Pod的资源设置",
+			})
+		case condition.Type == appsv1.DeploymentProgressing && condition.Status == corev1.ConditionFalse:
+			findings = append(findings, dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "Deployment",
+				Reason:      condition.Reason,
+				Message:     condition.Message,
+				Explanation: "滚动更新在progressDeadlineSeconds内没有完成, 通常是新版本的Pod无法就绪, 请查看下面Pod的诊断结果, 必要时回滚",
+			})
+		}
+	}
+	if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+		findings = append(findings, dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityInfo,
+			Category:    "Deployment",
+			Reason:      "ScaledToZero",
+			Explanation: "Deployment的副本数为0, 不会创建Pod",
+		})
+	} else if pods == 0 {
+		findings = append(findings, dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityError,
+			Category:    "Deployment",
+			Reason:      "NoPods",
+			Explanation: "没有找到Deployment的Pod, 请检查ReplicaSet的事件",
+		})
+	}
+	return findings
+}
+
+func (d *diagnoser) loadEvents(fieldSelector string) error {
+	events, err := global.K8s.Use(d.clusterName).ClientSet.CoreV1().Events(d.namespace).List(d.ctx, metav1.ListOptions{
+		FieldSelector: fieldSelector,
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(events.Items, func(i, j int) bool {
+		return eventTime(&events.Items[i]).Before(eventTime(&events.Items[j]))
+	})
+	for _, event := range events.Items {
+		d.events[event.InvolvedObject.Name] = append(d.events[event.InvolvedObject.Name], event)
+	}
+	return nil
+}
+
+func (d *diagnoser) diagnosePod(pod *corev1.Pod) dto.K8sPodDiagnosis {
+	var findings []dto.K8sDiagnosticFinding
+	add := func(finding dto.K8sDiagnosticFinding) {
+		findings = append(findings, finding)
+	}
+
+	d.checkPod(pod, add)
+	d.checkScheduling(pod, add)
+	d.checkNode(pod, add)
+	for _, status := range pod.Status.InitContainerStatuses {
+		d.checkContainer(pod, status, true, add)
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		d.checkContainer(pod, status, false, add)
+	}
+	d.checkReferences(pod, add)
+	d.checkEvents(pod, add)
+
+	if findings == nil {
+		findings = make([]dto.K8sDiagnosticFinding, 0)
+	}
+	return dto.K8sPodDiagnosis{
+		PodName:  pod.Name,
+		Status:   k8s.PodStatus(pod),
+		Healthy:  k8s.Healthy(findings),
+		Findings: findings,
+	}
+}
+
+// checkPod Pod整体的状态: 被驱逐、失败、删除卡住
+func (d *diagnoser) checkPod(pod *corev1.Pod, add func(dto.K8sDiagnosticFinding)) {
+	switch {
+	case pod.Status.Reason == "Evicted":
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityWarning,
+			Category:    "Eviction",
+			Reason:      pod.Status.Reason,
+			Message:     pod.Status.Message,
+			Explanation: "Pod因为节点资源压力(内存、磁盘或PID不足)被kubelet驱逐, 控制器会在其他节点重新创建Pod。请为容器设置合理的request, 被驱逐的Pod可以批量删除",
+		})
+	case pod.Status.Phase == corev1.PodFailed:
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityError,
+			Category:    "Pod",
+			Reason:      firstNonEmpty(pod.Status.Reason, string(pod.Status.Phase)),
+			Message:     pod.Status.Message,
+			Explanation: "Pod已经失败且不会重启, 请查看容器的退出原因",
+		})
+	case pod.Status.Phase == corev1.PodUnknown:
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityError,
+			Category:    "Node",
+			Reason:      "Unknown",
+			Message:     pod.Status.Message,
+			Explanation: "无法获取Pod的状态, 通常是Pod所在节点与apiserver失去连接",
+		})
+	}
+
+	if pod.DeletionTimestamp != nil && time.Since(pod.DeletionTimestamp.Time) > stuckTerminatingAfter {
+		explanation := "Pod删除已经超过5分钟仍未完成, 可能是节点失联或容器无法停止, 确认容器已经停止后可以强制删除"
+		if len(pod.Finalizers) > 0 {
+			explanation = fmt.Sprintf("Pod删除已经超过5分钟仍未完成, Pod上有finalizer %s, 需要等待对应的控制器处理, 强制删除也需要先移除finalizer", strings.Join(pod.Finalizers, ", "))
+		}
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityWarning,
+			Category:    "Pod",
+			Reason:      "StuckTerminating",
+			Message:     fmt.Sprintf("deletionTimestamp: %s", pod.DeletionTimestamp.Format(time.RFC3339)),
+			Explanation: explanation,
+		})
+	}
+}
+
+// checkScheduling 根据PodScheduled条件的信息分析调度失败的原因
+func (d *diagnoser) checkScheduling(pod *corev1.Pod, add func(dto.K8sDiagnosticFinding)) {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type != corev1.PodScheduled || condition.Status != corev1.ConditionFalse {
+			continue
+		}
+
+		var reasons []string
+		message := condition.Message
+		if strings.Contains(message, "Insufficient ") {
+			reasons = append(reasons, "节点的可分配资源不足以满足容器的request, 请降低request、清理节点上的Pod或扩容节点")
+		}
+		if strings.Contains(message, "node affinity") || strings.Contains(message, "node selector") {
+			reasons = append(reasons, "没有节点满足Pod的nodeSelector或节点亲和性, 请检查节点标签")
+		}
+		if strings.Contains(message, "taint") {
+			reasons = append(reasons, "节点有Pod不能容忍的污点, 请为Pod添加toleration或去掉节点污点")
+		}
+		if strings.Contains(message, "PersistentVolumeClaim") || strings.Contains(message, "volume node affinity") {
+			reasons = append(reasons, "Pod使用的PVC没有绑定或PV所在的可用区没有可用节点, 请检查PVC和StorageClass")
+		}
+		if strings.Contains(message, "anti-affinity") || strings.Contains(message, "pod affinity") {
+			reasons = append(reasons, "Pod亲和性或反亲和性规则无法满足, 例如反亲和性要求的节点数量多于可用节点")
+		}
+		if strings.Contains(message, "Too many pods") {
+			reasons = append(reasons, "节点上的Pod数量已经达到上限")
+		}
+		if strings.Contains(message, "unschedulable") {
+			reasons = append(reasons, "部分节点被标记为不可调度(cordon)")
+		}
+		if len(reasons) == 0 {
+			reasons = append(reasons, "调度器找不到合适的节点, 请根据信息检查Pod的调度约束")
+		}
+
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityError,
+			Category:    "Scheduling",
+			Reason:      firstNonEmpty(condition.Reason, "Unschedulable"),
+			Message:     message,
+			Explanation: strings.Join(reasons, "; "),
+		})
+	}
+}
+
+// checkNode Pod所在节点是否就绪
+func (d *diagnoser) checkNode(pod *corev1.Pod, add func(dto.K8sDiagnosticFinding)) {
+	if pod.Spec.NodeName == "" {
+		return
+	}
+	node, ok := d.nodes[pod.Spec.NodeName]
+	if !ok {
+		var err error
+		node, err = global.K8s.Use(d.clusterName).ClientSet.CoreV1().Nodes().Get(d.ctx, pod.Spec.NodeName, metav1.GetOptions{})
+		if err != nil {
+			// 没有权限查看节点时不检查节点状态
+			node = nil
+		}
+		d.nodes[pod.Spec.NodeName] = node
+	}
+	if node == nil {
+		return
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue {
+			add(dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "Node",
+				Reason:      "NodeNotReady",
+				Message:     fmt.Sprintf("节点%s: %s", node.Name, condition.Message),
+				Explanation: "Pod所在的节点没有就绪, kubelet可能已经停止或节点失联, 节点恢复前Pod无法正常运行",
+			})
+		}
+	}
+}
+
+// checkContainer 分析容器当前的等待/退出原因和上一次的退出原因
+func (d *diagnoser) checkContainer(pod *corev1.Pod, status corev1.ContainerStatus, init bool, add func(dto.K8sDiagnosticFinding)) {
+	container := status.Name
+	if init {
+		container = "init:" + status.Name
+	}
+
+	if waiting := status.State.Waiting; waiting != nil {
+		switch waiting.Reason {
+		case "ErrImagePull", "ImagePullBackOff":
+			add(dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "Image",
+				Container:   container,
+				Reason:      waiting.Reason,
+				Message:     waiting.Message,
+				Explanation: d.explainImagePull(pod, status.Image, waiting.Message),
+			})
+		case "InvalidImageName":
+			add(dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "Image",
+				Container:   container,
+				Reason:      waiting.Reason,
+				Message:     waiting.Message,
+				Explanation: fmt.Sprintf("镜像名称%q格式不正确", status.Image),
+			})
+		case "CreateContainerConfigError":
+			add(dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "Config",
+				Container:   container,
+				Reason:      waiting.Reason,
+				Message:     waiting.Message,
+				Explanation: "容器配置无法生成, 通常是引用的ConfigMap、Secret或其中的key不存在, 请创建对应的资源或将引用设置为optional",
+			})
+		case "CreateContainerError", "RunContainerError", "StartError":
+			add(dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "Runtime",
+				Container:   container,
+				Reason:      waiting.Reason,
+				Message:     waiting.Message,
+				Explanation: "容器运行时无法创建或启动容器, 常见原因是command不存在、挂载路径冲突或securityContext不被允许",
+			})
+		case "CrashLoopBackOff":
+			add(dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "Crash",
+				Container:   container,
+				Reason:      waiting.Reason,
+				Message:     waiting.Message,
+				Explanation: fmt.Sprintf("容器启动后反复退出, 已重启%d次, kubelet在每次重启之间等待的时间逐渐增加。请查看上一个实例的日志(previous=true)和下面的退出原因", status.RestartCount),
+			})
+		}
+	}
+
+	if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+		add(d.exitFinding(container, terminated, "容器已经退出"))
+	}
+
+	// 正在运行的容器上一次退出的原因, CrashLoopBackOff时这是最有用的信息
+	if last := status.LastTerminationState.Terminated; last != nil && (last.ExitCode != 0 || last.Reason == "OOMKilled") {
+		finding := d.exitFinding(container, last, "上一个容器实例退出")
+		if status.State.Running != nil && status.Ready {
+			finding.Severity = k8s.SeverityInfo
+		}
+		add(finding)
+	}
+
+	if status.State.Running != nil && !status.Ready && !init && pod.DeletionTimestamp == nil {
+		started := status.State.Running.StartedAt.Time
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityWarning,
+			Category:    "Probe",
+			Container:   container,
+			Reason:      "NotReady",
+			Message:     fmt.Sprintf("容器从%s开始运行, 但没有就绪", started.Format(time.RFC3339)),
+			Explanation: "容器正在运行但readinessProbe或startupProbe没有通过, Service不会将流量转发到该Pod。请检查探针的路径、端口和超时时间, 以及应用是否启动完成",
+		})
+	}
+}
+
+// exitFinding 根据退出码和原因解释容器退出的原因
+func (d *diagnoser) exitFinding(container string, terminated *corev1.ContainerStateTerminated, prefix string) dto.K8sDiagnosticFinding {
+	var explanation string
+	switch {
+	case terminated.Reason == "OOMKilled":
+		explanation = "容器使用的内存超过了memory limit, 被内核OOM Killer终止。请提高memory limit或排查应用的内存使用"
+	case terminated.ExitCode == 137:
+		explanation = "容器被SIGKILL终止(137), 可能是节点内存不足、livenessProbe失败后没有在宽限期内停止, 或被手动kill"
+	case terminated.ExitCode == 143:
+		explanation = "容器收到SIGTERM后退出(143), 通常是livenessProbe失败被kubelet重启, 或Pod被删除"
+	case terminated.ExitCode == 126:
+		explanation = "command无法执行(126), 请检查文件的执行权限"
+	case terminated.ExitCode == 127:
+		explanation = "command不存在(127), 请检查镜像中是否有该命令以及command/args的拼写"
+	case terminated.ExitCode == 128 || terminated.Reason == "ContainerCannotRun":
+		explanation = "容器无法启动, 请根据信息检查command和镜像"
+	case terminated.ExitCode == 0 && terminated.Reason == "Completed":
+		explanation = "容器正常退出, 对于Deployment中的长期运行容器, 主进程不应该退出"
+	default:
+		explanation = fmt.Sprintf("应用以退出码%d退出, 通常是应用自身的错误, 请查看上一个实例的日志", terminated.ExitCode)
+	}
+
+	return dto.K8sDiagnosticFinding{
+		Severity:    k8s.SeverityError,
+		Category:    "Crash",
+		Container:   container,
+		Reason:      firstNonEmpty(terminated.Reason, "Error"),
+		Message:     strings.TrimSpace(fmt.Sprintf("%s, 退出码%d, 时间%s %s", prefix, terminated.ExitCode, terminated.FinishedAt.Format(time.RFC3339), terminated.Message)),
+		Explanation: explanation,
+	}
+}
+
+// explainImagePull 根据拉取失败的信息和imagePullSecrets判断镜像拉取失败的原因
+func (d *diagnoser) explainImagePull(pod *corev1.Pod, image, message string) string {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "not found") || strings.Contains(lower, "manifest unknown"):
+		return fmt.Sprintf("镜像%q或其tag不存在, 请检查镜像名称和tag", image)
+	case strings.Contains(lower, "unauthorized") || strings.Contains(lower, "authentication required") ||
+		strings.Contains(lower, "pull access denied") || strings.Contains(lower, "forbidden"):
+		if len(pod.Spec.ImagePullSecrets) == 0 {
+			return "镜像仓库需要认证, 但Pod没有配置imagePullSecrets, ServiceAccount上也可能没有, 请创建docker-registry类型的Secret并添加到imagePullSecrets"
+		}
+		return "镜像仓库认证失败, 请检查imagePullSecrets中的用户名、密码和仓库地址是否正确"
+	case strings.Contains(lower, "timeout") || strings.Contains(lower, "no such host") || strings.Contains(lower, "connection refused") ||
+		strings.Contains(lower, "i/o timeout"):
+		return "节点无法连接镜像仓库, 请检查节点的网络、DNS和代理设置"
+	case strings.Contains(lower, "toomanyrequests") || strings.Contains(lower, "rate limit"):
+		return "镜像仓库限制了拉取频率, 请稍后重试或使用镜像缓存"
+	}
+	return "镜像拉取失败, 请根据信息检查镜像名称、仓库地址和网络"
+}
+
+// checkReferences 检查Pod引用的ConfigMap、Secret、PVC和imagePullSecrets是否存在
+func (d *diagnoser) checkReferences(pod *corev1.Pod, add func(dto.K8sDiagnosticFinding)) {
+	missing := func(kind, name, usage string) {
+		err := d.exist(kind, name)
+		if err == nil {
+			return
+		}
+		finding := dto.K8sDiagnosticFinding{
+			Severity: k8s.SeverityError,
+			Category: "Config",
+			Reason:   kind + "NotFound",
+			Message:  err.Error(),
+		}
+		if !k8serrors.IsNotFound(err) {
+			finding.Severity = k8s.SeverityInfo
+			finding.Reason = "CheckFailed"
+			finding.Explanation = fmt.Sprintf("无法检查%s %s是否存在", kind, name)
+			add(finding)
+			return
+		}
+		finding.Explanation = fmt.Sprintf("%s引用的%s %q不存在, 请在namespace %s中创建", usage, kind, name, pod.Namespace)
+		if kind == "Secret" && usage == "imagePullSecrets" {
+			finding.Category = "Image"
+			finding.Severity = k8s.SeverityWarning
+			finding.Explanation = fmt.Sprintf("imagePullSecrets中的Secret %q不存在, 拉取私有镜像时会认证失败", name)
+		}
+		add(finding)
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		switch {
+		case volume.ConfigMap != nil && !isTrue(volume.ConfigMap.Optional):
+			missing("ConfigMap", volume.ConfigMap.Name, "volume "+volume.Name)
+		case volume.Secret != nil && !isTrue(volume.Secret.Optional):
+			missing("Secret", volume.Secret.SecretName, "volume "+volume.Name)
+		case volume.PersistentVolumeClaim != nil:
+			d.checkPVC(volume.Name, volume.PersistentVolumeClaim.ClaimName, add)
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil && !isTrue(source.ConfigMap.Optional) {
+					missing("ConfigMap", source.ConfigMap.Name, "volume "+volume.Name)
+				}
+				if source.Secret != nil && !isTrue(source.Secret.Optional) {
+					missing("Secret", source.Secret.Name, "volume "+volume.Name)
+				}
+			}
+		}
+	}
+
+	for _, container := range append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...) {
+		usage := "容器" + container.Name
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil && !isTrue(envFrom.ConfigMapRef.Optional) {
+				missing("ConfigMap", envFrom.ConfigMapRef.Name, usage)
+			}
+			if envFrom.SecretRef != nil && !isTrue(envFrom.SecretRef.Optional) {
+				missing("Secret", envFrom.SecretRef.Name, usage)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil && !isTrue(ref.Optional) {
+				missing("ConfigMap", ref.Name, usage+"的环境变量"+env.Name)
+			}
+			if ref := env.ValueFrom.SecretKeyRef; ref != nil && !isTrue(ref.Optional) {
+				missing("Secret", ref.Name, usage+"的环境变量"+env.Name)
+			}
+		}
+	}
+
+	for _, secret := range pod.Spec.ImagePullSecrets {
+		missing("Secret", secret.Name, "imagePullSecrets")
+	}
+}
+
+// checkPVC PVC是否存在且已经绑定
+func (d *diagnoser) checkPVC(volumeName, claimName string, add func(dto.K8sDiagnosticFinding)) {
+	pvc, ok := d.pvcs[claimName]
+	if !ok {
+		var err error
+		pvc, err = global.K8s.Use(d.clusterName).ClientSet.CoreV1().PersistentVolumeClaims(d.namespace).Get(d.ctx, claimName, metav1.GetOptions{})
+		if err != nil {
+			pvc = nil
+			if k8serrors.IsNotFound(err) {
+				add(dto.K8sDiagnosticFinding{
+					Severity:    k8s.SeverityError,
+					Category:    "Storage",
+					Reason:      "PersistentVolumeClaimNotFound",
+					Message:     err.Error(),
+					Explanation: fmt.Sprintf("volume %s引用的PVC %q不存在, Pod无法调度", volumeName, claimName),
+				})
+			}
+			return
+		}
+		d.pvcs[claimName] = pvc
+	}
+	if pvc == nil || pvc.Status.Phase == corev1.ClaimBound {
+		return
+	}
+
+	explanation := "PVC还没有绑定到PV"
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		explanation += ", PVC没有指定StorageClass且集群可能没有默认StorageClass, 需要手动创建匹配的PV"
+	} else {
+		explanation += fmt.Sprintf(", 请检查StorageClass %s的provisioner是否正常, 使用WaitForFirstConsumer时PVC会在Pod调度后才绑定", *pvc.Spec.StorageClassName)
+	}
+	add(dto.K8sDiagnosticFinding{
+		Severity:    k8s.SeverityWarning,
+		Category:    "Storage",
+		Reason:      "PersistentVolumeClaim" + string(pvc.Status.Phase),
+		Message:     fmt.Sprintf("PVC %s的状态为%s", claimName, pvc.Status.Phase),
+		Explanation: explanation,
+	})
+}
+
+// eventExplanations Warning事件的解释, 调度失败、镜像拉取和重启由状态分析覆盖, 不重复报告
+var eventExplanations = map[string]string{
+	"FailedMount":            "存储卷挂载失败, 请检查PVC、ConfigMap、Secret是否存在, 以及存储后端是否正常",
+	"FailedAttachVolume":     "存储卷无法挂载到节点, 可能被其他节点占用(ReadWriteOnce)或存储后端异常",
+	"FailedCreatePodSandBox": "无法创建Pod的网络沙箱, 通常是节点的CNI插件异常或IP地址耗尽",
+	"Unhealthy":              "探针检查失败, livenessProbe失败会导致容器重启, readinessProbe失败会导致Pod不接收流量",
+	"FailedPostStartHook":    "postStart钩子执行失败, 容器会被终止",
+	"FailedPreStopHook":      "preStop钩子执行失败",
+	"NetworkNotReady":        "节点网络没有就绪, 请检查CNI插件",
+	"FailedKillPod":          "kubelet无法停止Pod, 容器运行时可能异常",
+	"Evicted":                "Pod因为节点资源压力被驱逐",
+	"Preempting":             "Pod被更高优先级的Pod抢占",
+}
+
+var eventsCoveredByStatus = map[string]bool{
+	"FailedScheduling":  true,
+	"Failed":            true,
+	"BackOff":           true,
+	"ErrImageNeverPull": true,
+	"InspectFailed":     true,
+}
+
+// checkEvents 报告Pod最近的Warning事件, 同一原因只报告最后一次
+func (d *diagnoser) checkEvents(pod *corev1.Pod, add func(dto.K8sDiagnosticFinding)) {
+	latest := map[string]corev1.Event{}
+	var reasons []string
+	for _, event := range d.events[pod.Name] {
+		if event.Type != corev1.EventTypeWarning || eventsCoveredByStatus[event.Reason] {
+			continue
+		}
+		if event.InvolvedObject.UID != "" && event.InvolvedObject.UID != pod.UID {
+			continue
+		}
+		if _, ok := latest[event.Reason]; !ok {
+			reasons = append(reasons, event.Reason)
+		}
+		latest[event.Reason] = event
+	}
+
+	for _, reason := range reasons {
+		event := latest[reason]
+		explanation, ok := eventExplanations[reason]
+		if !ok {
+			explanation = "Kubernetes报告了Warning事件, 请根据信息排查"
+		}
+		severity := k8s.SeverityWarning
+		// 就绪后的探针失败已经过去, 不影响当前状态
+		if reason == "Unhealthy" && isPodReady(pod) {
+			severity = k8s.SeverityInfo
+		}
+		add(dto.K8sDiagnosticFinding{
+			Severity:    severity,
+			Category:    "Event",
+			Reason:      reason,
+			Message:     fmt.Sprintf("%s (%d次, 最近一次%s)", strings.TrimSpace(event.Message), maxInt32(event.Count, 1), eventTime(&event).Format(time.RFC3339)),
+			Explanation: explanation,
+		})
+	}
+}
+
+// exist 检查ConfigMap或Secret是否存在, 结果在同一次诊断中缓存
+func (d *diagnoser) exist(kind, name string) error {
+	key := kind + "/" + name
+	if err, ok := d.exists[key]; ok {
+		return err
+	}
+
+	var err error
+	clientSet := global.K8s.Use(d.clusterName).ClientSet
+	switch kind {
+	case "ConfigMap":
+		_, err = clientSet.CoreV1().ConfigMaps(d.namespace).Get(d.ctx, name, metav1.GetOptions{})
+	case "Secret":
+		_, err = clientSet.CoreV1().Secrets(d.namespace).Get(d.ctx, name, metav1.GetOptions{})
+	}
+	d.exists[key] = err
+	return err
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func maxInt32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,119 @@
+package pod
+
+import (
+	"context"
+	"errors"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
+	"soul/apis/dto"
+	"soul/apis/service/k8s"
+	"soul/global"
+	log "soul/internal/logger"
+	"sync"
+)
+
+// bulkConcurrency 批量操作时同时处理的Pod数量
+const bulkConcurrency = 10
+
+// EvictPodByName 通过policy/v1 Eviction API驱逐Pod, 会遵守PodDisruptionBudget
+func (p *Pod) EvictPodByName(ctx context.Context, clusterName, podName, namespace string) error {
+	err := global.K8s.Use(clusterName).ClientSet.PolicyV1().Evictions(namespace).Evict(ctx, &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: namespace,
+		},
+	})
+	if k8serrors.IsTooManyRequests(err) {
+		// PDB不允许驱逐时apiserver返回429, 原因在message中
+		return errors.New("驱逐被PodDisruptionBudget阻止: " + err.Error())
+	}
+	return err
+}
+
+// ForceDeletePodByName 以宽限期0强制删除Pod, 用于节点失联等原因一直处于Terminating的Pod。
+// 不会等待kubelet确认容器已经停止, 有finalizer的Pod仍然需要等待finalizer移除
+func (p *Pod) ForceDeletePodByName(ctx context.Context, clusterName, podName, namespace string) error {
+	var gracePeriod int64 = 0
+	return global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).Delete(ctx, podName, metav1.DeleteOptions{
+		GracePeriodSeconds: &gracePeriod,
+	})
+}
+
+// BulkPodOperation 按标签选择器和状态批量删除、强制删除或驱逐namespace下的Pod, 返回每个Pod的操作结果
+func (p *Pod) BulkPodOperation(ctx context.Context, clusterName, namespace string, operation dto.K8sPodBulkOperation, userId uint) (*dto.K8sPodBulkResult, error) {
+	pods, err := global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: operation.LabelSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []corev1.Pod
+	for i := range pods.Items {
+		if operation.Status == "" || k8s.PodStatus(&pods.Items[i]) == operation.Status {
+			matched = append(matched, pods.Items[i])
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].Name < matched[j].Name
+	})
+
+	result := &dto.K8sPodBulkResult{
+		Total:  len(matched),
+		DryRun: operation.DryRun,
+		Items:  make([]dto.K8sPodOperationResult, len(matched)),
+	}
+	for i := range matched {
+		result.Items[i] = dto.K8sPodOperationResult{PodName: matched[i].Name, Status: k8s.PodStatus(&matched[i])}
+	}
+	if operation.DryRun || len(matched) == 0 {
+		return result, nil
+	}
+
+	log.Info("用户%d批量%s %s/%s 中的%d个Pod, labelSelector: %q, status: %q", userId, operation.Action, clusterName, namespace, len(matched), operation.LabelSelector, operation.Status)
+
+	action := p.podAction(operation.Action)
+	sem := make(chan struct{}, bulkConcurrency)
+	var wg sync.WaitGroup
+	for i := range result.Items {
+		wg.Add(1)
+		go func(item *dto.K8sPodOperationResult) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			err := action(ctx, clusterName, item.PodName, namespace)
+			// 已经被删除的Pod视为成功
+			if err != nil && !k8serrors.IsNotFound(err) {
+				item.Error = err.Error()
+				return
+			}
+			item.Success = true
+		}(&result.Items[i])
+	}
+	wg.Wait()
+
+	for _, item := range result.Items {
+		if item.Success {
+			result.Succeeded++
+		} else {
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+func (p *Pod) podAction(action string) func(ctx context.Context, clusterName, podName, namespace string) error {
+	switch action {
+	case "evict":
+		return p.EvictPodByName
+	case "forceDelete":
+		return p.ForceDeletePodByName
+	}
+	return func(ctx context.Context, clusterName, podName, namespace string) error {
+		return global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).Delete(ctx, podName, metav1.DeleteOptions{})
+	}
+}
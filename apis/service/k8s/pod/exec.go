@@ -0,0 +1,201 @@
+package pod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilexec "k8s.io/client-go/util/exec"
+	"sort"
+	"soul/apis/dto"
+	"soul/global"
+	log "soul/internal/logger"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cappedBuffer 只保留前limit字节, 超过的部分丢弃但仍然返回成功, 避免命令因为写入失败而阻塞。
+// 超时返回时remotecommand可能仍在写入, 需要加锁
+type cappedBuffer struct {
+	mu        sync.Mutex
+	buf       []byte
+	limit     int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if remain := b.limit - len(b.buf); remain < len(p) {
+		b.truncated = true
+		if remain > 0 {
+			b.buf = append(b.buf, p[:remain]...)
+		}
+		return len(p), nil
+	}
+	b.buf = append(b.buf, p...)
+	return len(p), nil
+}
+
+func (b *cappedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.buf)
+}
+
+func (b *cappedBuffer) Truncated() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.truncated
+}
+
+// execTimeout 请求中的超时时间, 单位秒, 为0时使用默认值
+func execTimeout(seconds int) (time.Duration, error) {
+	if seconds == 0 {
+		return global.Config.Exec.DefaultTimeout, nil
+	}
+	timeout := time.Duration(seconds) * time.Second
+	if max := global.Config.Exec.MaxTimeout; max > 0 && timeout > max {
+		return 0, fmt.Errorf("timeout不能超过%s", max)
+	}
+	return timeout, nil
+}
+
+// ExecPodCommand 在容器中执行非交互命令并返回输出和退出码。命令执行失败、退出码非0或超时都通过结果返回,
+// 只有Pod或容器不存在等无法开始执行的情况返回error
+func (p *Pod) ExecPodCommand(ctx context.Context, clusterName, namespace, podName string, exec dto.K8sPodExec, userId uint) (*dto.K8sPodExecResult, error) {
+	timeout, err := execTimeout(exec.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	containerName, err := p.containerOrDefault(clusterName, podName, namespace, exec.Container)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("用户%d在 %s/%s/%s/%s 中执行命令: %q", userId, clusterName, namespace, podName, containerName, exec.Command)
+	result := runCommand(ctx, clusterName, namespace, podName, containerName, exec, timeout)
+	return &result, nil
+}
+
+// ExecDeploymentCommand 在Deployment的所有Pod中并行执行同一个命令, 未运行的Pod不执行, 记为失败
+func (p *Pod) ExecDeploymentCommand(ctx context.Context, clusterName, namespace, deploymentName string, exec dto.K8sPodExec, userId uint) (*dto.K8sBatchExecResult, error) {
+	timeout, err := execTimeout(exec.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	selector, err := resolveSelector(clusterName, namespace, "deployment", deploymentName, "")
+	if err != nil {
+		return nil, err
+	}
+	pods, err := global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pods.Items) == 0 {
+		return nil, errors.New("没有匹配的Pod")
+	}
+
+	log.Info("用户%d在 %s/%s/deployment/%s 的%d个Pod中执行命令: %q", userId, clusterName, namespace, deploymentName, len(pods.Items), exec.Command)
+
+	concurrency := global.Config.Exec.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+	results := make([]dto.K8sPodExecResult, len(pods.Items))
+	var wg sync.WaitGroup
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		containerName := exec.Container
+		if containerName == "" {
+			containerName = pod.Spec.Containers[0].Name
+		}
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+			results[i] = dto.K8sPodExecResult{
+				PodName:   pod.Name,
+				Container: containerName,
+				ExitCode:  -1,
+				Error:     fmt.Sprintf("Pod状态为%s, 没有执行", podPhase(pod)),
+			}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, podName, containerName string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				results[i] = dto.K8sPodExecResult{PodName: podName, Container: containerName, ExitCode: -1, Error: "请求已取消"}
+				return
+			}
+			results[i] = runCommand(ctx, clusterName, namespace, podName, containerName, exec, timeout)
+		}(i, pod.Name, containerName)
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].PodName < results[j].PodName
+	})
+	batch := &dto.K8sBatchExecResult{Total: len(results), Items: results}
+	for _, result := range results {
+		if result.ExitCode == 0 {
+			batch.Succeeded++
+		} else {
+			batch.Failed++
+		}
+	}
+	return batch, nil
+}
+
+// runCommand 执行命令, 将执行结果和错误都转换为PodExecResult
+func runCommand(ctx context.Context, clusterName, namespace, podName, containerName string, exec dto.K8sPodExec, timeout time.Duration) dto.K8sPodExecResult {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	limit := int(global.Config.Exec.MaxOutputSize << 10)
+	stdout, stderr := &cappedBuffer{limit: limit}, &cappedBuffer{limit: limit}
+	var stdin io.Reader
+	if exec.Stdin != "" {
+		stdin = strings.NewReader(exec.Stdin)
+	}
+
+	start := time.Now()
+	err := execCommand(ctx, clusterName, namespace, podName, containerName, exec.Command, stdin, stdout, stderr)
+
+	result := dto.K8sPodExecResult{
+		PodName:   podName,
+		Container: containerName,
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Truncated: stdout.Truncated() || stderr.Truncated(),
+		Duration:  time.Since(start).Milliseconds(),
+	}
+	var exitErr utilexec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitStatus()
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		result.ExitCode = -1
+		result.Error = fmt.Sprintf("执行超时(%s)", timeout)
+	default:
+		result.ExitCode = -1
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func podPhase(pod *corev1.Pod) string {
+	if pod.DeletionTimestamp != nil {
+		return "Terminating"
+	}
+	return string(pod.Status.Phase)
+}
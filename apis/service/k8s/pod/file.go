@@ -0,0 +1,279 @@
+package pod
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+	"path"
+	"soul/apis/dto"
+	"soul/global"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrTarNotFound  = errors.New("容器中没有tar命令, 无法传输文件")
+	ErrFileTooLarge = errors.New("文件大小超过限制")
+)
+
+// execCommand 在容器中执行非交互命令, 与startProcess使用相同的SPDY exec, 但不分配TTY, stdout和stderr分开
+func execCommand(ctx context.Context, clusterName, namespace, podName, containerName string, cmd []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	req := global.K8s.Use(clusterName).ClientSet.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(podName).
+		Namespace(namespace).
+		SubResource("exec")
+
+	req.VersionedParams(&corev1.PodExecOptions{
+		Container: containerName,
+		Command:   cmd,
+		Stdin:     stdin != nil,
+		Stdout:    stdout != nil,
+		Stderr:    stderr != nil,
+		TTY:       false,
+	}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(global.K8s.Use(clusterName).Config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	return exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
+
+// commandError 将命令执行失败转换为可读的错误, 126/127或找不到可执行文件时认为命令不存在
+func commandError(err error, stderr string, notFound error) error {
+	stderr = strings.TrimSpace(stderr)
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) && (exitErr.ExitStatus() == 126 || exitErr.ExitStatus() == 127) {
+		return notFound
+	}
+	if strings.Contains(err.Error(), "executable file not found") || strings.Contains(stderr, "executable file not found") {
+		return notFound
+	}
+	if stderr != "" {
+		return errors.New(stderr)
+	}
+	return err
+}
+
+// containerOrDefault containerName为空时返回第1个容器
+func (p *Pod) containerOrDefault(clusterName, podName, namespace, containerName string) (string, error) {
+	if containerName != "" {
+		return containerName, nil
+	}
+	containers, err := p.GetPodContainers(clusterName, podName, namespace)
+	if err != nil {
+		return "", err
+	}
+	return containers[0].Name, nil
+}
+
+// ListPodFiles 列出容器中的目录, 通过ls -lA获取, 兼容coreutils和busybox的输出格式
+func (p *Pod) ListPodFiles(ctx context.Context, clusterName, podName, namespace string, query dto.K8sPodFileQuery) ([]dto.K8sPodFile, error) {
+	containerName, err := p.containerOrDefault(clusterName, podName, namespace, query.Container)
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	cmd := []string{"env", "LC_ALL=C", "ls", "-lA", query.Path + "/"}
+	if err = execCommand(ctx, clusterName, namespace, podName, containerName, cmd, nil, stdout, stderr); err != nil {
+		return nil, commandError(err, stderr.String(), errors.New("容器中没有ls命令, 无法列出目录"))
+	}
+
+	files := make([]dto.K8sPodFile, 0)
+	for _, line := range strings.Split(stdout.String(), "\n") {
+		if file, ok := parseLsLine(line); ok {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// parseLsLine 解析 ls -l 的一行: mode links owner group size month day time|year name
+func parseLsLine(line string) (dto.K8sPodFile, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 9 || strings.HasPrefix(line, "total") {
+		return dto.K8sPodFile{}, false
+	}
+
+	// 设备文件的大小列是 "major, minor", 多占一列
+	skip := 8
+	if strings.HasSuffix(fields[4], ",") {
+		if len(fields) < 10 {
+			return dto.K8sPodFile{}, false
+		}
+		fields = append(fields[:4], fields[5:]...)
+		fields[4] = "0"
+		skip = 9
+	}
+
+	file := dto.K8sPodFile{
+		Mode:    fields[0],
+		IsDir:   strings.HasPrefix(fields[0], "d"),
+		Owner:   fields[2],
+		Group:   fields[3],
+		ModTime: strings.Join(fields[5:8], " "),
+	}
+	file.Size, _ = strconv.ParseInt(fields[4], 10, 64)
+
+	// 文件名可能包含空格, 取时间之后的原始内容
+	name := line
+	for i := 0; i < skip; i++ {
+		name = strings.TrimLeft(name, " ")
+		name = name[strings.IndexByte(name, ' ')+1:]
+	}
+	name = strings.TrimLeft(name, " ")
+	if strings.HasPrefix(file.Mode, "l") {
+		if index := strings.Index(name, " -> "); index >= 0 {
+			file.Link = name[index+4:]
+			name = name[:index]
+		}
+	}
+	file.Name = name
+	return file, true
+}
+
+// limitWriter 写入超过limit字节后返回ErrFileTooLarge并调用cancel中断exec,
+// remotecommand只记录stdout的写入错误, 不中断的话容器中的命令会阻塞
+type limitWriter struct {
+	w        io.Writer
+	limit    int64
+	written  int64
+	exceeded atomic.Bool
+	cancel   context.CancelFunc
+}
+
+func (l *limitWriter) Write(p []byte) (int, error) {
+	if l.written+int64(len(p)) > l.limit {
+		l.exceeded.Store(true)
+		l.cancel()
+		return 0, ErrFileTooLarge
+	}
+	n, err := l.w.Write(p)
+	l.written += int64(n)
+	return n, err
+}
+
+// limitReader 读取超过limit字节后返回ErrFileTooLarge, exceeded记录是否超过限制
+type limitReader struct {
+	r        io.Reader
+	limit    int64
+	read     int64
+	exceeded atomic.Bool
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		l.exceeded.Store(true)
+		return 0, ErrFileTooLarge
+	}
+	return n, err
+}
+
+// DownloadPodFile 将容器中的文件或目录以tar格式写入w, 边读边写不在内存中缓存。
+// 写入第一个字节前出错时w没有被写入, 调用方可以返回错误响应
+func (p *Pod) DownloadPodFile(ctx context.Context, clusterName, podName, namespace string, query dto.K8sPodFileQuery, w io.Writer) error {
+	containerName, err := p.containerOrDefault(clusterName, podName, namespace, query.Container)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	dir, base := path.Split(path.Clean(query.Path))
+	if base == "" {
+		dir, base = "/", "."
+	}
+	stderr := new(bytes.Buffer)
+	stdout := &limitWriter{w: w, limit: global.Config.PodFile.MaxDownloadSize << 20, cancel: cancel}
+	// base以-开头时会被tar当作选项, 使用--结束选项
+	cmd := []string{"tar", "cf", "-", "-C", dir, "--", base}
+	err = execCommand(ctx, clusterName, namespace, podName, containerName, cmd, nil, stdout, stderr)
+	if stdout.exceeded.Load() {
+		return fmt.Errorf("%w: 最大%dM", ErrFileTooLarge, global.Config.PodFile.MaxDownloadSize)
+	}
+	if err != nil {
+		return commandError(err, stderr.String(), ErrTarNotFound)
+	}
+	return nil
+}
+
+// UploadPodFile 上传文件到容器的目录中, 通过tar xf解压。
+// archive为true时reader本身是tar包; 否则reader是单个文件的内容, 大小为size, 边读边打包成tar
+func (p *Pod) UploadPodFile(ctx context.Context, clusterName, podName, namespace string, upload dto.K8sPodFileUpload, reader io.Reader, size int64) error {
+	containerName, err := p.containerOrDefault(clusterName, podName, namespace, upload.Container)
+	if err != nil {
+		return err
+	}
+
+	maxSize := global.Config.PodFile.MaxUploadSize << 20
+	if size > maxSize {
+		return fmt.Errorf("%w: 最大%dM", ErrFileTooLarge, global.Config.PodFile.MaxUploadSize)
+	}
+	limited := &limitReader{r: reader, limit: maxSize}
+
+	var stdin io.Reader = limited
+	if !upload.Archive {
+		if size < 0 {
+			return errors.New("上传单个文件时必须指定Content-Length")
+		}
+		// .和..会让tar覆盖目标目录本身或写到上一级目录
+		if upload.FileName == "." || upload.FileName == ".." {
+			return errors.New("fileName不能是.或..")
+		}
+		pr, pw := io.Pipe()
+		defer pr.Close()
+		go func() {
+			pw.CloseWithError(writeTarFile(pw, upload.FileName, limited, size))
+		}()
+		stdin = pr
+	}
+
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	cmd := []string{"tar", "xmf", "-", "-C", path.Clean(upload.Path)}
+	err = execCommand(ctx, clusterName, namespace, podName, containerName, cmd, stdin, stdout, stderr)
+	if limited.exceeded.Load() {
+		// stdin提前结束时tar可能报错也可能正常退出, 都视为上传失败
+		return fmt.Errorf("%w: 最大%dM, 已解压的部分文件可能残留在容器中", ErrFileTooLarge, global.Config.PodFile.MaxUploadSize)
+	}
+	if err != nil {
+		return commandError(err, stderr.String()+stdout.String(), ErrTarNotFound)
+	}
+	return nil
+}
+
+// writeTarFile 将单个文件打包为只有一个文件的tar
+func writeTarFile(w io.Writer, name string, reader io.Reader, size int64) error {
+	tw := tar.NewWriter(w)
+	err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    size,
+		ModTime: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if _, err = io.CopyN(tw, reader, size); err != nil {
+		return err
+	}
+	return tw.Close()
+}
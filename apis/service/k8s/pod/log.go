@@ -0,0 +1,83 @@
+package pod
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"soul/apis/dto"
+	"soul/global"
+	"soul/utils/httputil"
+	"strings"
+)
+
+// logBufferLines 日志行缓冲数量, 缓冲满时停止读取上游, 由TCP流控限制kubelet的发送速度
+const logBufferLines = 64
+
+// StreamPodLog 读取Pod日志流并按行发送, ctx取消时关闭上游日志流。
+// 读取出错时发送一个error事件, 日志读取完毕(非follow模式或容器退出)时关闭channel
+func (p *Pod) StreamPodLog(ctx context.Context, clusterName, podName, namespace string, query dto.K8sPodLogQuery) (<-chan httputil.Event, error) {
+	stream, _, err := p.OpenPodLog(ctx, clusterName, podName, namespace, query)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan httputil.Event, logBufferLines)
+	go func() {
+		defer close(events)
+		defer stream.Close()
+
+		readLines(ctx, stream, func(line string) bool {
+			select {
+			case events <- httputil.Event{Name: "log", Data: line}:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}, func(err error) {
+			select {
+			case events <- httputil.Event{Name: "error", Data: err.Error()}:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	return events, nil
+}
+
+// OpenPodLog 打开Pod日志流, 返回实际读取的容器名, 调用方负责关闭日志流
+func (p *Pod) OpenPodLog(ctx context.Context, clusterName, podName, namespace string, query dto.K8sPodLogQuery) (io.ReadCloser, string, error) {
+	option, err := query.ToPodLogOptions()
+	if err != nil {
+		return nil, "", err
+	}
+	if option.Container == "" {
+		pod, err := p.GetPodByName(clusterName, podName, namespace)
+		if err != nil {
+			return nil, "", err
+		}
+		option.Container = pod.Spec.Containers[0].Name
+	}
+
+	stream, err := global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).GetLogs(podName, option).Stream(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return stream, option.Container, nil
+}
+
+// readLines 按行读取日志, send返回false时停止读取; ctx取消导致的读取错误不会回调onError
+func readLines(ctx context.Context, reader io.Reader, send func(line string) bool, onError func(err error)) {
+	buf := bufio.NewReader(reader)
+	for {
+		line, err := buf.ReadString('\n')
+		if len(line) > 0 && !send(strings.TrimRight(line, "\r\n")) {
+			return
+		}
+		if err != nil {
+			if err != io.EOF && ctx.Err() == nil {
+				onError(err)
+			}
+			return
+		}
+	}
+}
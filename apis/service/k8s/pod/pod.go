@@ -3,13 +3,20 @@ package pod
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/remotecommand"
+	"soul/apis/dao"
+	"soul/apis/dto"
 	"soul/apis/service/k8s"
 	"soul/global"
+	log "soul/internal/logger"
+	"soul/model"
 	"soul/utils/httputil"
+	"sync/atomic"
+	"time"
 )
 
 type Pod struct{}
@@ -40,6 +47,19 @@ func (p *Pod) GetPodByName(clusterName, name, namespace string) (*corev1.Pod, er
 	return pod, nil
 }
 
+// GetPodDetail 获取Pod和各容器的资源使用量, metrics-server不可用时metrics为空
+func (p *Pod) GetPodDetail(ctx context.Context, clusterName, name, namespace string) (*dto.K8sPodWithMetrics, error) {
+	pod, err := global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &dto.K8sPodWithMetrics{
+		Pod:     *pod,
+		Metrics: k8s.GetPodMetrics(ctx, clusterName, pod),
+	}, nil
+}
+
+// GetPodList Pod列表, 每个Pod带有资源使用量, metrics-server不可用时metrics为空
 func (p *Pod) GetPodList(clusterName, filterName, namespace string, limit, page int) (*httputil.PageResp, error) {
 	pods, err := global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
@@ -61,11 +81,17 @@ func (p *Pod) GetPodList(clusterName, filterName, namespace string, limit, page
 	total := len(selectableData.Filter().GenericDataList)
 	data := selectableData.Sort().Paginate()
 
+	metrics := k8s.ListPodMetrics(context.TODO(), clusterName, namespace, "")
+	items := make([]dto.K8sPodWithMetrics, 0, len(data.GenericDataList))
+	for _, pod := range p.fromCells(data.GenericDataList) {
+		items = append(items, dto.K8sPodWithMetrics{Pod: pod, Metrics: metrics.Of(&pod)})
+	}
+
 	return &httputil.PageResp{
 		Limit: limit,
 		Page:  page,
 		Total: total,
-		Items: data.GenericDataList,
+		Items: items,
 	}, nil
 }
 
@@ -122,20 +148,101 @@ func (p *Pod) GetPodContainers(clusterName, podName, namespace string) (containe
 	return pod.Spec.Containers, nil
 }
 
-func (p *Pod) StartTerminal(clusterName, namespace, podName, containerName, shell string) (string, error) {
+// StartTerminal 创建终端会话并开始录像, 录像失败时不允许打开终端。
+// 返回会话ID和一次性bind token, 前端建立SockJS连接后需要在bindTimeout内带上token完成bind
+func (p *Pod) StartTerminal(clusterName, namespace, podName, containerName, shell string, userId uint) (string, string, error) {
+	return startTerminal(clusterName, namespace, podName, containerName, shell, userId, nil)
+}
+
+// startTerminal 创建终端会话, cleanup不为空时在会话结束后执行, 创建失败时不会执行cleanup
+func startTerminal(clusterName, namespace, podName, containerName, shell string, userId uint, cleanup func()) (string, string, error) {
 	sessionID, err := genTerminalSessionId()
 	if err != nil {
-		return "", err
+		return "", "", err
+	}
+	token, err := genTerminalSessionId()
+	if err != nil {
+		return "", "", err
 	}
 
-	terminalSessions.Set(sessionID, TerminalSession{
-		id:       sessionID,
-		bound:    make(chan error),
-		sizeChan: make(chan remotecommand.TerminalSize),
-	})
+	record := &model.K8sTerminalRecord{
+		SessionID:     sessionID,
+		UserID:        userId,
+		ClusterName:   clusterName,
+		Namespace:     namespace,
+		PodName:       podName,
+		ContainerName: containerName,
+		Shell:         shell,
+		StartedAt:     time.Now(),
+	}
+	if user := dao.SystemUser.GetUserById(userId); user != nil {
+		record.Username = user.Username
+	}
+	rec, err := newRecorder(record)
+	if err != nil {
+		return "", "", errors.New("创建终端录像失败: " + err.Error())
+	}
 
-	// {"Op":"bind","SessionID":"db1888b4dd29e3c61540c56a5f7cfc22"}
+	ctx, cancel := context.WithCancel(context.Background())
+	err = terminalSessions.Add(TerminalSession{
+		id:         sessionID,
+		token:      token,
+		bound:      make(chan error, 1),
+		sizeChan:   make(chan remotecommand.TerminalSize),
+		recorder:   rec,
+		ctx:        ctx,
+		cancel:     cancel,
+		lastActive: new(atomic.Int64),
+		cleanup:    cleanup,
+		info: dto.K8sTerminalSessionInfo{
+			SessionID:     sessionID,
+			UserID:        userId,
+			Username:      record.Username,
+			ClusterName:   clusterName,
+			Namespace:     namespace,
+			PodName:       podName,
+			ContainerName: containerName,
+			Shell:         shell,
+			StartedAt:     record.StartedAt,
+		},
+	}, global.Config.Terminal.MaxSessionsPerUser)
+	if err != nil {
+		cancel()
+		rec.close()
+		return "", "", err
+	}
+
+	// {"Op":"bind","SessionID":"db1888b4dd29e3c61540c56a5f7cfc22","Token":"..."}
 	// {"Op":"stdin","Data":"ls\r","Cols":164,"Rows":41}
 	go WaitForTerminal(clusterName, namespace, podName, containerName, shell, sessionID)
-	return sessionID, err
+	return sessionID, token, nil
+}
+
+// ListTerminalSessions 获取当前打开的终端会话, userId为0时返回所有用户的会话
+func (p *Pod) ListTerminalSessions(userId uint) []dto.K8sTerminalSessionInfo {
+	return terminalSessions.List(userId)
+}
+
+// CloseAllTerminalSessions 服务停止时通知并关闭所有终端会话
+func (p *Pod) CloseAllTerminalSessions(reason string) {
+	terminalSessions.CloseAll(reason)
+}
+
+// KillTerminalSession 强制结束终端会话, 非管理员只能结束自己的会话
+func (p *Pod) KillTerminalSession(sessionId string, userId uint, admin bool) error {
+	ses := terminalSessions.Get(sessionId)
+	if ses.id == "" {
+		return errors.New("会话不存在")
+	}
+	if !admin && ses.info.UserID != userId {
+		return errors.New("只能结束自己的会话")
+	}
+
+	if ses.sockJSSession != nil {
+		if err := ses.Toast("会话已被强制结束"); err != nil {
+			log.Debug(err.Error())
+		}
+	}
+	terminalSessions.Close(sessionId, 4, "会话已被强制结束")
+	return nil
 }
@@ -0,0 +1,382 @@
+package pod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+	"net"
+	"net/http"
+	proxyutil "net/http/httputil"
+	"sort"
+	"soul/apis/dao"
+	"soul/apis/dto"
+	"soul/global"
+	log "soul/internal/logger"
+	"soul/model"
+	"soul/utils/httputil"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// forwardCheckInterval 检查端口转发空闲时间和最长时间的间隔
+const forwardCheckInterval = 30 * time.Second
+
+var ErrForwardNotFound = errors.New("端口转发会话不存在或已过期, 请重新创建")
+
+type forwardKey struct {
+	userId      uint
+	clusterName string
+	namespace   string
+	podName     string
+	port        int32
+}
+
+// forwardSession 一个到Pod端口的SPDY连接, 每个HTTP请求在连接上新建一对error/data stream
+type forwardSession struct {
+	key        forwardKey
+	info       dto.K8sPortForwardSession
+	conn       httpstream.Connection
+	proxy      *proxyutil.ReverseProxy
+	streamId   atomic.Int64
+	requests   atomic.Int64
+	lastActive atomic.Int64
+	lastError  atomic.Value // 最近一次error stream返回的错误
+	closeOnce  sync.Once
+}
+
+type forwardSessionMap struct {
+	lock     sync.Mutex
+	sessions map[forwardKey]*forwardSession
+	janitor  sync.Once
+}
+
+var forwardSessions = forwardSessionMap{sessions: map[forwardKey]*forwardSession{}}
+
+// StartPortForward 创建到Pod端口的转发会话, 同一用户对同一端口重复创建时返回已有的会话。
+// target用于审计, 记录用户请求的原始目标, 如 service/name:port
+func (p *Pod) StartPortForward(clusterName, namespace, podName string, port int32, target string, userId uint) (*dto.K8sPortForwardSession, error) {
+	key := forwardKey{userId: userId, clusterName: clusterName, namespace: namespace, podName: podName, port: port}
+
+	if session := forwardSessions.get(key); session != nil {
+		info := session.info
+		return &info, nil
+	}
+
+	pod, err := p.GetPodByName(clusterName, podName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if pod.Status.Phase != corev1.PodRunning {
+		return nil, fmt.Errorf("Pod状态为%s, 无法转发端口", pod.Status.Phase)
+	}
+
+	conn, err := dialPortForward(clusterName, namespace, podName)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionId, err := genTerminalSessionId()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if target == "" {
+		target = fmt.Sprintf("pod/%s:%d", podName, port)
+	}
+	now := time.Now()
+	session := &forwardSession{
+		key:  key,
+		conn: conn,
+		info: dto.K8sPortForwardSession{
+			SessionID:   sessionId,
+			UserID:      userId,
+			ClusterName: clusterName,
+			Namespace:   namespace,
+			PodName:     podName,
+			Port:        port,
+			Target:      target,
+			ProxyPath:   fmt.Sprintf("/api/v1/k8s/%s/pod/%s/%s/portforward/%d/", clusterName, namespace, podName, port),
+			StartedAt:   now,
+			ExpiresAt:   now.Add(global.Config.PortForward.MaxDuration),
+		},
+	}
+	if user := dao.SystemUser.GetUserById(userId); user != nil {
+		session.info.Username = user.Username
+	}
+	session.lastActive.Store(now.UnixNano())
+	session.proxy = session.newProxy()
+
+	forwardSessions.lock.Lock()
+	if exists, ok := forwardSessions.sessions[key]; ok {
+		// 并发创建时使用先创建的会话
+		forwardSessions.lock.Unlock()
+		conn.Close()
+		info := exists.info
+		return &info, nil
+	}
+	forwardSessions.sessions[key] = session
+	forwardSessions.lock.Unlock()
+
+	err = dao.K8sPortForward.CreatePortForwardRecord(&model.K8sPortForwardRecord{
+		SessionID:   sessionId,
+		UserID:      userId,
+		Username:    session.info.Username,
+		ClusterName: clusterName,
+		Namespace:   namespace,
+		PodName:     podName,
+		Port:        port,
+		Target:      target,
+		StartedAt:   now,
+	})
+	if err != nil {
+		forwardSessions.lock.Lock()
+		delete(forwardSessions.sessions, key)
+		forwardSessions.lock.Unlock()
+		conn.Close()
+		return nil, err
+	}
+
+	forwardSessions.janitor.Do(func() {
+		go forwardSessions.checkTimeout()
+	})
+	go func() {
+		<-conn.CloseChan()
+		forwardSessions.close(session, "连接已断开")
+	}()
+	log.Info("用户[%s]创建端口转发 %s/%s/%s:%d", session.info.Username, clusterName, namespace, podName, port)
+
+	info := session.info
+	return &info, nil
+}
+
+// StopPortForward 关闭用户自己的端口转发会话
+func (p *Pod) StopPortForward(clusterName, namespace, podName string, port int32, userId uint) error {
+	key := forwardKey{userId: userId, clusterName: clusterName, namespace: namespace, podName: podName, port: port}
+	session := forwardSessions.get(key)
+	if session == nil {
+		return ErrForwardNotFound
+	}
+	forwardSessions.close(session, "用户关闭")
+	return nil
+}
+
+// ProxyPortForward 将请求通过端口转发代理到Pod, path是代理路径之后的部分。
+// 会话不存在时返回ErrForwardNotFound, 此时还没有写入响应
+func (p *Pod) ProxyPortForward(w http.ResponseWriter, r *http.Request, clusterName, namespace, podName string, port int32, userId uint, path string) error {
+	key := forwardKey{userId: userId, clusterName: clusterName, namespace: namespace, podName: podName, port: port}
+	session := forwardSessions.get(key)
+	if session == nil {
+		return ErrForwardNotFound
+	}
+
+	session.lastActive.Store(time.Now().UnixNano())
+	session.requests.Add(1)
+	log.Info("用户[%s]端口转发请求 %s %s -> %s", session.info.Username, r.Method, path, session.info.Target)
+
+	req := r.Clone(r.Context())
+	req.URL.Path = path
+	req.URL.RawPath = ""
+	session.proxy.ServeHTTP(w, req)
+	return nil
+}
+
+// ListPortForwardSessions 获取当前的端口转发会话, userId为0时返回所有用户的会话
+func (p *Pod) ListPortForwardSessions(userId uint) []dto.K8sPortForwardSession {
+	forwardSessions.lock.Lock()
+	defer forwardSessions.lock.Unlock()
+	sessions := make([]dto.K8sPortForwardSession, 0, len(forwardSessions.sessions))
+	for key, session := range forwardSessions.sessions {
+		if userId != 0 && key.userId != userId {
+			continue
+		}
+		sessions = append(sessions, session.info)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+	})
+	return sessions
+}
+
+// ListPortForwardRecord 分页查询端口转发审计记录
+func (p *Pod) ListPortForwardRecord(query dto.K8sPortForwardRecordQuery) *httputil.PageResp {
+	records, total := dao.K8sPortForward.ListPortForwardRecord(query)
+	return &httputil.PageResp{
+		Limit: query.Limit,
+		Page:  query.Page,
+		Total: int(total),
+		Items: records,
+	}
+}
+
+// CloseAllPortForwards 服务停止时关闭所有端口转发
+func (p *Pod) CloseAllPortForwards(reason string) {
+	forwardSessions.lock.Lock()
+	sessions := make([]*forwardSession, 0, len(forwardSessions.sessions))
+	for _, session := range forwardSessions.sessions {
+		sessions = append(sessions, session)
+	}
+	forwardSessions.lock.Unlock()
+
+	for _, session := range sessions {
+		forwardSessions.close(session, reason)
+	}
+}
+
+func (m *forwardSessionMap) get(key forwardKey) *forwardSession {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.sessions[key]
+}
+
+// close 关闭SPDY连接并记录审计信息, 可以重复调用
+func (m *forwardSessionMap) close(session *forwardSession, reason string) {
+	session.closeOnce.Do(func() {
+		m.lock.Lock()
+		if m.sessions[session.key] == session {
+			delete(m.sessions, session.key)
+		}
+		m.lock.Unlock()
+
+		if err := session.conn.Close(); err != nil {
+			log.Debug(err.Error())
+		}
+		err := dao.K8sPortForward.FinishPortForwardRecord(session.info.SessionID, time.Now(), session.requests.Load(), reason)
+		if err != nil {
+			log.Error("更新端口转发记录失败: %s", err.Error())
+		}
+		log.Info("用户[%s]的端口转发 %s 已关闭: %s", session.info.Username, session.info.Target, reason)
+	})
+}
+
+// checkTimeout 关闭空闲或超过最长时间的会话
+func (m *forwardSessionMap) checkTimeout() {
+	ticker := time.NewTicker(forwardCheckInterval)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		cfg := global.Config.PortForward
+		m.lock.Lock()
+		sessions := make([]*forwardSession, 0, len(m.sessions))
+		for _, session := range m.sessions {
+			sessions = append(sessions, session)
+		}
+		m.lock.Unlock()
+
+		for _, session := range sessions {
+			switch {
+			case cfg.MaxDuration > 0 && now.After(session.info.ExpiresAt):
+				m.close(session, "达到最长时间")
+			case cfg.IdleTimeout > 0 && now.Sub(time.Unix(0, session.lastActive.Load())) > cfg.IdleTimeout:
+				m.close(session, "空闲超时")
+			}
+		}
+	}
+}
+
+// dialPortForward 建立到Pod portforward子资源的SPDY连接
+func dialPortForward(clusterName, namespace, podName string) (httpstream.Connection, error) {
+	client := global.K8s.Use(clusterName)
+	transport, upgrader, err := spdy.RoundTripperFor(client.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	req := client.ClientSet.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(podName).
+		SubResource("portforward")
+
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", req.URL())
+	conn, _, err := dialer.Dial(portforward.PortForwardProtocolV1Name)
+	return conn, err
+}
+
+func (s *forwardSession) newProxy() *proxyutil.ReverseProxy {
+	host := fmt.Sprintf("%s:%d", s.info.PodName, s.info.Port)
+	return &proxyutil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = "http"
+			req.URL.Host = host
+			req.Host = host
+			// 不能把平台的token转发给Pod
+			req.Header.Del("Authorization")
+			req.Header.Set("X-Forwarded-Prefix", s.info.ProxyPath)
+		},
+		Transport: &http.Transport{
+			DialContext:       s.dial,
+			DisableKeepAlives: true,
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			msg := err.Error()
+			if lastError, ok := s.lastError.Load().(string); ok && lastError != "" {
+				msg = lastError
+			}
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte("端口转发失败: " + msg))
+		},
+	}
+}
+
+// dial 在SPDY连接上创建一对error/data stream, 与kubectl port-forward的协议相同
+func (s *forwardSession) dial(ctx context.Context, network, addr string) (net.Conn, error) {
+	headers := http.Header{}
+	headers.Set(corev1.StreamType, corev1.StreamTypeError)
+	headers.Set(corev1.PortHeader, strconv.Itoa(int(s.info.Port)))
+	headers.Set(corev1.PortForwardRequestIDHeader, strconv.FormatInt(s.streamId.Add(1), 10))
+	errorStream, err := s.conn.CreateStream(headers)
+	if err != nil {
+		return nil, err
+	}
+	// error stream只读
+	errorStream.Close()
+
+	headers.Set(corev1.StreamType, corev1.StreamTypeData)
+	dataStream, err := s.conn.CreateStream(headers)
+	if err != nil {
+		s.conn.RemoveStreams(errorStream)
+		return nil, err
+	}
+
+	go func() {
+		message, err := io.ReadAll(errorStream)
+		switch {
+		case err != nil:
+			log.Debug("读取端口转发error stream失败: %s", err.Error())
+		case len(message) > 0:
+			s.lastError.Store(string(message))
+			log.Error("端口转发 %s 出错: %s", s.info.Target, string(message))
+		}
+	}()
+
+	return &streamConn{Stream: dataStream, errorStream: errorStream, conn: s.conn}, nil
+}
+
+// streamConn 将data stream包装为net.Conn, 供http.Transport使用
+type streamConn struct {
+	httpstream.Stream
+	errorStream httpstream.Stream
+	conn        httpstream.Connection
+}
+
+func (c *streamConn) Close() error {
+	defer c.conn.RemoveStreams(c.Stream, c.errorStream)
+	return c.Stream.Reset()
+}
+
+func (c *streamConn) LocalAddr() net.Addr                { return forwardAddr{} }
+func (c *streamConn) RemoteAddr() net.Addr               { return forwardAddr{} }
+func (c *streamConn) SetDeadline(_ time.Time) error      { return nil }
+func (c *streamConn) SetReadDeadline(_ time.Time) error  { return nil }
+func (c *streamConn) SetWriteDeadline(_ time.Time) error { return nil }
+
+type forwardAddr struct{}
+
+func (forwardAddr) Network() string { return "portforward" }
+func (forwardAddr) String() string  { return "portforward" }
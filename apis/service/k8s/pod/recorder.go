@@ -0,0 +1,128 @@
+package pod
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"soul/apis/dao"
+	"soul/global"
+	log "soul/internal/logger"
+	"soul/model"
+	"sync"
+	"time"
+)
+
+// asciicast v2 默认终端大小, 前端连接后会通过resize事件更新
+const (
+	defaultTerminalWidth  = 80
+	defaultTerminalHeight = 24
+)
+
+// asciicastHeader asciicast v2 文件的第一行, 见 https://docs.asciinema.org/manual/asciicast/v2/
+type asciicastHeader struct {
+	Version   int               `json:"version"`
+	Width     uint16            `json:"width"`
+	Height    uint16            `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// recorder 以asciicast v2格式记录终端会话, 每个事件一行: [秒, "o"|"i"|"r", 数据]
+type recorder struct {
+	mu        sync.Mutex
+	sessionId string
+	file      *os.File
+	start     time.Time
+	closed    bool
+}
+
+// newRecorder 创建录像文件并写入录像记录, 文件路径为 recordDir/集群/日期/会话ID.cast
+func newRecorder(record *model.K8sTerminalRecord) (*recorder, error) {
+	dir := filepath.Join(global.Config.Terminal.RecordDir, record.ClusterName, record.StartedAt.Format("20060102"))
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+	record.FilePath = filepath.Join(dir, record.SessionID+".cast")
+
+	file, err := os.OpenFile(record.FilePath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0640)
+	if err != nil {
+		return nil, err
+	}
+
+	header, _ := json.Marshal(asciicastHeader{
+		Version:   2,
+		Width:     defaultTerminalWidth,
+		Height:    defaultTerminalHeight,
+		Timestamp: record.StartedAt.Unix(),
+		Title:     fmt.Sprintf("%s@%s/%s/%s/%s", record.Username, record.ClusterName, record.Namespace, record.PodName, record.ContainerName),
+		Env:       map[string]string{"SHELL": record.Shell, "TERM": "xterm"},
+	})
+	if _, err = file.Write(append(header, '\n')); err != nil {
+		file.Close()
+		os.Remove(record.FilePath)
+		return nil, err
+	}
+
+	if err = dao.K8sTerminalRecord.CreateTerminalRecord(record); err != nil {
+		file.Close()
+		os.Remove(record.FilePath)
+		return nil, err
+	}
+
+	return &recorder{sessionId: record.SessionID, file: file, start: record.StartedAt}, nil
+}
+
+func (r *recorder) output(data string) {
+	r.event("o", data)
+}
+
+func (r *recorder) input(data string) {
+	r.event("i", data)
+}
+
+func (r *recorder) resize(cols, rows uint16) {
+	r.event("r", fmt.Sprintf("%dx%d", cols, rows))
+}
+
+func (r *recorder) event(code, data string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+
+	elapsed := float64(time.Since(r.start).Microseconds()) / 1e6
+	line, _ := json.Marshal([]any{elapsed, code, data})
+	if _, err := r.file.Write(append(line, '\n')); err != nil {
+		log.Error("写入终端录像失败: %s", err.Error())
+	}
+}
+
+// close 关闭录像文件并记录结束时间, 可以重复调用
+func (r *recorder) close() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return
+	}
+	r.closed = true
+
+	var size int64
+	if info, err := r.file.Stat(); err == nil {
+		size = info.Size()
+	}
+	if err := r.file.Close(); err != nil {
+		log.Error("关闭终端录像失败: %s", err.Error())
+	}
+	if err := dao.K8sTerminalRecord.FinishTerminalRecord(r.sessionId, time.Now(), size); err != nil {
+		log.Error("更新终端录像记录失败: %s", err.Error())
+	}
+}
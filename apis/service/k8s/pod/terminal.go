@@ -15,9 +15,12 @@ package pod
 // limitations under the License.
 
 import (
+	"context"
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"gopkg.in/igm/sockjs-go.v2/sockjs"
 	"io"
@@ -25,14 +28,20 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/remotecommand"
 	"net/http"
+	"sort"
+	"soul/apis/dto"
 	"soul/global"
 	log "soul/internal/logger"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 const END_OF_TRANSMISSION = "\u0004"
 
+// bindTimeout 创建会话后等待前端bind的时间, 也是bind token的有效期
+const bindTimeout = 10 * time.Second
+
 // PtyHandler is what remotecommand expects from a pty
 type PtyHandler interface {
 	io.Reader
@@ -43,33 +52,45 @@ type PtyHandler interface {
 // TerminalSession implements PtyHandler (using a SockJS connection)
 type TerminalSession struct {
 	id            string
+	token         string // 一次性bind token, bind成功后清空
 	bound         chan error
 	sockJSSession sockjs.Session
 	sizeChan      chan remotecommand.TerminalSize
+	recorder      *recorder
+	info          dto.K8sTerminalSessionInfo
+	cancel        context.CancelFunc // 结束exec连接
+	ctx           context.Context
+	lastActive    *atomic.Int64 // 最后一次输入的时间(UnixNano), 用于空闲超时
+	cleanup       func()        // 会话结束后执行, 例如删除节点调试Pod
 }
 
 // TerminalMessage is the messaging protocol between ShellController and TerminalSession.
 //
 // OP      DIRECTION  FIELD(S) USED  DESCRIPTION
 // ---------------------------------------------------------------------
-// bind    fe->be     SessionID      Id sent back from TerminalResponse
+// bind    fe->be     SessionID, Token  Id and single-use token sent back from TerminalResponse
 // stdin   fe->be     Data           Keystrokes/paste buffer
 // resize  fe->be     Rows, Cols     New terminal size
 // stdout  be->fe     Data           Output from the process
 // toast   be->fe     Data           OOB message to be shown to the user
 type TerminalMessage struct {
-	Op, Data, SessionID string
-	Rows, Cols          uint16
+	Op, Data, SessionID, Token string
+	Rows, Cols                 uint16
 }
 
 // Next handles pty->process resize events
 // Called in a loop from remotecommand as long as the process is running
 func (t TerminalSession) Next() *remotecommand.TerminalSize {
-	size := <-t.sizeChan
-	if size.Height == 0 && size.Width == 0 {
+	select {
+	case size := <-t.sizeChan:
+		if size.Height == 0 && size.Width == 0 {
+			return nil
+		}
+		return &size
+	case <-t.ctx.Done():
+		// 会话已关闭, 返回nil结束remotecommand的resize循环
 		return nil
 	}
-	return &size
 }
 
 // Read handles pty->process messages (stdin, resize)
@@ -89,10 +110,18 @@ func (t TerminalSession) Read(p []byte) (int, error) {
 	switch msg.Op {
 	case "stdin":
 		log.Trace("读取到:%s", msg.Data)
+		t.lastActive.Store(time.Now().UnixNano())
+		t.recorder.input(msg.Data)
 		return copy(p, msg.Data), nil
 	case "resize":
-		t.sizeChan <- remotecommand.TerminalSize{Width: msg.Cols, Height: msg.Rows}
-		return 0, nil
+		t.lastActive.Store(time.Now().UnixNano())
+		t.recorder.resize(msg.Cols, msg.Rows)
+		select {
+		case t.sizeChan <- remotecommand.TerminalSize{Width: msg.Cols, Height: msg.Rows}:
+			return 0, nil
+		case <-t.ctx.Done():
+			return copy(p, END_OF_TRANSMISSION), t.ctx.Err()
+		}
 	default:
 		return copy(p, END_OF_TRANSMISSION), fmt.Errorf("unknown message type '%s'", msg.Op)
 	}
@@ -113,6 +142,7 @@ func (t TerminalSession) Write(p []byte) (int, error) {
 	if err = t.sockJSSession.Send(string(msg)); err != nil {
 		return 0, err
 	}
+	t.recorder.output(string(p))
 	return len(p), nil
 }
 
@@ -146,6 +176,14 @@ func (sm *SessionMap) Get(sessionId string) TerminalSession {
 	return sm.Sessions[sessionId]
 }
 
+// Lookup return a given terminalSession by sessionId and whether it exists
+func (sm *SessionMap) Lookup(sessionId string) (TerminalSession, bool) {
+	sm.Lock.RLock()
+	defer sm.Lock.RUnlock()
+	ses, ok := sm.Sessions[sessionId]
+	return ses, ok
+}
+
 // Set store a TerminalSession to SessionMap
 func (sm *SessionMap) Set(sessionId string, session TerminalSession) {
 	sm.Lock.Lock()
@@ -157,19 +195,132 @@ func (sm *SessionMap) Set(sessionId string, session TerminalSession) {
 // Can happen if the process exits or if there is an error starting up the process
 // For now the status code is unused and reason is shown to the user (unless "")
 func (sm *SessionMap) Close(sessionId string, status uint32, reason string) {
+	sm.Lock.Lock()
+	ses, ok := sm.Sessions[sessionId]
+	if !ok {
+		// 会话已经被强制关闭
+		sm.Lock.Unlock()
+		return
+	}
+	delete(sm.Sessions, sessionId)
+	sm.Lock.Unlock()
+
+	// 关闭连接和写录像记录可能较慢, 在锁外执行
+	if ses.sockJSSession != nil {
+		if err := ses.sockJSSession.Close(status, reason); err != nil {
+			log.Debug(err.Error())
+		}
+	}
+	ses.release()
+}
+
+// Add 保存新的会话, maxPerUser大于0时限制每个用户同时打开的会话数量
+func (sm *SessionMap) Add(session TerminalSession, maxPerUser int) error {
 	sm.Lock.Lock()
 	defer sm.Lock.Unlock()
-	ses := sm.Sessions[sessionId]
-	err := ses.sockJSSession.Close(status, reason)
-	if err != nil {
-		log.Debug(err.Error())
+	if maxPerUser > 0 {
+		count := 0
+		for _, ses := range sm.Sessions {
+			if ses.info.UserID == session.info.UserID {
+				count++
+			}
+		}
+		if count >= maxPerUser {
+			return fmt.Errorf("每个用户最多同时打开%d个终端", maxPerUser)
+		}
+	}
+	sm.Sessions[session.id] = session
+	return nil
+}
+
+// Bind 校验一次性token并绑定SockJS连接, token只能使用一次且在bindTimeout内有效
+func (sm *SessionMap) Bind(sessionId, token string, session sockjs.Session) (TerminalSession, error) {
+	sm.Lock.Lock()
+	defer sm.Lock.Unlock()
+	ses, ok := sm.Sessions[sessionId]
+	if !ok {
+		return ses, errors.New("会话不存在")
+	}
+	if ses.token == "" || subtle.ConstantTimeCompare([]byte(ses.token), []byte(token)) != 1 {
+		return ses, errors.New("token无效或已被使用")
+	}
+
+	ses.token = ""
+	ses.sockJSSession = session
+	ses.lastActive.Store(time.Now().UnixNano())
+	sm.Sessions[sessionId] = ses
+	return ses, nil
+}
+
+// Expire 会话在bindTimeout内没有bind时删除会话. found为false表示会话已经被关闭,
+// found为true且expired为false表示会话已经bind
+func (sm *SessionMap) Expire(sessionId string) (expired, found bool) {
+	sm.Lock.Lock()
+	ses, ok := sm.Sessions[sessionId]
+	if !ok || ses.sockJSSession != nil {
+		sm.Lock.Unlock()
+		return false, ok
 	}
-	close(ses.sizeChan)
 	delete(sm.Sessions, sessionId)
+	sm.Lock.Unlock()
+
+	ses.release()
+	return true, true
+}
+
+// release 结束exec连接并保存录像, 会话必须已经从SessionMap中删除.
+// sizeChan不关闭, Read和Next通过ctx感知会话结束, 避免向已关闭的channel发送
+func (t TerminalSession) release() {
+	t.cancel()
+	t.recorder.close()
+	if t.cleanup != nil {
+		pendingCleanups.Add(1)
+		go func() {
+			defer pendingCleanups.Done()
+			t.cleanup()
+		}()
+	}
+}
+
+// CloseAll 通知并关闭所有会话, 用于服务停止时. 等待所有会话的清理(如删除节点调试Pod)完成后返回
+func (sm *SessionMap) CloseAll(reason string) {
+	sm.Lock.RLock()
+	sessions := make([]TerminalSession, 0, len(sm.Sessions))
+	for _, ses := range sm.Sessions {
+		sessions = append(sessions, ses)
+	}
+	sm.Lock.RUnlock()
+
+	for _, ses := range sessions {
+		closeTerminal(ses, reason)
+	}
+	pendingCleanups.Wait()
+}
+
+// List 返回所有会话的信息, userId为0时返回所有用户的会话
+func (sm *SessionMap) List(userId uint) []dto.K8sTerminalSessionInfo {
+	sm.Lock.RLock()
+	defer sm.Lock.RUnlock()
+	sessions := make([]dto.K8sTerminalSessionInfo, 0, len(sm.Sessions))
+	for _, ses := range sm.Sessions {
+		if userId != 0 && ses.info.UserID != userId {
+			continue
+		}
+		info := ses.info
+		info.Bound = ses.sockJSSession != nil
+		sessions = append(sessions, info)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+	})
+	return sessions
 }
 
 var terminalSessions = SessionMap{Sessions: make(map[string]TerminalSession)}
 
+// pendingCleanups 正在执行的会话清理, 服务停止时需要等待它们完成
+var pendingCleanups sync.WaitGroup
+
 // handleTerminalSession is Called by net/http for any new /api/sockjs connections
 func handleTerminalSession(session sockjs.Session) {
 	var (
@@ -194,13 +345,14 @@ func handleTerminalSession(session sockjs.Session) {
 		return
 	}
 
-	if terminalSession = terminalSessions.Get(msg.SessionID); terminalSession.id == "" {
-		log.Debug("handleTerminalSession: can't find session '%s'", msg.SessionID)
+	if terminalSession, err = terminalSessions.Bind(msg.SessionID, msg.Token, session); err != nil {
+		log.Info("handleTerminalSession: bind session '%s' failed: %s", msg.SessionID, err.Error())
+		if err = session.Close(3, err.Error()); err != nil {
+			log.Debug(err.Error())
+		}
 		return
 	}
 
-	terminalSession.sockJSSession = session
-	terminalSessions.Set(msg.SessionID, terminalSession)
 	terminalSession.bound <- nil
 	log.Debug("会话初始化成功")
 }
@@ -212,7 +364,7 @@ func CreateAttachHandler(path string) http.Handler {
 
 // startProcess is called by handleAttach
 // Executed cmd in the container specified in request and connects it up with the ptyHandler (a session)
-func startProcess(clusterName, namespace, podName, containerName string, cmd []string, ptyHandler PtyHandler) error {
+func startProcess(ctx context.Context, clusterName, namespace, podName, containerName string, cmd []string, ptyHandler PtyHandler) error {
 	req := global.K8s.Use(clusterName).ClientSet.CoreV1().RESTClient().Post().
 		Resource("pods").
 		Name(podName).
@@ -234,7 +386,7 @@ func startProcess(clusterName, namespace, podName, containerName string, cmd []s
 		return err
 	}
 
-	err = exec.Stream(remotecommand.StreamOptions{
+	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
 		Stdin:             ptyHandler,
 		Stdout:            ptyHandler,
 		Stderr:            ptyHandler,
@@ -276,39 +428,60 @@ func isValidShell(validShells []string, shell string) bool {
 // WaitForTerminal is called from apihandler.handleAttach as a goroutine
 // Waits for the SockJS connection to be opened by the client the session to be bound in handleTerminalSession
 func WaitForTerminal(clusterName, namespace, podName, containerName, shell, sessionId string) {
+	ses, ok := terminalSessions.Lookup(sessionId)
+	if !ok {
+		return
+	}
+	bound := ses.bound
 
-	select {
-	case <-terminalSessions.Get(sessionId).bound:
-		close(terminalSessions.Get(sessionId).bound)
-
-		var err error
-		validShells := []string{"bash", "sh", "powershell", "cmd"}
-
-		if isValidShell(validShells, shell) {
-			cmd := []string{shell}
-			err = startProcess(clusterName, namespace, podName, containerName, cmd, terminalSessions.Get(sessionId))
-		} else {
-			// No shell given or it was not valid: try some shells until one succeeds or all fail
-			// FIXME: if the first shell fails then the first keyboard event is lost
-			for _, testShell := range validShells {
-				cmd := []string{testShell}
-				if err = startProcess(clusterName, namespace, podName, containerName, cmd, terminalSessions.Get(sessionId)); err == nil {
-					break
-				}
+	for {
+		select {
+		case <-bound:
+			runTerminal(clusterName, namespace, podName, containerName, shell, sessionId)
+			return
+
+		case <-time.After(bindTimeout):
+			// Delete session when sockjs connection was timeout
+			expired, found := terminalSessions.Expire(sessionId)
+			if expired || !found {
+				return
 			}
+			// bind和超时同时发生, 会话已经bind, 重新等待bound
 		}
+	}
+}
 
-		if err != nil {
-			terminalSessions.Close(sessionId, 2, err.Error())
-			return
+// runTerminal 会话bind之后启动shell, 进程退出或启动失败时关闭会话
+func runTerminal(clusterName, namespace, podName, containerName, shell, sessionId string) {
+	var err error
+	session, ok := terminalSessions.Lookup(sessionId)
+	if !ok {
+		// bind之后、启动进程之前会话已经被关闭
+		return
+	}
+	go watchTerminal(session)
+	validShells := []string{"bash", "sh", "powershell", "cmd"}
+
+	if isValidShell(validShells, shell) {
+		cmd := []string{shell}
+		err = startProcess(session.ctx, clusterName, namespace, podName, containerName, cmd, session)
+	} else {
+		// No shell given or it was not valid: try some shells until one succeeds or all fail
+		// FIXME: if the first shell fails then the first keyboard event is lost
+		for _, testShell := range validShells {
+			cmd := []string{testShell}
+			if err = startProcess(session.ctx, clusterName, namespace, podName, containerName, cmd, session); err == nil {
+				break
+			}
 		}
-		terminalSessions.Get(sessionId).Write([]byte("Process exited"))
-		terminalSessions.Close(sessionId, 1, "Process exited")
+	}
 
-	case <-time.After(10 * time.Second):
-		// Close chan and delete session when sockjs connection was timeout
-		close(terminalSessions.Get(sessionId).bound)
-		delete(terminalSessions.Sessions, sessionId)
+	if err != nil {
+		terminalSessions.Close(sessionId, 2, err.Error())
 		return
 	}
+	if ses := terminalSessions.Get(sessionId); ses.sockJSSession != nil {
+		ses.Write([]byte("Process exited"))
+	}
+	terminalSessions.Close(sessionId, 1, "Process exited")
 }
@@ -0,0 +1,72 @@
+package pod
+
+import (
+	"fmt"
+	"soul/global"
+	log "soul/internal/logger"
+	"time"
+)
+
+// watchInterval 检查会话空闲时间和持续时间的间隔
+const watchInterval = 5 * time.Second
+
+// watchTerminal 在会话bind后运行, 会话空闲或持续时间超过配置时断开, 断开前通过toast提醒用户
+func watchTerminal(session TerminalSession) {
+	cfg := global.Config.Terminal
+	if cfg.IdleTimeout <= 0 && cfg.MaxDuration <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	var idleWarned, durationWarned bool
+	for {
+		select {
+		case <-session.ctx.Done():
+			return
+		case now := <-ticker.C:
+			if cfg.MaxDuration > 0 {
+				remaining := cfg.MaxDuration - now.Sub(session.info.StartedAt)
+				if remaining <= 0 {
+					closeTerminal(session, "会话已达到最长时间, 连接已断开")
+					return
+				}
+				if remaining <= cfg.WarnBefore && !durationWarned {
+					durationWarned = true
+					toast(session, fmt.Sprintf("会话将在%s后达到最长时间并断开", remaining.Round(time.Second)))
+				}
+			}
+
+			if cfg.IdleTimeout > 0 {
+				remaining := cfg.IdleTimeout - now.Sub(time.Unix(0, session.lastActive.Load()))
+				if remaining <= 0 {
+					closeTerminal(session, "会话空闲超时, 连接已断开")
+					return
+				}
+				// 有新的输入后重新提醒
+				if remaining > cfg.WarnBefore {
+					idleWarned = false
+				} else if !idleWarned {
+					idleWarned = true
+					toast(session, fmt.Sprintf("会话空闲, 将在%s后断开", remaining.Round(time.Second)))
+				}
+			}
+		}
+	}
+}
+
+// closeTerminal 通过toast告知用户原因后关闭会话
+func closeTerminal(session TerminalSession, reason string) {
+	toast(session, reason)
+	terminalSessions.Close(session.id, 5, reason)
+}
+
+func toast(session TerminalSession, msg string) {
+	if session.sockJSSession == nil {
+		return
+	}
+	if err := session.Toast(msg); err != nil {
+		log.Debug("发送toast失败: %s", err.Error())
+	}
+}
@@ -0,0 +1,120 @@
+package scalepolicy
+
+import (
+	"errors"
+	"fmt"
+	"soul/apis/dao"
+	"soul/apis/dto"
+	log "soul/internal/logger"
+	"soul/internal/scheduler"
+	"soul/model"
+	"soul/utils/httputil"
+)
+
+type ScalePolicy struct{}
+
+func (s *ScalePolicy) ListScalePolicy(clusterName string) []model.K8sScalePolicy {
+	return dao.K8sScalePolicy.ListScalePolicy(clusterName)
+}
+
+func (s *ScalePolicy) GetScalePolicyById(clusterName string, id uint) (*model.K8sScalePolicy, error) {
+	policy := dao.K8sScalePolicy.GetScalePolicyById(id)
+	if policy == nil || policy.ClusterName != clusterName {
+		return nil, errors.New("策略不存在")
+	}
+	return policy, nil
+}
+
+func (s *ScalePolicy) CreateScalePolicy(clusterName string, create dto.K8sScalePolicyCreate) (*model.K8sScalePolicy, error) {
+	if dao.K8sScalePolicy.GetScalePolicyByName(create.Name) != nil {
+		return nil, errors.New("策略名称已存在")
+	}
+
+	policy := createToPolicy(clusterName, create)
+	if err := dao.K8sScalePolicy.CreateScalePolicy(policy); err != nil {
+		return nil, err
+	}
+
+	if err := scheduler.SchedulePolicy(*policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (s *ScalePolicy) UpdateScalePolicy(clusterName string, id uint, create dto.K8sScalePolicyCreate) (*model.K8sScalePolicy, error) {
+	old, err := s.GetScalePolicyById(clusterName, id)
+	if err != nil {
+		return nil, err
+	}
+	if exist := dao.K8sScalePolicy.GetScalePolicyByName(create.Name); exist != nil && exist.ID.ID != id {
+		return nil, errors.New("策略名称已存在")
+	}
+
+	policy := createToPolicy(clusterName, create)
+	policy.ID = old.ID
+	policy.CreatedAt = old.CreatedAt
+
+	// 停用策略或更换目标后不会再按原策略恢复, 更新前先将缩容过的Deployment恢复到原始副本数
+	if !policy.Enabled || policy.Namespace != old.Namespace || policy.DeploymentName != old.DeploymentName {
+		scheduler.UnschedulePolicy(id)
+		if err = scheduler.RestorePolicy(old); err != nil {
+			if scheduleErr := scheduler.SchedulePolicy(*old); scheduleErr != nil {
+				log.Error("重新加载扩缩容策略%s失败: %s", old.Name, scheduleErr.Error())
+			}
+			return nil, fmt.Errorf("恢复副本数失败, 策略未更新: %s", err.Error())
+		}
+	}
+
+	if err = dao.K8sScalePolicy.UpdateScalePolicy(policy); err != nil {
+		return nil, err
+	}
+
+	if err = scheduler.SchedulePolicy(*policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// DeleteScalePolicy 删除策略, 删除前将缩容过的Deployment恢复到原始副本数, 恢复失败时不删除策略
+func (s *ScalePolicy) DeleteScalePolicy(clusterName string, id uint) error {
+	policy, err := s.GetScalePolicyById(clusterName, id)
+	if err != nil {
+		return err
+	}
+
+	// 先停止定时任务, 避免恢复过程中再次缩容
+	scheduler.UnschedulePolicy(id)
+	if err = scheduler.RestorePolicy(policy); err != nil {
+		if scheduleErr := scheduler.SchedulePolicy(*policy); scheduleErr != nil {
+			log.Error("重新加载扩缩容策略%s失败: %s", policy.Name, scheduleErr.Error())
+		}
+		return fmt.Errorf("恢复副本数失败, 策略未删除: %s", err.Error())
+	}
+	return dao.K8sScalePolicy.DeleteScalePolicyById(id)
+}
+
+func (s *ScalePolicy) ListScaleHistory(clusterName string, id uint, limit, page int) (*httputil.PageResp, error) {
+	if _, err := s.GetScalePolicyById(clusterName, id); err != nil {
+		return nil, err
+	}
+	histories, total := dao.K8sScalePolicy.ListScaleHistory(id, limit, page)
+	return &httputil.PageResp{
+		Limit: limit,
+		Page:  page,
+		Total: int(total),
+		Items: histories,
+	}, nil
+}
+
+func createToPolicy(clusterName string, create dto.K8sScalePolicyCreate) *model.K8sScalePolicy {
+	return &model.K8sScalePolicy{
+		Name:            create.Name,
+		ClusterName:     clusterName,
+		Namespace:       create.Namespace,
+		DeploymentName:  create.DeploymentName,
+		ScaleSchedule:   create.ScaleSchedule,
+		ScaleReplicas:   create.ScaleReplicas,
+		RestoreSchedule: create.RestoreSchedule,
+		Enabled:         create.Enabled,
+	}
+}
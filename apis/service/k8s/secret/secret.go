@@ -8,6 +8,7 @@ import (
 	"soul/apis/dto"
 	"soul/apis/service/k8s"
 	"soul/global"
+	"soul/utils/diffutil"
 	"soul/utils/httputil"
 )
 
@@ -75,24 +76,11 @@ func (s *Secret) DeleteSecretByName(clusterName, secretName, namespace string) (
 }
 
 func (s *Secret) CreateSecretForDockerRegistry(clusterName string, secretForDockerRegistryCreate *dto.K8sSecretForDockerRegistryCreate) (err error) {
-	// 格式转换
-	data := secretForDockerRegistryCreate.ToDockerconfig()
-	secretStr, err := json.Marshal(data)
+	secret, err := s.dockerRegistryToSecret(secretForDockerRegistryCreate)
 	if err != nil {
 		return err
 	}
 
-	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        secretForDockerRegistryCreate.Name,
-			Namespace:   secretForDockerRegistryCreate.Namespace,
-			Labels:      nil,
-			Annotations: map[string]string{"created-by": global.K8sManager},
-		},
-		StringData: map[string]string{".dockerconfigjson": string(secretStr)},
-		Type:       corev1.SecretTypeDockerConfigJson,
-	}
-
 	_, err = global.K8s.Use(clusterName).ClientSet.CoreV1().Secrets(secretForDockerRegistryCreate.Namespace).Create(context.TODO(), secret, metav1.CreateOptions{})
 	if err != nil {
 		return err
@@ -100,23 +88,10 @@ func (s *Secret) CreateSecretForDockerRegistry(clusterName string, secretForDock
 	return nil
 }
 func (s *Secret) UpdateSecretForDockerRegistry(clusterName string, secretForDockerRegistryCreate *dto.K8sSecretForDockerRegistryCreate) (err error) {
-	// 格式转换
-	data := secretForDockerRegistryCreate.ToDockerconfig()
-	secretStr, err := json.Marshal(data)
-
+	secret, err := s.dockerRegistryToSecret(secretForDockerRegistryCreate)
 	if err != nil {
 		return err
 	}
-	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        secretForDockerRegistryCreate.Name,
-			Namespace:   secretForDockerRegistryCreate.Namespace,
-			Labels:      nil,
-			Annotations: map[string]string{"created-by": global.K8sManager},
-		},
-		StringData: map[string]string{".dockerconfigjson": string(secretStr)},
-		Type:       corev1.SecretTypeDockerConfigJson,
-	}
 
 	_, err = global.K8s.Use(clusterName).ClientSet.CoreV1().Secrets(secretForDockerRegistryCreate.Namespace).Update(context.TODO(), secret, metav1.UpdateOptions{})
 	if err != nil {
@@ -125,23 +100,16 @@ func (s *Secret) UpdateSecretForDockerRegistry(clusterName string, secretForDock
 	return nil
 }
 
-func (s *Secret) CreateSecretForTls(clusterName string, secretForTlsCreate *dto.K8sSecretForTlsCreate) (err error) {
+func (s *Secret) DiffSecretForDockerRegistry(clusterName string, secretForDockerRegistryCreate *dto.K8sSecretForDockerRegistryCreate) (*diffutil.Result, error) {
+	secret, err := s.dockerRegistryToSecret(secretForDockerRegistryCreate)
 	if err != nil {
-		return err
-	}
-	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        secretForTlsCreate.Name,
-			Namespace:   secretForTlsCreate.Namespace,
-			Labels:      nil,
-			Annotations: map[string]string{"created-by": global.K8sManager},
-		},
-		StringData: map[string]string{
-			"tls.crt": secretForTlsCreate.Certificate,
-			"tls.key": secretForTlsCreate.PrivateKey,
-		},
-		Type: corev1.SecretTypeTLS,
+		return nil, err
 	}
+	return s.diffSecret(clusterName, secret)
+}
+
+func (s *Secret) CreateSecretForTls(clusterName string, secretForTlsCreate *dto.K8sSecretForTlsCreate) (err error) {
+	secret := s.tlsToSecret(secretForTlsCreate)
 
 	_, err = global.K8s.Use(clusterName).ClientSet.CoreV1().Secrets(secretForTlsCreate.Namespace).Create(context.TODO(), secret, metav1.CreateOptions{})
 	if err != nil {
@@ -150,22 +118,7 @@ func (s *Secret) CreateSecretForTls(clusterName string, secretForTlsCreate *dto.
 	return nil
 }
 func (s *Secret) UpdateSecretForTls(clusterName string, secretForTlsCreate *dto.K8sSecretForTlsCreate) (err error) {
-	if err != nil {
-		return err
-	}
-	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        secretForTlsCreate.Name,
-			Namespace:   secretForTlsCreate.Namespace,
-			Labels:      nil,
-			Annotations: map[string]string{"created-by": global.K8sManager},
-		},
-		StringData: map[string]string{
-			"tls.crt": secretForTlsCreate.Certificate,
-			"tls.key": secretForTlsCreate.PrivateKey,
-		},
-		Type: corev1.SecretTypeTLS,
-	}
+	secret := s.tlsToSecret(secretForTlsCreate)
 
 	_, err = global.K8s.Use(clusterName).ClientSet.CoreV1().Secrets(secretForTlsCreate.Namespace).Update(context.TODO(), secret, metav1.UpdateOptions{})
 	if err != nil {
@@ -174,20 +127,12 @@ func (s *Secret) UpdateSecretForTls(clusterName string, secretForTlsCreate *dto.
 	return nil
 }
 
+func (s *Secret) DiffSecretForTls(clusterName string, secretForTlsCreate *dto.K8sSecretForTlsCreate) (*diffutil.Result, error) {
+	return s.diffSecret(clusterName, s.tlsToSecret(secretForTlsCreate))
+}
+
 func (s *Secret) CreateSecret(clusterName string, secretCreate *dto.K8sSecretCreate) (err error) {
-	if err != nil {
-		return err
-	}
-	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:        secretCreate.Name,
-			Namespace:   secretCreate.Namespace,
-			Labels:      nil,
-			Annotations: map[string]string{"created-by": global.K8sManager},
-		},
-		StringData: secretCreate.Data,
-		Type:       corev1.SecretTypeOpaque,
-	}
+	secret := s.opaqueToSecret(secretCreate)
 
 	_, err = global.K8s.Use(clusterName).ClientSet.CoreV1().Secrets(secretCreate.Namespace).Create(context.TODO(), secret, metav1.CreateOptions{})
 	if err != nil {
@@ -196,10 +141,74 @@ func (s *Secret) CreateSecret(clusterName string, secretCreate *dto.K8sSecretCre
 	return nil
 }
 func (s *Secret) UpdateSecret(clusterName string, secretCreate *dto.K8sSecretCreate) (err error) {
+	secret := s.opaqueToSecret(secretCreate)
+
+	_, err = global.K8s.Use(clusterName).ClientSet.CoreV1().Secrets(secretCreate.Namespace).Update(context.TODO(), secret, metav1.UpdateOptions{})
 	if err != nil {
 		return err
 	}
-	secret := &corev1.Secret{
+	return nil
+}
+
+func (s *Secret) DiffSecret(clusterName string, secretCreate *dto.K8sSecretCreate) (*diffutil.Result, error) {
+	return s.diffSecret(clusterName, s.opaqueToSecret(secretCreate))
+}
+
+// diffSecret 使用 dry-run 模拟更新, 返回集群中的对象和更新后对象的差异
+func (s *Secret) diffSecret(clusterName string, secret *corev1.Secret) (*diffutil.Result, error) {
+	live, err := s.GetSecretByName(clusterName, secret.Name, secret.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := global.K8s.Use(clusterName).ClientSet.CoreV1().Secrets(secret.Namespace).Update(context.TODO(), secret, metav1.UpdateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return k8s.DiffObject("secret", secret.Name, live, result)
+}
+
+func (s *Secret) dockerRegistryToSecret(secretForDockerRegistryCreate *dto.K8sSecretForDockerRegistryCreate) (*corev1.Secret, error) {
+	// 格式转换
+	data := secretForDockerRegistryCreate.ToDockerconfig()
+	secretStr, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secretForDockerRegistryCreate.Name,
+			Namespace:   secretForDockerRegistryCreate.Namespace,
+			Labels:      nil,
+			Annotations: map[string]string{"created-by": global.K8sManager},
+		},
+		StringData: map[string]string{".dockerconfigjson": string(secretStr)},
+		Type:       corev1.SecretTypeDockerConfigJson,
+	}, nil
+}
+
+func (s *Secret) tlsToSecret(secretForTlsCreate *dto.K8sSecretForTlsCreate) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secretForTlsCreate.Name,
+			Namespace:   secretForTlsCreate.Namespace,
+			Labels:      nil,
+			Annotations: map[string]string{"created-by": global.K8sManager},
+		},
+		StringData: map[string]string{
+			"tls.crt": secretForTlsCreate.Certificate,
+			"tls.key": secretForTlsCreate.PrivateKey,
+		},
+		Type: corev1.SecretTypeTLS,
+	}
+}
+
+func (s *Secret) opaqueToSecret(secretCreate *dto.K8sSecretCreate) *corev1.Secret {
+	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        secretCreate.Name,
 			Namespace:   secretCreate.Namespace,
@@ -209,10 +218,4 @@ func (s *Secret) UpdateSecret(clusterName string, secretCreate *dto.K8sSecretCre
 		StringData: secretCreate.Data,
 		Type:       corev1.SecretTypeOpaque,
 	}
-
-	_, err = global.K8s.Use(clusterName).ClientSet.CoreV1().Secrets(secretCreate.Namespace).Update(context.TODO(), secret, metav1.UpdateOptions{})
-	if err != nil {
-		return err
-	}
-	return nil
 }
@@ -0,0 +1,66 @@
+package k8s
+
+import (
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodStatus 计算Pod的状态, 与 kubectl get pod 的STATUS列相同, 如Evicted、CrashLoopBackOff、Init:0/1、Terminating
+func PodStatus(pod *corev1.Pod) string {
+	reason := string(pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		reason = pod.Status.Reason
+	}
+
+	initializing := false
+	for i := range pod.Status.InitContainerStatuses {
+		container := pod.Status.InitContainerStatuses[i]
+		switch {
+		case container.State.Terminated != nil && container.State.Terminated.ExitCode == 0:
+			continue
+		case container.State.Terminated != nil:
+			if container.State.Terminated.Reason != "" {
+				reason = "Init:" + container.State.Terminated.Reason
+			} else {
+				reason = fmt.Sprintf("Init:ExitCode:%d", container.State.Terminated.ExitCode)
+			}
+		case container.State.Waiting != nil && container.State.Waiting.Reason != "" && container.State.Waiting.Reason != "PodInitializing":
+			reason = "Init:" + container.State.Waiting.Reason
+		default:
+			reason = fmt.Sprintf("Init:%d/%d", i, len(pod.Spec.InitContainers))
+		}
+		initializing = true
+		break
+	}
+
+	if !initializing {
+		hasRunning := false
+		for i := len(pod.Status.ContainerStatuses) - 1; i >= 0; i-- {
+			container := pod.Status.ContainerStatuses[i]
+			switch {
+			case container.State.Waiting != nil && container.State.Waiting.Reason != "":
+				reason = container.State.Waiting.Reason
+			case container.State.Terminated != nil && container.State.Terminated.Reason != "":
+				reason = container.State.Terminated.Reason
+			case container.State.Terminated != nil && container.State.Terminated.Signal != 0:
+				reason = fmt.Sprintf("Signal:%d", container.State.Terminated.Signal)
+			case container.State.Terminated != nil:
+				reason = fmt.Sprintf("ExitCode:%d", container.State.Terminated.ExitCode)
+			case container.Ready && container.State.Running != nil:
+				hasRunning = true
+			}
+		}
+		// 部分容器已经退出, 其他容器仍在运行
+		if reason == "Completed" && hasRunning {
+			reason = "Running"
+		}
+	}
+
+	if pod.DeletionTimestamp != nil {
+		if pod.Status.Reason == "NodeLost" {
+			return "Unknown"
+		}
+		return "Terminating"
+	}
+	return reason
+}
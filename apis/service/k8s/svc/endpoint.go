@@ -0,0 +1,330 @@
+package svc
+
+import (
+	"context"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	ingressv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sort"
+	"soul/apis/dto"
+	"soul/apis/service/k8s"
+	"soul/global"
+	"strconv"
+	"strings"
+)
+
+// GetSvcDetail 获取Service的EndpointSlice、后端Pod和引用它的Ingress, 并检查selector和端口配置是否能匹配到Pod
+func (s *Svc) GetSvcDetail(ctx context.Context, clusterName, name, namespace string) (*dto.K8sSvcDetail, error) {
+	clientSet := global.K8s.Use(clusterName).ClientSet
+	svc, err := clientSet.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	detail := &dto.K8sSvcDetail{
+		Service:   svc,
+		Endpoints: []dto.K8sSvcEndpoint{},
+		Findings:  []dto.K8sDiagnosticFinding{},
+		Ingresses: []dto.K8sSvcIngressRef{},
+	}
+	add := func(finding dto.K8sDiagnosticFinding) {
+		detail.Findings = append(detail.Findings, finding)
+	}
+
+	var pods []corev1.Pod
+	if len(svc.Spec.Selector) > 0 {
+		list, err := clientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		pods = list.Items
+	}
+
+	if svc.Spec.Type == corev1.ServiceTypeExternalName {
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityInfo,
+			Category:    "Service",
+			Reason:      "ExternalName",
+			Message:     fmt.Sprintf("Service解析为 %s 的CNAME记录", svc.Spec.ExternalName),
+			Explanation: "ExternalName类型的Service只提供DNS别名, 没有Endpoint, 连接问题需要检查外部域名是否可以解析和访问。",
+		})
+	} else {
+		slices, err := clientSet.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: discoveryv1.LabelServiceName + "=" + name,
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.resolveEndpoints(detail, slices.Items, pods)
+		checkSelector(svc, pods, detail, add)
+	}
+
+	ingresses, err := clientSet.NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	detail.Ingresses = ingressRefs(svc, ingresses.Items, add)
+
+	detail.Healthy = k8s.Healthy(detail.Findings)
+	return detail, nil
+}
+
+// resolveEndpoints 展开EndpointSlice中的地址, 同一个地址可能同时出现在IPv4和IPv6的EndpointSlice中, 按slice分别列出
+func (s *Svc) resolveEndpoints(detail *dto.K8sSvcDetail, slices []discoveryv1.EndpointSlice, pods []corev1.Pod) {
+	podsByName := make(map[string]*corev1.Pod, len(pods))
+	for i := range pods {
+		podsByName[pods[i].Name] = &pods[i]
+	}
+
+	for _, slice := range slices {
+		ports := make([]dto.K8sSvcEndpointPort, 0, len(slice.Ports))
+		for _, port := range slice.Ports {
+			p := dto.K8sSvcEndpointPort{}
+			if port.Name != nil {
+				p.Name = *port.Name
+			}
+			if port.Port != nil {
+				p.Port = *port.Port
+			}
+			if port.Protocol != nil {
+				p.Protocol = string(*port.Protocol)
+			}
+			ports = append(ports, p)
+		}
+
+		for _, endpoint := range slice.Endpoints {
+			// ready为空时按就绪处理, serving为空时与ready相同
+			ready := endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+			serving := ready
+			if endpoint.Conditions.Serving != nil {
+				serving = *endpoint.Conditions.Serving
+			}
+			terminating := endpoint.Conditions.Terminating != nil && *endpoint.Conditions.Terminating
+
+			for _, address := range endpoint.Addresses {
+				e := dto.K8sSvcEndpoint{
+					Address:       address,
+					Ready:         ready,
+					Serving:       serving,
+					Terminating:   terminating,
+					EndpointSlice: slice.Name,
+					Ports:         ports,
+				}
+				if endpoint.NodeName != nil {
+					e.NodeName = *endpoint.NodeName
+				}
+				if endpoint.Zone != nil {
+					e.Zone = *endpoint.Zone
+				}
+				if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" {
+					e.PodName = endpoint.TargetRef.Name
+					if pod, ok := podsByName[e.PodName]; ok {
+						e.PodStatus = k8s.PodStatus(pod)
+					}
+				}
+				if ready {
+					detail.Ready++
+				} else {
+					detail.NotReady++
+				}
+				detail.Endpoints = append(detail.Endpoints, e)
+			}
+		}
+	}
+
+	sort.SliceStable(detail.Endpoints, func(i, j int) bool {
+		if detail.Endpoints[i].Ready != detail.Endpoints[j].Ready {
+			return detail.Endpoints[i].Ready
+		}
+		return detail.Endpoints[i].Address < detail.Endpoints[j].Address
+	})
+}
+
+// checkSelector 检查selector是否匹配到Pod、Pod是否就绪以及targetPort是否在容器中声明
+func checkSelector(svc *corev1.Service, pods []corev1.Pod, detail *dto.K8sSvcDetail, add func(dto.K8sDiagnosticFinding)) {
+	if len(svc.Spec.Selector) == 0 {
+		severity := k8s.SeverityInfo
+		if len(detail.Endpoints) == 0 {
+			severity = k8s.SeverityWarning
+		}
+		add(dto.K8sDiagnosticFinding{
+			Severity:    severity,
+			Category:    "Selector",
+			Reason:      "NoSelector",
+			Message:     fmt.Sprintf("Service没有selector, 有%d个手动维护的地址", len(detail.Endpoints)),
+			Explanation: "没有selector的Service不会自动生成Endpoint, 需要手动创建EndpointSlice或Endpoints指向后端地址。",
+		})
+		return
+	}
+
+	selector := labels.SelectorFromSet(svc.Spec.Selector).String()
+	if len(pods) == 0 {
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityError,
+			Category:    "Selector",
+			Reason:      "NoMatchingPods",
+			Message:     fmt.Sprintf("selector %s 没有匹配到namespace %s 中的Pod", selector, svc.Namespace),
+			Explanation: "Service的selector需要和Pod模板的labels完全匹配, 检查Deployment的spec.template.metadata.labels是否包含selector中的所有标签, 以及Service和Pod是否在同一个namespace。",
+		})
+		return
+	}
+
+	ready := 0
+	for i := range pods {
+		if pods[i].DeletionTimestamp == nil && isPodReady(&pods[i]) {
+			ready++
+		}
+	}
+	if ready == 0 {
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityError,
+			Category:    "Endpoint",
+			Reason:      "NoReadyPods",
+			Message:     fmt.Sprintf("selector匹配到%d个Pod, 没有就绪的Pod", len(pods)),
+			Explanation: "只有Ready的Pod会作为Service的后端, 检查Pod的readinessProbe和容器状态, 可以使用Pod诊断接口查看具体原因。",
+		})
+	} else if ready < len(pods) {
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityInfo,
+			Category:    "Endpoint",
+			Reason:      "PodsNotReady",
+			Message:     fmt.Sprintf("selector匹配到%d个Pod, 其中%d个就绪", len(pods), ready),
+			Explanation: "未就绪的Pod不会接收流量, 滚动更新期间出现是正常的。",
+		})
+	}
+	if ready > 0 && detail.Ready == 0 {
+		add(dto.K8sDiagnosticFinding{
+			Severity:    k8s.SeverityWarning,
+			Category:    "Endpoint",
+			Reason:      "EndpointsNotSynced",
+			Message:     fmt.Sprintf("有%d个就绪的Pod, EndpointSlice中没有就绪的地址", ready),
+			Explanation: "EndpointSlice由kube-controller-manager维护, Pod刚就绪时可能有短暂延迟; 持续出现时检查controller-manager是否正常运行。",
+		})
+	}
+
+	for _, port := range svc.Spec.Ports {
+		checkTargetPort(port, pods, add)
+	}
+}
+
+// checkTargetPort 名称形式的targetPort在Pod中找不到时该Pod不会成为这个端口的后端;
+// 数字形式的targetPort没有声明也能访问, 但通常是端口配置错误
+func checkTargetPort(port corev1.ServicePort, pods []corev1.Pod, add func(dto.K8sDiagnosticFinding)) {
+	target := port.TargetPort
+	if target.Type == intstr.Int && target.IntVal == 0 {
+		target = intstr.FromInt(int(port.Port))
+	}
+
+	var missing []string
+	for i := range pods {
+		if !exposesPort(&pods[i], target, port.Protocol) {
+			missing = append(missing, pods[i].Name)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	portName := strconv.Itoa(int(port.Port))
+	if port.Name != "" {
+		portName = port.Name
+	}
+	finding := dto.K8sDiagnosticFinding{
+		Category: "Port",
+		Message:  fmt.Sprintf("端口%s的targetPort %s 没有在%d/%d个Pod的容器中声明: %s", portName, target.String(), len(missing), len(pods), strings.Join(missing, ", ")),
+	}
+	if target.Type == intstr.String {
+		finding.Severity = k8s.SeverityError
+		finding.Reason = "TargetPortNameNotFound"
+		finding.Explanation = fmt.Sprintf("targetPort使用名称时需要容器ports中有同名的端口(协议也要相同), 没有的Pod不会成为端口%s的后端。检查容器端口的name是否和targetPort一致。", portName)
+	} else {
+		finding.Severity = k8s.SeverityWarning
+		finding.Reason = "TargetPortNotDeclared"
+		finding.Explanation = "容器没有在ports中声明该端口, 如果进程没有监听这个端口, 连接会被拒绝。确认targetPort是应用实际监听的端口。"
+	}
+	add(finding)
+}
+
+func exposesPort(pod *corev1.Pod, target intstr.IntOrString, protocol corev1.Protocol) bool {
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			containerProtocol := containerPort.Protocol
+			if containerProtocol == "" {
+				containerProtocol = corev1.ProtocolTCP
+			}
+			if containerProtocol != protocol {
+				continue
+			}
+			if target.Type == intstr.String && containerPort.Name == target.StrVal {
+				return true
+			}
+			if target.Type == intstr.Int && containerPort.ContainerPort == target.IntVal {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ingressRefs 查找后端指向Service的Ingress规则, 引用了Service中不存在的端口时记录问题
+func ingressRefs(svc *corev1.Service, ingresses []ingressv1.Ingress, add func(dto.K8sDiagnosticFinding)) []dto.K8sSvcIngressRef {
+	refs := []dto.K8sSvcIngressRef{}
+	check := func(ingress *ingressv1.Ingress, backend *ingressv1.IngressBackend, ref dto.K8sSvcIngressRef) {
+		if backend == nil || backend.Service == nil || backend.Service.Name != svc.Name {
+			return
+		}
+		ref.Name = ingress.Name
+		if backend.Service.Port.Name != "" {
+			ref.Port = backend.Service.Port.Name
+		} else {
+			ref.Port = strconv.Itoa(int(backend.Service.Port.Number))
+		}
+		refs = append(refs, ref)
+
+		// ExternalName类型的Service由Ingress控制器直接转发到外部域名, 不检查端口
+		if svc.Spec.Type != corev1.ServiceTypeExternalName && !hasServicePort(svc, backend.Service.Port) {
+			add(dto.K8sDiagnosticFinding{
+				Severity:    k8s.SeverityError,
+				Category:    "Ingress",
+				Reason:      "IngressPortNotFound",
+				Message:     fmt.Sprintf("Ingress %s 的规则 %s%s 引用了Service不存在的端口%s", ingress.Name, ref.Host, ref.Path, ref.Port),
+				Explanation: "Ingress后端的端口需要是Service的port或端口名称, 而不是容器端口, 请求会返回503。",
+			})
+		}
+	}
+
+	for i := range ingresses {
+		ingress := &ingresses[i]
+		check(ingress, ingress.Spec.DefaultBackend, dto.K8sSvcIngressRef{Default: true})
+		for _, rule := range ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for j := range rule.HTTP.Paths {
+				check(ingress, &rule.HTTP.Paths[j].Backend, dto.K8sSvcIngressRef{Host: rule.Host, Path: rule.HTTP.Paths[j].Path})
+			}
+		}
+	}
+	return refs
+}
+
+func hasServicePort(svc *corev1.Service, port ingressv1.ServiceBackendPort) bool {
+	for _, servicePort := range svc.Spec.Ports {
+		if port.Name != "" && servicePort.Name == port.Name {
+			return true
+		}
+		if port.Name == "" && servicePort.Port == port.Number {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package svc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"soul/global"
+)
+
+// ResolvePortForwardTarget 与kubectl port-forward svc/name一样, 选择Service的一个就绪Pod, 并将Service端口转换为Pod端口
+func (s *Svc) ResolvePortForwardTarget(clusterName, name, namespace string, port int32) (string, int32, error) {
+	svc, err := s.GetSvcByName(clusterName, name, namespace)
+	if err != nil {
+		return "", 0, err
+	}
+	if len(svc.Spec.Selector) == 0 {
+		return "", 0, errors.New("Service没有selector, 无法选择Pod")
+	}
+
+	var servicePort *corev1.ServicePort
+	for i := range svc.Spec.Ports {
+		if svc.Spec.Ports[i].Port == port {
+			servicePort = &svc.Spec.Ports[i]
+			break
+		}
+	}
+	if servicePort == nil {
+		return "", 0, fmt.Errorf("Service没有端口%d", port)
+	}
+
+	pods, err := global.K8s.Use(clusterName).ClientSet.CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String(),
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning || !isPodReady(pod) {
+			continue
+		}
+		podPort, err := resolveTargetPort(pod, servicePort)
+		if err != nil {
+			return "", 0, err
+		}
+		return pod.Name, podPort, nil
+	}
+	return "", 0, errors.New("Service没有就绪的Pod")
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// resolveTargetPort targetPort为名称时在Pod的容器端口中查找
+func resolveTargetPort(pod *corev1.Pod, servicePort *corev1.ServicePort) (int32, error) {
+	switch {
+	case servicePort.TargetPort.Type == intstr.String && servicePort.TargetPort.StrVal != "":
+		for _, container := range pod.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				if containerPort.Name == servicePort.TargetPort.StrVal {
+					return containerPort.ContainerPort, nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("Pod %s 中没有名为%s的端口", pod.Name, servicePort.TargetPort.StrVal)
+	case servicePort.TargetPort.IntVal != 0:
+		return servicePort.TargetPort.IntVal, nil
+	}
+	return servicePort.Port, nil
+}
@@ -2,6 +2,8 @@ package svc
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -9,6 +11,7 @@ import (
 	"soul/apis/service/k8s"
 	"soul/apis/service/k8s/deployment"
 	"soul/global"
+	"soul/utils/diffutil"
 	"soul/utils/httputil"
 )
 
@@ -78,6 +81,9 @@ func (s *Svc) DeleteSvcByName(clusterName, name, namespace string) (err error) {
 
 func (s *Svc) CreateSimpleSvc(clusterName string, svcSimpleCreate *dto.K8sSvcSimpleCreate) (err error) {
 	svc, err := s.simpleSvcToService(clusterName, svcSimpleCreate)
+	if err != nil {
+		return err
+	}
 	_, err = global.K8s.Use(clusterName).ClientSet.CoreV1().Services(svc.Namespace).Create(context.TODO(), svc, metav1.CreateOptions{})
 	if err != nil {
 		return err
@@ -85,8 +91,9 @@ func (s *Svc) CreateSimpleSvc(clusterName string, svcSimpleCreate *dto.K8sSvcSim
 	return
 }
 
+// UpdateSimpleSvc 更新Service, 保留集群中已分配的clusterIP、nodePort等字段
 func (s *Svc) UpdateSimpleSvc(clusterName string, svcSimpleCreate *dto.K8sSvcSimpleCreate) (err error) {
-	svc, err := s.simpleSvcToService(clusterName, svcSimpleCreate)
+	svc, _, err := s.mergeLiveSvc(clusterName, svcSimpleCreate)
 	if err != nil {
 		return err
 	}
@@ -98,10 +105,104 @@ func (s *Svc) UpdateSimpleSvc(clusterName string, svcSimpleCreate *dto.K8sSvcSim
 	return
 }
 
+// DiffSimpleSvc 使用 dry-run 模拟更新, 返回集群中的对象和更新后对象的差异
+func (s *Svc) DiffSimpleSvc(clusterName string, svcSimpleCreate *dto.K8sSvcSimpleCreate) (*diffutil.Result, error) {
+	svc, live, err := s.mergeLiveSvc(clusterName, svcSimpleCreate)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := global.K8s.Use(clusterName).ClientSet.CoreV1().Services(svc.Namespace).Update(context.TODO(), svc, metav1.UpdateOptions{
+		DryRun: []string{metav1.DryRunAll},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return k8s.DiffObject("service", svc.Name, live, result)
+}
+
+// mergeLiveSvc 生成更新后的Service, 并从集群中的Service复制由apiserver分配或其他控制器维护的字段
+func (s *Svc) mergeLiveSvc(clusterName string, svcSimpleCreate *dto.K8sSvcSimpleCreate) (svc, live *corev1.Service, err error) {
+	svc, err = s.simpleSvcToService(clusterName, svcSimpleCreate)
+	if err != nil {
+		return nil, nil, err
+	}
+	live, err = s.GetSvcByName(clusterName, svc.Name, svc.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	svc.ResourceVersion = live.ResourceVersion
+	// 云厂商的LoadBalancer控制器等会写入注解, 合并后再覆盖请求中的注解
+	annotations := make(map[string]string, len(live.Annotations)+len(svc.Annotations))
+	for k, v := range live.Annotations {
+		annotations[k] = v
+	}
+	for k, v := range svc.Annotations {
+		annotations[k] = v
+	}
+	svc.Annotations = annotations
+
+	// ExternalName类型没有clusterIP, 从ExternalName改为其他类型时重新分配
+	if svc.Spec.Type != corev1.ServiceTypeExternalName && live.Spec.Type != corev1.ServiceTypeExternalName {
+		liveHeadless := live.Spec.ClusterIP == corev1.ClusterIPNone
+		if liveHeadless != (svc.Spec.ClusterIP == corev1.ClusterIPNone) {
+			return nil, nil, errors.New("clusterIP创建后不能修改, headless和非headless之间切换需要删除后重新创建Service")
+		}
+		svc.Spec.ClusterIP = live.Spec.ClusterIP
+		svc.Spec.ClusterIPs = live.Spec.ClusterIPs
+		svc.Spec.IPFamilies = live.Spec.IPFamilies
+		svc.Spec.IPFamilyPolicy = live.Spec.IPFamilyPolicy
+	}
+
+	// 没有指定nodePort的端口沿用已经分配的nodePort, 按名称匹配, 没有名称时按端口和协议匹配
+	if svc.Spec.Type == corev1.ServiceTypeNodePort || svc.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		for i := range svc.Spec.Ports {
+			port := &svc.Spec.Ports[i]
+			if port.NodePort != 0 {
+				continue
+			}
+			for _, livePort := range live.Spec.Ports {
+				if livePort.NodePort != 0 && sameServicePort(*port, livePort) {
+					port.NodePort = livePort.NodePort
+					break
+				}
+			}
+		}
+		if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && svc.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyTypeLocal {
+			svc.Spec.HealthCheckNodePort = live.Spec.HealthCheckNodePort
+		}
+	}
+
+	// 请求中没有以下字段, 沿用集群中的值, 避免更新时被清空. loadBalancerClass创建后不能修改
+	if svc.Spec.Type == corev1.ServiceTypeLoadBalancer && live.Spec.Type == corev1.ServiceTypeLoadBalancer {
+		svc.Spec.LoadBalancerClass = live.Spec.LoadBalancerClass
+		svc.Spec.LoadBalancerIP = live.Spec.LoadBalancerIP
+	}
+	if svc.Spec.Type != corev1.ServiceTypeExternalName {
+		svc.Spec.ExternalIPs = live.Spec.ExternalIPs
+	}
+	return svc, live, nil
+}
+
+func sameServicePort(port, livePort corev1.ServicePort) bool {
+	if port.Name != "" || livePort.Name != "" {
+		return port.Name == livePort.Name
+	}
+	return port.Port == livePort.Port && port.Protocol == livePort.Protocol
+}
+
 func (s *Svc) simpleSvcToService(clusterName string, svcSimpleCreate *dto.K8sSvcSimpleCreate) (*corev1.Service, error) {
-	svcSimpleCreate.Type = "ClusterIP"
+	if svcSimpleCreate.Type == "" {
+		svcSimpleCreate.Type = string(corev1.ServiceTypeClusterIP)
+	}
+	svcType := corev1.ServiceType(svcSimpleCreate.Type)
+	if err := validateSimpleSvc(svcSimpleCreate); err != nil {
+		return nil, err
+	}
 
-	if svcSimpleCreate.DeploymentName != "" {
+	if svcSimpleCreate.DeploymentName != "" && svcType != corev1.ServiceTypeExternalName {
 		d := deployment.Deployment{}
 		deploy, err := d.GetDeploymentByName(clusterName, svcSimpleCreate.DeploymentName, svcSimpleCreate.Namespace)
 		if err != nil {
@@ -112,29 +213,123 @@ func (s *Svc) simpleSvcToService(clusterName string, svcSimpleCreate *dto.K8sSvc
 
 	var ports []corev1.ServicePort
 	for _, port := range svcSimpleCreate.Ports {
+		// targetPort为空时与Service端口相同, 可以是端口号或容器中定义的端口名称
+		targetPort := intstr.FromInt(int(port.ContainerPort))
+		if port.TargetPort != "" {
+			targetPort = intstr.Parse(port.TargetPort)
+		}
+		protocol := corev1.Protocol(port.Protocol)
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
 		ports = append(ports, corev1.ServicePort{
-			Name:     port.Name,
-			Protocol: corev1.Protocol(port.Protocol),
-			Port:     port.ContainerPort,
-			TargetPort: intstr.IntOrString{
-				Type:   0,
-				IntVal: port.ContainerPort,
-			},
+			Name:       port.Name,
+			Protocol:   protocol,
+			Port:       port.ContainerPort,
+			TargetPort: targetPort,
+			NodePort:   port.NodePort,
 		})
 	}
 
+	annotations := map[string]string{}
+	for k, v := range svcSimpleCreate.Annotations {
+		annotations[k] = v
+	}
+	annotations["created-by"] = global.K8sManager
+
 	svc := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        svcSimpleCreate.Name,
 			Namespace:   svcSimpleCreate.Namespace,
 			Labels:      svcSimpleCreate.Labels,
-			Annotations: map[string]string{"created-by": global.K8sManager},
+			Annotations: annotations,
 		},
 		Spec: corev1.ServiceSpec{
-			Ports:    ports,
-			Selector: svcSimpleCreate.Selector,
-			Type:     corev1.ServiceType(svcSimpleCreate.Type),
+			Ports: ports,
+			Type:  svcType,
 		},
 	}
+
+	switch svcType {
+	case corev1.ServiceTypeExternalName:
+		svc.Spec.ExternalName = svcSimpleCreate.ExternalName
+		return svc, nil
+	case corev1.ServiceTypeClusterIP:
+		if svcSimpleCreate.Headless {
+			svc.Spec.ClusterIP = corev1.ClusterIPNone
+		}
+	case corev1.ServiceTypeLoadBalancer:
+		svc.Spec.LoadBalancerSourceRanges = svcSimpleCreate.LoadBalancerSourceRanges
+	}
+	if svcType == corev1.ServiceTypeNodePort || svcType == corev1.ServiceTypeLoadBalancer {
+		svc.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyType(svcSimpleCreate.ExternalTrafficPolicy)
+		if svc.Spec.ExternalTrafficPolicy == "" {
+			svc.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
+		}
+	}
+
+	svc.Spec.Selector = svcSimpleCreate.Selector
+	svc.Spec.SessionAffinity = corev1.ServiceAffinityNone
+	if svcSimpleCreate.SessionAffinity == string(corev1.ServiceAffinityClientIP) {
+		svc.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+		timeout := svcSimpleCreate.SessionAffinityTimeout
+		if timeout == 0 {
+			timeout = corev1.DefaultClientIPServiceAffinitySeconds
+		}
+		svc.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+			ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: &timeout},
+		}
+	}
 	return svc, nil
 }
+
+// validateSimpleSvc 检查binding无法表达的字段组合
+func validateSimpleSvc(svcSimpleCreate *dto.K8sSvcSimpleCreate) error {
+	svcType := corev1.ServiceType(svcSimpleCreate.Type)
+	if svcSimpleCreate.Headless && svcType != corev1.ServiceTypeClusterIP {
+		return errors.New("只有ClusterIP类型的Service可以设置为headless")
+	}
+	if svcSimpleCreate.ExternalName != "" && svcType != corev1.ServiceTypeExternalName {
+		return errors.New("只有ExternalName类型的Service可以设置externalName")
+	}
+	if svcSimpleCreate.ExternalTrafficPolicy != "" && svcType != corev1.ServiceTypeNodePort && svcType != corev1.ServiceTypeLoadBalancer {
+		return errors.New("只有NodePort和LoadBalancer类型的Service可以设置externalTrafficPolicy")
+	}
+	if len(svcSimpleCreate.LoadBalancerSourceRanges) > 0 && svcType != corev1.ServiceTypeLoadBalancer {
+		return errors.New("只有LoadBalancer类型的Service可以设置loadBalancerSourceRanges")
+	}
+	if svcSimpleCreate.SessionAffinityTimeout != 0 && svcSimpleCreate.SessionAffinity != string(corev1.ServiceAffinityClientIP) {
+		return errors.New("sessionAffinityTimeout只在sessionAffinity为ClientIP时有效")
+	}
+	if svcType == corev1.ServiceTypeExternalName {
+		if svcSimpleCreate.SessionAffinity == string(corev1.ServiceAffinityClientIP) {
+			return errors.New("ExternalName类型的Service不支持会话保持")
+		}
+		return nil
+	}
+
+	if len(svcSimpleCreate.Ports) == 0 && !svcSimpleCreate.Headless {
+		return errors.New("ports不能为空")
+	}
+	for _, port := range svcSimpleCreate.Ports {
+		if port.ContainerPort <= 0 || port.ContainerPort > 65535 {
+			return fmt.Errorf("端口%d不合法", port.ContainerPort)
+		}
+		if port.NodePort != 0 && svcType != corev1.ServiceTypeNodePort && svcType != corev1.ServiceTypeLoadBalancer {
+			return errors.New("只有NodePort和LoadBalancer类型的Service可以指定nodePort")
+		}
+		if port.TargetPort != "" {
+			if target := intstr.Parse(port.TargetPort); target.Type == intstr.Int && (target.IntVal <= 0 || target.IntVal > 65535) {
+				return fmt.Errorf("targetPort %s不合法", port.TargetPort)
+			}
+		}
+	}
+	if len(svcSimpleCreate.Ports) > 1 {
+		for _, port := range svcSimpleCreate.Ports {
+			if port.Name == "" {
+				return errors.New("有多个端口时每个端口都需要设置name")
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package terminalrecord
+
+import (
+	"errors"
+	"os"
+	"soul/apis/dao"
+	"soul/apis/dto"
+	"soul/model"
+	"soul/utils/httputil"
+)
+
+type TerminalRecord struct{}
+
+func (t *TerminalRecord) ListTerminalRecord(query dto.K8sTerminalRecordQuery) *httputil.PageResp {
+	records, total := dao.K8sTerminalRecord.ListTerminalRecord(query)
+	return &httputil.PageResp{
+		Limit: query.Limit,
+		Page:  query.Page,
+		Total: int(total),
+		Items: records,
+	}
+}
+
+// GetTerminalRecordById userId不为0时只能获取该用户的录像
+func (t *TerminalRecord) GetTerminalRecordById(id, userId uint) (*model.K8sTerminalRecord, error) {
+	record := dao.K8sTerminalRecord.GetTerminalRecordById(id)
+	if record == nil || (userId != 0 && record.UserID != userId) {
+		return nil, errors.New("录像不存在")
+	}
+	return record, nil
+}
+
+// OpenTerminalRecord 打开asciicast录像文件用于回放, 会话进行中时返回已经录制的部分
+func (t *TerminalRecord) OpenTerminalRecord(id, userId uint) (*model.K8sTerminalRecord, *os.File, error) {
+	record, err := t.GetTerminalRecordById(id, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.Open(record.FilePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, errors.New("录像文件不存在")
+		}
+		return nil, nil, err
+	}
+	return record, file, nil
+}
+
+// DeleteTerminalRecord 删除录像记录和文件, 进行中的会话不能删除
+func (t *TerminalRecord) DeleteTerminalRecord(id uint) error {
+	record, err := t.GetTerminalRecordById(id, 0)
+	if err != nil {
+		return err
+	}
+	if record.EndedAt == nil {
+		return errors.New("会话进行中, 不能删除录像")
+	}
+
+	if err = os.Remove(record.FilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return dao.K8sTerminalRecord.DeleteTerminalRecordById(id)
+}
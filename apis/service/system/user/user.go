@@ -165,6 +165,20 @@ func (u *User) Info(userId uint) (*dto.SystemUserInfo, bool) {
 	return userinfo.FromModel(user), true
 }
 
+// IsAdmin 用户是否拥有admin角色
+func (u *User) IsAdmin(userId uint) bool {
+	user := dao.SystemUser.GetUserById(userId)
+	if user == nil {
+		return false
+	}
+	for _, role := range user.RolesToList() {
+		if role == "admin" {
+			return true
+		}
+	}
+	return false
+}
+
 func (u *User) AssignRole(roleId, userId uint) error {
 	_, exists := u.Info(userId)
 	if !exists {
@@ -1,11 +1,13 @@
 package cmd
 
 import (
+	"soul/apis/service"
 	"soul/global"
 	"soul/internal/config"
 	"soul/internal/database"
 	"soul/internal/k8s"
 	"soul/internal/logger"
+	"soul/internal/scheduler"
 	"soul/internal/server"
 	"soul/internal/tasks"
 )
@@ -28,8 +30,14 @@ func init() {
 	// 初始化client-go
 	global.K8s = k8s.InitClient(global.DB, global.Config.KubeConfig, global.Config.InCluster)
 
+	// 清理上次运行残留的节点调试Pod, 需要在接收请求前完成, 避免删除新会话的Pod
+	service.K8sPod.CleanNodeDebugPods()
+
 	// 初始化后台任务
 	tasks.InitTasks()
+
+	// 加载定时扩缩容策略
+	scheduler.InitScheduler()
 }
 
 func Execute() {
@@ -1,14 +1,20 @@
 package config
 
 type Configuration struct {
-	AppName    string   `yaml:"appName" mapstructure:"appName"`
-	Listen     string   `yaml:"listen" mapstructure:"listen"`
-	Port       int      `yaml:"port" mapstructure:"port"`
-	Log        Log      `yaml:"log" mapstructure:"log"`
-	Env        string   `yaml:"env" mapstructure:"env"`
-	Config     string   `yaml:"config" mapstructure:"config"`
-	Database   Database `yaml:"database" mapstructure:"database"`
-	Jwt        Jwt      `yaml:"jwt" mapstructure:"jwt"`
-	KubeConfig string   `yaml:"kubeConfig" mapstructure:"kubeConfig"`
-	InCluster  bool     `yaml:"inCluster" mapstructure:"inCluster"`
+	AppName     string      `yaml:"appName" mapstructure:"appName"`
+	Listen      string      `yaml:"listen" mapstructure:"listen"`
+	Port        int         `yaml:"port" mapstructure:"port"`
+	Log         Log         `yaml:"log" mapstructure:"log"`
+	Env         string      `yaml:"env" mapstructure:"env"`
+	Config      string      `yaml:"config" mapstructure:"config"`
+	Database    Database    `yaml:"database" mapstructure:"database"`
+	Jwt         Jwt         `yaml:"jwt" mapstructure:"jwt"`
+	KubeConfig  string      `yaml:"kubeConfig" mapstructure:"kubeConfig"`
+	InCluster   bool        `yaml:"inCluster" mapstructure:"inCluster"`
+	Helm        Helm        `yaml:"helm" mapstructure:"helm"`
+	Terminal    Terminal    `yaml:"terminal" mapstructure:"terminal"`
+	PodFile     PodFile     `yaml:"podFile" mapstructure:"podFile"`
+	PortForward PortForward `yaml:"portForward" mapstructure:"portForward"`
+	Debug       Debug       `yaml:"debug" mapstructure:"debug"`
+	Exec        Exec        `yaml:"exec" mapstructure:"exec"`
 }
@@ -0,0 +1,9 @@
+package config
+
+import "time"
+
+type Debug struct {
+	Image         string        `yaml:"image" mapstructure:"image"`                 // 临时调试容器和节点调试Pod默认使用的镜像
+	NodeNamespace string        `yaml:"nodeNamespace" mapstructure:"nodeNamespace"` // 节点调试Pod所在的namespace
+	StartTimeout  time.Duration `yaml:"startTimeout" mapstructure:"startTimeout"`   // 等待调试容器启动(包括拉取镜像)的时间
+}
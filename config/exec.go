@@ -0,0 +1,10 @@
+package config
+
+import "time"
+
+type Exec struct {
+	DefaultTimeout time.Duration `yaml:"defaultTimeout" mapstructure:"defaultTimeout"` // 请求中没有指定超时时间时使用
+	MaxTimeout     time.Duration `yaml:"maxTimeout" mapstructure:"maxTimeout"`         // 请求中允许指定的最长超时时间
+	MaxOutputSize  int64         `yaml:"maxOutputSize" mapstructure:"maxOutputSize"`   // stdout和stderr各自保留的大小上限, 单位K
+	Concurrency    int           `yaml:"concurrency" mapstructure:"concurrency"`       // 批量执行时同时执行的Pod数量
+}
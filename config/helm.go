@@ -0,0 +1,6 @@
+package config
+
+type Helm struct {
+	ChartDir     string `yaml:"chartDir" mapstructure:"chartDir"`
+	MaxChartSize int64  `yaml:"maxChartSize" mapstructure:"maxChartSize"` // 上传chart包的最大大小, 单位M
+}
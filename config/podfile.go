@@ -0,0 +1,6 @@
+package config
+
+type PodFile struct {
+	MaxUploadSize   int64 `yaml:"maxUploadSize" mapstructure:"maxUploadSize"`     // 上传到容器的文件大小上限, 单位M
+	MaxDownloadSize int64 `yaml:"maxDownloadSize" mapstructure:"maxDownloadSize"` // 从容器下载的文件大小上限(tar包大小), 单位M
+}
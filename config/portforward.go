@@ -0,0 +1,8 @@
+package config
+
+import "time"
+
+type PortForward struct {
+	IdleTimeout time.Duration `yaml:"idleTimeout" mapstructure:"idleTimeout"` // 没有请求时自动关闭的时间
+	MaxDuration time.Duration `yaml:"maxDuration" mapstructure:"maxDuration"` // 端口转发会话最长时间
+}
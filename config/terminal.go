@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+type Terminal struct {
+	RecordDir          string        `yaml:"recordDir" mapstructure:"recordDir"`                   // 终端录像(asciicast v2)保存目录
+	MaxSessionsPerUser int           `yaml:"maxSessionsPerUser" mapstructure:"maxSessionsPerUser"` // 每个用户同时打开的终端数量上限, 0为不限制
+	IdleTimeout        time.Duration `yaml:"idleTimeout" mapstructure:"idleTimeout"`               // 没有输入时自动断开的时间, 0为不限制
+	MaxDuration        time.Duration `yaml:"maxDuration" mapstructure:"maxDuration"`               // 会话最长时间, 0为不限制
+	WarnBefore         time.Duration `yaml:"warnBefore" mapstructure:"warnBefore"`                 // 断开前多久发出提醒
+}
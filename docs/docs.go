@@ -1,4 +1,5 @@
-// Code generated by swaggo/swag. DO NOT EDIT
+// Package docs GENERATED BY SWAG; DO NOT EDIT
+// This file was generated by swaggo/swag
 package docs
 
 import "github.com/swaggo/swag"
@@ -9,26 +10,23 @@ const docTemplate = `{
     "info": {
         "description": "{{escape .Description}}",
         "title": "{{.Title}}",
-        "contact": {
-            "name": "SoulChild",
-            "url": "https://github.com/SoulChildTc/HandoverCloud"
-        },
+        "contact": {},
         "version": "{{.Version}}"
     },
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/k8s/cluster/": {
+        "/api/v1/k8s/apptemplate/": {
             "get": {
-                "description": "获取集群列表",
+                "description": "获取应用模板列表",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Cluster"
+                    "AppTemplate"
                 ],
-                "summary": "获取集群列表",
+                "summary": "获取应用模板列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -40,77 +38,73 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回集群列表",
+                        "description": "成功返回模板列表",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/k8s/cluster/{clusterName}/": {
-            "get": {
-                "description": "获取集群信息",
+            },
+            "post": {
+                "description": "创建应用模板, 只有管理员可以操作",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Cluster"
+                    "AppTemplate"
                 ],
-                "summary": "获取集群信息",
+                "summary": "创建应用模板",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "模板信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sAppTemplateCreate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回集群信息",
+                        "description": "成功返回模板",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "更新集群",
+            }
+        },
+        "/api/v1/k8s/apptemplate/{id}": {
+            "get": {
+                "description": "获取应用模板",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Cluster"
+                    "AppTemplate"
                 ],
-                "summary": "更新集群",
+                "summary": "获取应用模板",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
+                        "type": "integer",
+                        "description": "模板ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "description": "集群信息",
-                        "name": "clusterInfo",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.K8sClusterInfo"
-                        }
-                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
@@ -121,51 +115,54 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回集群信息",
+                        "description": "成功返回模板",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "添加集群",
+            "put": {
+                "description": "更新应用模板, 参数定义或模板内容变化时生成新版本, 只有管理员可以操作",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Cluster"
+                    "AppTemplate"
                 ],
-                "summary": "添加集群",
+                "summary": "更新应用模板",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
+                        "type": "integer",
+                        "description": "模板ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "description": "集群信息",
-                        "name": "clusterInfo",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.K8sClusterInfo"
-                        }
-                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "模板信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sAppTemplateCreate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回集群信息",
+                        "description": "成功返回模板",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
@@ -173,20 +170,20 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "删除集群",
+                "description": "删除应用模板, 已被实例化的模板不能删除, 只有管理员可以操作",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Cluster"
+                    "AppTemplate"
                 ],
-                "summary": "删除集群",
+                "summary": "删除应用模板",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
+                        "type": "integer",
+                        "description": "模板ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
@@ -200,42 +197,30 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回集群信息",
-                        "schema": {
-                            "$ref": "#/definitions/httputil.ResponseBody"
-                        }
+                        "description": "成功返回"
                     }
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/deployment": {
-            "put": {
-                "description": "使用原生 deployment api 对象更新",
+        "/api/v1/k8s/apptemplate/{id}/versions": {
+            "get": {
+                "description": "获取应用模板的所有版本",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Deployment"
+                    "AppTemplate"
                 ],
-                "summary": "使用原生 deployment api 对象更新",
+                "summary": "获取应用模板的所有版本",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
+                        "type": "integer",
+                        "description": "模板ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "description": "Deployment Api Object",
-                        "name": "deploymentName",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
-                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
@@ -244,33 +229,33 @@ const docTemplate = `{
                         "required": true
                     }
                 ],
-                "responses": {}
+                "responses": {
+                    "200": {
+                        "description": "成功返回模板版本列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
             }
         },
-        "/api/v1/k8s/{clusterName}/deployment/": {
+        "/api/v1/k8s/cluster/": {
             "get": {
-                "description": "获取Deployment列表",
+                "description": "获取集群列表",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Deployment"
+                    "Cluster"
                 ],
-                "summary": "获取Deployment列表",
+                "summary": "获取集群列表",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Namespace 不填为全部",
-                        "name": "namespace",
-                        "in": "path"
+                        "type": "boolean",
+                        "description": "强制查询,不走缓存",
+                        "name": "force",
+                        "in": "query"
                     },
                     {
                         "type": "string",
@@ -278,48 +263,29 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "根据Deployment名字模糊查询",
-                        "name": "filter",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "一页获取多少条数据,默认十条",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "获取第几页的数据,默认第一页",
-                        "name": "page",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回Deployment列表",
+                        "description": "成功返回集群列表",
                         "schema": {
-                            "$ref": "#/definitions/httputil.PageResponseBody"
+                            "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "创建 Deployment",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/v1/k8s/cluster/{clusterName}/": {
+            "get": {
+                "description": "获取集群信息",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Deployment"
+                    "Cluster"
                 ],
-                "summary": "创建 Deployment",
+                "summary": "获取集群信息",
                 "parameters": [
                     {
                         "type": "string",
@@ -336,36 +302,31 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Deployment对象",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.K8sDeploymentCreate"
-                        }
+                        "type": "boolean",
+                        "description": "强制查询,不走缓存",
+                        "name": "force",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回",
+                        "description": "成功返回集群信息",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/k8s/{clusterName}/deployment/{namespace}": {
-            "get": {
-                "description": "获取Deployment列表",
+            },
+            "put": {
+                "description": "更新集群",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Deployment"
+                    "Cluster"
                 ],
-                "summary": "获取Deployment列表",
+                "summary": "更新集群",
                 "parameters": [
                     {
                         "type": "string",
@@ -375,10 +336,13 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Namespace 不填为全部",
-                        "name": "namespace",
-                        "in": "path"
+                        "description": "集群信息",
+                        "name": "clusterInfo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sClusterInfo"
+                        }
                     },
                     {
                         "type": "string",
@@ -386,47 +350,27 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "根据Deployment名字模糊查询",
-                        "name": "filter",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "一页获取多少条数据,默认十条",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "获取第几页的数据,默认第一页",
-                        "name": "page",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回Deployment列表",
+                        "description": "成功返回集群信息",
                         "schema": {
-                            "$ref": "#/definitions/httputil.PageResponseBody"
+                            "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}": {
-            "get": {
-                "description": "获取Deployment信息",
+            },
+            "post": {
+                "description": "添加集群",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Deployment"
+                    "Cluster"
                 ],
-                "summary": "获取Deployment信息",
+                "summary": "添加集群",
                 "parameters": [
                     {
                         "type": "string",
@@ -436,18 +380,13 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "deployment名称",
-                        "name": "deploymentName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Namespace",
-                        "name": "namespace",
-                        "in": "path",
-                        "required": true
+                        "description": "集群信息",
+                        "name": "clusterInfo",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sClusterInfo"
+                        }
                     },
                     {
                         "type": "string",
@@ -459,7 +398,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回Deployment信息",
+                        "description": "成功返回集群信息",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
@@ -467,15 +406,15 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "删除 Deployment",
+                "description": "删除集群",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Deployment"
+                    "Cluster"
                 ],
-                "summary": "删除 Deployment",
+                "summary": "删除集群",
                 "parameters": [
                     {
                         "type": "string",
@@ -484,26 +423,6 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "string",
-                        "description": "deployment名称",
-                        "name": "deploymentName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Namespace",
-                        "name": "namespace",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "是否强制删除",
-                        "name": "force",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
@@ -514,7 +433,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回",
+                        "description": "成功返回集群信息",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
@@ -522,62 +441,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}/image": {
-            "put": {
-                "description": "修改 Deployment 容器镜像",
+        "/api/v1/k8s/helmchart/": {
+            "get": {
+                "description": "获取本地chart仓库中的chart列表",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Deployment"
+                    "Helm"
                 ],
-                "summary": "修改 Deployment 容器镜像",
+                "summary": "获取本地chart列表",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deployment名称",
-                        "name": "deploymentName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Namespace",
-                        "name": "namespace",
-                        "in": "path",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "新的容器镜像,只更新第一个容器时 name参数可忽略",
-                        "name": "container",
-                        "in": "body",
-                        "required": true,
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回chart列表",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object",
-                                "required": [
-                                    "image"
-                                ],
-                                "properties": {
-                                    "image": {
-                                        "type": "string"
-                                    },
-                                    "name": {
-                                        "type": "string"
-                                    }
-                                }
-                            }
+                            "$ref": "#/definitions/httputil.ResponseBody"
                         }
-                    },
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/portforward/": {
+            "get": {
+                "description": "获取当前的端口转发会话, 管理员可以看到所有用户的会话, 其他用户只能看到自己的会话",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "获取端口转发会话列表",
+                "parameters": [
                     {
                         "type": "string",
                         "description": "Authorization token",
@@ -586,41 +491,75 @@ const docTemplate = `{
                         "required": true
                     }
                 ],
-                "responses": {}
+                "responses": {
+                    "200": {
+                        "description": "成功返回会话列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
             }
         },
-        "/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}/pods": {
+        "/api/v1/k8s/portforward/record": {
             "get": {
-                "description": "获取 Deployment 管理的 Pod 信息",
+                "description": "分页查询端口转发审计记录, podName为模糊匹配, 时间为RFC3339格式; 非管理员只能查询自己的记录",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Deployment"
+                    "Pod"
                 ],
-                "summary": "获取 Deployment 管理的 Pod 信息",
+                "summary": "查询端口转发审计记录",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster Name",
+                        "description": "集群名称",
                         "name": "clusterName",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Deployment名称",
-                        "name": "deploymentName",
-                        "in": "path",
-                        "required": true
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Namespace",
-                        "name": "namespace",
-                        "in": "path",
-                        "required": true
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "用户名",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "会话开始时间不早于",
+                        "name": "startTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "会话开始时间不晚于",
+                        "name": "endTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "分页大小,默认10",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "获取第几页的数据,默认第一页",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
                         "type": "string",
@@ -630,42 +569,28 @@ const docTemplate = `{
                         "required": true
                     }
                 ],
-                "responses": {}
+                "responses": {
+                    "200": {
+                        "description": "成功返回审计记录",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.PageResponseBody"
+                        }
+                    }
+                }
             }
         },
-        "/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}/restart": {
-            "put": {
-                "description": "重启 Deployment 管理的 Pod",
+        "/api/v1/k8s/terminal/": {
+            "get": {
+                "description": "获取当前打开的终端会话, 管理员可以看到所有用户的会话, 其他用户只能看到自己的会话",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Deployment"
+                    "Pod"
                 ],
-                "summary": "重启 Deployment 管理的 Pod",
+                "summary": "获取终端会话列表",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deployment名称",
-                        "name": "deploymentName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Namespace",
-                        "name": "namespace",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
@@ -674,51 +599,35 @@ const docTemplate = `{
                         "required": true
                     }
                 ],
-                "responses": {}
+                "responses": {
+                    "200": {
+                        "description": "成功返回会话列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
             }
         },
-        "/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}/scale": {
-            "put": {
-                "description": "修改 Deployment 副本数",
+        "/api/v1/k8s/terminal/{sessionId}": {
+            "delete": {
+                "description": "强制结束终端会话, 管理员可以结束任意用户的会话, 其他用户只能结束自己的会话",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Deployment"
+                    "Pod"
                 ],
-                "summary": "修改 Deployment 副本数",
+                "summary": "强制结束终端会话",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deployment名称",
-                        "name": "deploymentName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Namespace",
-                        "name": "namespace",
+                        "description": "会话ID",
+                        "name": "sessionId",
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "description": "副本数",
-                        "name": "replicas",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
-                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
@@ -727,50 +636,73 @@ const docTemplate = `{
                         "required": true
                     }
                 ],
-                "responses": {}
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
             }
         },
-        "/api/v1/k8s/{clusterName}/ingress/": {
+        "/api/v1/k8s/terminalrecord/": {
             "get": {
-                "description": "获取 Ingress 列表",
+                "description": "分页查询终端录像, podName为模糊匹配, 时间为RFC3339格式; 非管理员只能查询自己的录像",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Ingress"
+                    "TerminalRecord"
                 ],
-                "summary": "获取 Ingress 列表",
+                "summary": "查询终端录像",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster Name",
+                        "description": "集群名称",
                         "name": "clusterName",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Namespace 不填为全部",
+                        "description": "Namespace",
                         "name": "namespace",
-                        "in": "path"
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Authorization token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "根据 Ingress 名字模糊查询",
-                        "name": "filter",
+                        "description": "容器名称",
+                        "name": "containerName",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "一页获取多少条数据,默认十条",
+                        "description": "用户名",
+                        "name": "username",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "会话开始时间不早于",
+                        "name": "startTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "会话开始时间不晚于",
+                        "name": "endTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "分页大小,默认10",
                         "name": "limit",
                         "in": "query"
                     },
@@ -779,40 +711,41 @@ const docTemplate = `{
                         "description": "获取第几页的数据,默认第一页",
                         "name": "page",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回 Ingress 列表",
+                        "description": "成功返回录像列表",
                         "schema": {
                             "$ref": "#/definitions/httputil.PageResponseBody"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "更新简单 Ingress",
+            }
+        },
+        "/api/v1/k8s/terminalrecord/{id}": {
+            "get": {
+                "description": "获取终端录像信息, 非管理员只能获取自己的录像",
                 "produces": [
-                    "application/json",
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Ingress"
+                    "TerminalRecord"
                 ],
-                "summary": "更新简单 Ingress",
+                "summary": "获取终端录像信息",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
+                        "type": "integer",
+                        "description": "录像ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
@@ -822,49 +755,32 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "K8sIngressSimpleCreate 对象",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.K8sIngressSimpleCreate"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回",
+                        "description": "成功返回录像信息",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "创建简单 Ingress ,仅支持一个路由前缀,支持多个Host, 对应一个Service",
+            "delete": {
+                "description": "删除终端录像记录和文件, 只有管理员可以删除",
                 "produces": [
-                    "application/json",
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Ingress"
+                    "TerminalRecord"
                 ],
-                "summary": "创建简单 Ingress",
+                "summary": "删除终端录像",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
+                        "type": "integer",
+                        "description": "录像ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
@@ -874,20 +790,11 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "K8sIngressSimpleCreate 对象",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.K8sIngressSimpleCreate"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回",
+                        "description": "删除成功",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
@@ -895,30 +802,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/ingress/{namespace}": {
+        "/api/v1/k8s/terminalrecord/{id}/cast": {
             "get": {
-                "description": "获取 Ingress 列表",
+                "description": "获取asciicast v2格式的录像内容, 可以直接交给asciinema-player回放; download=true时以附件下载; 非管理员只能回放自己的录像",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
                     "K8s",
-                    "Ingress"
+                    "TerminalRecord"
                 ],
-                "summary": "获取 Ingress 列表",
+                "summary": "回放终端录像",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
+                        "type": "integer",
+                        "description": "录像ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Namespace 不填为全部",
-                        "name": "namespace",
-                        "in": "path"
+                        "type": "boolean",
+                        "description": "以附件下载",
+                        "name": "download",
+                        "in": "query"
                     },
                     {
                         "type": "string",
@@ -926,47 +833,29 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "根据 Ingress 名字模糊查询",
-                        "name": "filter",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "一页获取多少条数据,默认十条",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "获取第几页的数据,默认第一页",
-                        "name": "page",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回 Ingress 列表",
+                        "description": "asciicast录像",
                         "schema": {
-                            "$ref": "#/definitions/httputil.PageResponseBody"
+                            "type": "file"
                         }
                     }
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/ingress/{namespace}/{ingressName}": {
+        "/api/v1/k8s/{clusterName}/application/": {
             "get": {
-                "description": "获取 Ingress 信息",
+                "description": "获取应用列表",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Ingress"
+                    "Application"
                 ],
-                "summary": "获取 Ingress 信息",
+                "summary": "获取应用列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -977,17 +866,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ingress名称",
-                        "name": "ingressName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Namespace",
+                        "description": "Namespace 不填为全部",
                         "name": "namespace",
-                        "in": "path",
-                        "required": true
+                        "in": "path"
                     },
                     {
                         "type": "string",
@@ -999,23 +880,26 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回 Ingress 信息",
+                        "description": "成功返回应用列表",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "删除 Ingress",
+            "post": {
+                "description": "创建应用, 同时创建同名的Deployment、根据容器端口生成的Service和可选的Ingress",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Ingress"
+                    "Application"
                 ],
-                "summary": "删除 Ingress",
+                "summary": "创建应用",
                 "parameters": [
                     {
                         "type": "string",
@@ -1024,45 +908,44 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "string",
-                        "description": "Ingress名称",
-                        "name": "ingressName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Namespace",
-                        "name": "namespace",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "应用信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sApplicationCreate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回"
+                        "description": "成功返回应用",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/namespace/": {
+        "/api/v1/k8s/{clusterName}/application/{namespace}": {
             "get": {
-                "description": "获取 Namespace 列表",
+                "description": "获取应用列表",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "K8s"
+                    "K8s",
+                    "Application"
                 ],
-                "summary": "获取 Namespace 列表",
+                "summary": "获取应用列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -1071,54 +954,41 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
+                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "根据Namespace名字模糊查询",
-                        "name": "filter",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "一页获取多少条数据,默认十条",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "获取第几页的数据,默认第一页",
-                        "name": "page",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回Namespace列表",
+                        "description": "成功返回应用列表",
                         "schema": {
-                            "$ref": "#/definitions/httputil.PageResponseBody"
+                            "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "创建 Namespace",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/v1/k8s/{clusterName}/application/{namespace}/{appName}": {
+            "get": {
+                "description": "获取应用详情, 包含应用下所有Deployment、Service、Ingress的状态",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Namespace"
+                    "Application"
                 ],
-                "summary": "创建 Namespace",
+                "summary": "获取应用详情",
                 "parameters": [
                     {
                         "type": "string",
@@ -1127,44 +997,47 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "应用名称",
+                        "name": "appName",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Namespace对象",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回",
+                        "description": "成功返回应用详情",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/k8s/{clusterName}/namespace/{namespaceName}": {
-            "get": {
-                "description": "获取 Namespace 信息",
+            },
+            "delete": {
+                "description": "删除应用, 级联删除应用下所有Deployment、Service和Ingress",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Namespace"
+                    "Application"
                 ],
-                "summary": "获取 Namespace 信息",
+                "summary": "删除应用",
                 "parameters": [
                     {
                         "type": "string",
@@ -1175,8 +1048,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Namespace名称",
-                        "name": "namespaceName",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "应用名称",
+                        "name": "appName",
                         "in": "path",
                         "required": true
                     },
@@ -1190,24 +1070,22 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回 Namespace 信息",
-                        "schema": {
-                            "$ref": "#/definitions/httputil.ResponseBody"
-                        }
+                        "description": "成功返回"
                     }
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/namespace/{namespaceName}/": {
-            "delete": {
-                "description": "删除Namespace",
+        "/api/v1/k8s/{clusterName}/deployment": {
+            "put": {
+                "description": "使用原生 deployment api 对象更新",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "K8s"
+                    "K8s",
+                    "Deployment"
                 ],
-                "summary": "删除Namespace",
+                "summary": "使用原生 deployment api 对象更新",
                 "parameters": [
                     {
                         "type": "string",
@@ -1217,18 +1095,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Namespace名称",
-                        "name": "namespaceName",
-                        "in": "path",
-                        "required": true
+                        "description": "Deployment Api Object",
+                        "name": "deploymentName",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     },
                     {
-                        "type": "string",
-                        "description": "Namespace",
-                        "name": "namespace",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "只返回 dry-run 更新后的差异, 不实际更新",
+                        "name": "diff",
+                        "in": "query"
                     },
                     {
                         "type": "string",
@@ -1238,24 +1117,20 @@ const docTemplate = `{
                         "required": true
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "成功返回"
-                    }
-                }
+                "responses": {}
             }
         },
-        "/api/v1/k8s/{clusterName}/pod/": {
+        "/api/v1/k8s/{clusterName}/deployment/": {
             "get": {
-                "description": "获取Pod列表",
+                "description": "获取Deployment列表",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Pod"
+                    "Deployment"
                 ],
-                "summary": "获取Pod列表",
+                "summary": "获取Deployment列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -1279,7 +1154,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "根据Pod名字模糊查询",
+                        "description": "根据Deployment名字模糊查询",
                         "name": "filter",
                         "in": "query"
                     },
@@ -1298,25 +1173,72 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回Pod列表",
+                        "description": "成功返回Deployment列表",
                         "schema": {
                             "$ref": "#/definitions/httputil.PageResponseBody"
                         }
                     }
                 }
+            },
+            "post": {
+                "description": "创建 Deployment, 通过containers和initContainers定义容器. containers为空时兼容旧版本的单容器字段image、imagePullSecret、cpu、memory、containerPort、httpHealthCheck, 生成一个与Deployment同名的容器",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Deployment"
+                ],
+                "summary": "创建 Deployment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Deployment对象",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sDeploymentCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
             }
         },
-        "/api/v1/k8s/{clusterName}/pod/{namespace}": {
+        "/api/v1/k8s/{clusterName}/deployment/{namespace}": {
             "get": {
-                "description": "获取Pod列表",
+                "description": "获取Deployment列表",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Pod"
+                    "Deployment"
                 ],
-                "summary": "获取Pod列表",
+                "summary": "获取Deployment列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -1340,7 +1262,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "根据Pod名字模糊查询",
+                        "description": "根据Deployment名字模糊查询",
                         "name": "filter",
                         "in": "query"
                     },
@@ -1359,7 +1281,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回Pod列表",
+                        "description": "成功返回Deployment列表",
                         "schema": {
                             "$ref": "#/definitions/httputil.PageResponseBody"
                         }
@@ -1367,17 +1289,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}": {
+        "/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}": {
             "get": {
-                "description": "获取Pod信息",
+                "description": "获取Deployment信息",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Pod"
+                    "Deployment"
                 ],
-                "summary": "获取Pod信息",
+                "summary": "获取Deployment信息",
                 "parameters": [
                     {
                         "type": "string",
@@ -1388,8 +1310,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Pod名称",
-                        "name": "podName",
+                        "description": "deployment名称",
+                        "name": "deploymentName",
                         "in": "path",
                         "required": true
                     },
@@ -1410,7 +1332,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回Pod信息",
+                        "description": "成功返回Deployment信息, metrics为所有Pod资源使用量的合计, metrics-server不可用时为null",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
@@ -1418,15 +1340,15 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "删除Pod",
+                "description": "删除 Deployment",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Pod"
+                    "Deployment"
                 ],
-                "summary": "删除Pod",
+                "summary": "删除 Deployment",
                 "parameters": [
                     {
                         "type": "string",
@@ -1437,8 +1359,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Pod名称",
-                        "name": "podName",
+                        "description": "deployment名称",
+                        "name": "deploymentName",
                         "in": "path",
                         "required": true
                     },
@@ -1449,6 +1371,12 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "boolean",
+                        "description": "是否强制删除",
+                        "name": "force",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
@@ -1459,22 +1387,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回"
+                        "description": "成功返回",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/containers": {
+        "/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}/diagnose": {
             "get": {
-                "description": "获取Pod容器信息",
+                "description": "诊断Deployment的状态和所有Pod无法正常运行的原因",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Pod"
+                    "Deployment"
                 ],
-                "summary": "获取Pod容器信息",
+                "summary": "Deployment诊断",
                 "parameters": [
                     {
                         "type": "string",
@@ -1485,8 +1416,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Pod名称",
-                        "name": "podName",
+                        "description": "Deployment名称",
+                        "name": "deploymentName",
                         "in": "path",
                         "required": true
                     },
@@ -1505,20 +1436,30 @@ const docTemplate = `{
                         "required": true
                     }
                 ],
-                "responses": {}
+                "responses": {
+                    "200": {
+                        "description": "成功返回诊断结果",
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sDeploymentDiagnosis"
+                        }
+                    }
+                }
             }
         },
-        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/exec": {
-            "get": {
-                "description": "获取 exec sessionId",
+        "/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}/exec": {
+            "post": {
+                "description": "在Deployment的所有Pod中并行执行同一个非交互命令, 返回每个Pod的stdout、stderr和退出码",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Pod"
+                    "Deployment"
                 ],
-                "summary": "获取 exec sessionId",
+                "summary": "批量执行命令",
                 "parameters": [
                     {
                         "type": "string",
@@ -1529,8 +1470,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Pod名称",
-                        "name": "podName",
+                        "description": "Deployment名称",
+                        "name": "deploymentName",
                         "in": "path",
                         "required": true
                     },
@@ -1541,44 +1482,44 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "string",
-                        "description": "容器名,默认第1个容器",
-                        "name": "containerName",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "执行shell",
-                        "name": "shell",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "命令参数, containerName为空时使用每个Pod的第1个容器",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sPodExec"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回 sessionId 日志"
+                        "description": "成功返回汇总的执行结果",
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sBatchExecResult"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/log": {
-            "get": {
-                "description": "获取Pod日志",
+        "/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}/image": {
+            "put": {
+                "description": "修改 Deployment 容器镜像",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Pod"
+                    "Deployment"
                 ],
-                "summary": "获取Pod日志",
+                "summary": "修改 Deployment 容器镜像",
                 "parameters": [
                     {
                         "type": "string",
@@ -1589,8 +1530,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Pod名称",
-                        "name": "podName",
+                        "description": "Deployment名称",
+                        "name": "deploymentName",
                         "in": "path",
                         "required": true
                     },
@@ -1602,16 +1543,27 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "容器名,默认第1个容器",
-                        "name": "containerName",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "查看最后多少行日志,默认200",
-                        "name": "line",
-                        "in": "query"
+                        "description": "新的容器镜像,只更新第一个容器时 name参数可忽略",
+                        "name": "container",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "object",
+                                "required": [
+                                    "image"
+                                ],
+                                "properties": {
+                                    "image": {
+                                        "type": "string"
+                                    },
+                                    "name": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
                     },
                     {
                         "type": "string",
@@ -1621,24 +1573,20 @@ const docTemplate = `{
                         "required": true
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "成功返回Pod日志"
-                    }
-                }
+                "responses": {}
             }
         },
-        "/api/v1/k8s/{clusterName}/prometheus/servicemonitor/": {
+        "/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}/pods": {
             "get": {
-                "description": "获取ServiceMonitor列表",
+                "description": "获取 Deployment 管理的 Pod 信息",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "ServiceMonitor"
+                    "Deployment"
                 ],
-                "summary": "获取ServiceMonitor列表",
+                "summary": "获取 Deployment 管理的 Pod 信息",
                 "parameters": [
                     {
                         "type": "string",
@@ -1649,9 +1597,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Namespace 不填为全部",
-                        "name": "namespace",
-                        "in": "path"
+                        "description": "Deployment名称",
+                        "name": "deploymentName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
@@ -1659,10 +1615,206 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}/promote": {
+            "post": {
+                "description": "将 Deployment 及其关联的 Service、Ingress、ConfigMap、Secret 复制到目标集群/Namespace, dryRun 时只返回差异",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Deployment"
+                ],
+                "summary": "迁移 Deployment 到其他集群/Namespace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "根据ServiceMonitor名字模糊查询",
+                        "description": "Deployment名称",
+                        "name": "deploymentName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "迁移参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sDeploymentPromote"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回每个对象的迁移结果",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}/restart": {
+            "put": {
+                "description": "重启 Deployment 管理的 Pod",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Deployment"
+                ],
+                "summary": "重启 Deployment 管理的 Pod",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deployment名称",
+                        "name": "deploymentName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/api/v1/k8s/{clusterName}/deployment/{namespace}/{deploymentName}/scale": {
+            "put": {
+                "description": "修改 Deployment 副本数",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Deployment"
+                ],
+                "summary": "修改 Deployment 副本数",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deployment名称",
+                        "name": "deploymentName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "副本数",
+                        "name": "replicas",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/api/v1/k8s/{clusterName}/gateway/gateway/": {
+            "get": {
+                "description": "获取Gateway列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "GatewayAPI"
+                ],
+                "summary": "获取Gateway列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "根据Gateway名字模糊查询",
                         "name": "filter",
                         "in": "query"
                     },
@@ -1681,7 +1833,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回ServiceMonitor列表",
+                        "description": "成功返回Gateway列表",
                         "schema": {
                             "$ref": "#/definitions/httputil.PageResponseBody"
                         }
@@ -1689,17 +1841,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/prometheus/servicemonitor/{namespace}": {
+        "/api/v1/k8s/{clusterName}/gateway/gateway/{namespace}": {
             "get": {
-                "description": "获取ServiceMonitor列表",
+                "description": "获取Gateway列表",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "ServiceMonitor"
+                    "GatewayAPI"
                 ],
-                "summary": "获取ServiceMonitor列表",
+                "summary": "获取Gateway列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -1723,7 +1875,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "根据ServiceMonitor名字模糊查询",
+                        "description": "根据Gateway名字模糊查询",
                         "name": "filter",
                         "in": "query"
                     },
@@ -1742,7 +1894,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回ServiceMonitor列表",
+                        "description": "成功返回Gateway列表",
                         "schema": {
                             "$ref": "#/definitions/httputil.PageResponseBody"
                         }
@@ -1750,17 +1902,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/prometheus/servicemonitor/{namespace}/{name}": {
+        "/api/v1/k8s/{clusterName}/gateway/gateway/{namespace}/{name}": {
             "get": {
-                "description": "获取ServiceMonitor信息",
+                "description": "获取Gateway信息",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "ServiceMonitor"
+                    "GatewayAPI"
                 ],
-                "summary": "获取ServiceMonitor信息",
+                "summary": "获取Gateway信息",
                 "parameters": [
                     {
                         "type": "string",
@@ -1771,7 +1923,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ServiceMonitor名称",
+                        "description": "Gateway名称",
                         "name": "name",
                         "in": "path",
                         "required": true
@@ -1793,7 +1945,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回ServiceMonitor信息",
+                        "description": "成功返回Gateway信息",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
@@ -1801,15 +1953,15 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "删除ServiceMonitor",
+                "description": "删除Gateway",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "ServiceMonitor"
+                    "GatewayAPI"
                 ],
-                "summary": "删除ServiceMonitor",
+                "summary": "删除Gateway",
                 "parameters": [
                     {
                         "type": "string",
@@ -1820,7 +1972,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "ServiceMonitor名称",
+                        "description": "Gateway名称",
                         "name": "name",
                         "in": "path",
                         "required": true
@@ -1847,17 +1999,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/secret/": {
+        "/api/v1/k8s/{clusterName}/gateway/gatewayclass/": {
             "get": {
-                "description": "获取Secret列表",
+                "description": "获取GatewayClass列表, GatewayClass是集群级别的资源",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Secret"
+                    "GatewayAPI"
                 ],
-                "summary": "获取Secret列表",
+                "summary": "获取GatewayClass列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -1866,12 +2018,6 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "string",
-                        "description": "Namespace 不填为全部",
-                        "name": "namespace",
-                        "in": "path"
-                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
@@ -1881,7 +2027,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "根据Secret名字模糊查询",
+                        "description": "根据GatewayClass名字模糊查询",
                         "name": "filter",
                         "in": "query"
                     },
@@ -1900,24 +2046,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回Secret列表",
+                        "description": "成功返回GatewayClass列表",
                         "schema": {
                             "$ref": "#/definitions/httputil.PageResponseBody"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "更新 Opaque 类型的 Secret",
+            }
+        },
+        "/api/v1/k8s/{clusterName}/gateway/gatewayclass/{name}": {
+            "get": {
+                "description": "获取GatewayClass信息",
                 "produces": [
-                    "application/json",
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Secret"
+                    "GatewayAPI"
                 ],
-                "summary": "更新 Opaque 类型的 Secret",
+                "summary": "获取GatewayClass信息",
                 "parameters": [
                     {
                         "type": "string",
@@ -1928,8 +2075,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
+                        "description": "GatewayClass名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
@@ -1939,37 +2086,27 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "K8sSecretCreate 对象",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.K8sSecretCreate"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回",
+                        "description": "成功返回GatewayClass信息",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "创建 Opaque 类型的 Secret",
+            "delete": {
+                "description": "删除GatewayClass",
                 "produces": [
-                    "application/json",
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Secret"
+                    "GatewayAPI"
                 ],
-                "summary": "创建 Opaque 类型的 Secret",
+                "summary": "删除GatewayClass",
                 "parameters": [
                     {
                         "type": "string",
@@ -1980,8 +2117,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
+                        "description": "GatewayClass名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
@@ -1991,39 +2128,26 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "K8sSecretCreate 对象",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.K8sSecretCreate"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回",
-                        "schema": {
-                            "$ref": "#/definitions/httputil.ResponseBody"
-                        }
+                        "description": "成功返回"
                     }
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/secret/_docker-registry": {
-            "put": {
-                "description": "更新 docker-registry 类型的 Secret",
+        "/api/v1/k8s/{clusterName}/gateway/httproute/": {
+            "get": {
+                "description": "获取HTTPRoute列表",
                 "produces": [
-                    "application/json",
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Secret"
+                    "GatewayAPI"
                 ],
-                "summary": "更新 docker-registry 类型的 Secret",
+                "summary": "获取HTTPRoute列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -2034,10 +2158,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
-                        "in": "path",
-                        "required": true
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
                     },
                     {
                         "type": "string",
@@ -2046,90 +2169,44 @@ const docTemplate = `{
                         "in": "header",
                         "required": true
                     },
-                    {
-                        "description": "K8sSecretForDockerRegistryCreate 对象",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.K8sSecretForDockerRegistryCreate"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "成功返回",
-                        "schema": {
-                            "$ref": "#/definitions/httputil.ResponseBody"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "创建 docker-registry 类型的 Secret",
-                "produces": [
-                    "application/json",
-                    "application/json"
-                ],
-                "tags": [
-                    "K8s",
-                    "Secret"
-                ],
-                "summary": "创建 docker-registry 类型的 Secret",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
-                        "in": "path",
-                        "required": true
+                        "description": "根据HTTPRoute名字模糊查询",
+                        "name": "filter",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
-                        "in": "path",
-                        "required": true
+                        "description": "一页获取多少条数据,默认十条",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Authorization token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "K8sSecretForDockerRegistryCreate 对象",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.K8sSecretForDockerRegistryCreate"
-                        }
+                        "description": "获取第几页的数据,默认第一页",
+                        "name": "page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回",
+                        "description": "成功返回HTTPRoute列表",
                         "schema": {
-                            "$ref": "#/definitions/httputil.ResponseBody"
+                            "$ref": "#/definitions/httputil.PageResponseBody"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/k8s/{clusterName}/secret/_tls": {
+            },
             "put": {
-                "description": "更新 tls 类型的 Secret",
+                "description": "更新 HTTPRoute, 使用merge patch, 保留其他工具添加的注解",
                 "produces": [
-                    "application/json",
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Secret"
+                    "GatewayAPI"
                 ],
-                "summary": "更新 tls 类型的 Secret",
+                "summary": "更新简单 HTTPRoute",
                 "parameters": [
                     {
                         "type": "string",
@@ -2139,11 +2216,10 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "只返回 dry-run 更新后的差异, 不实际更新",
+                        "name": "diff",
+                        "in": "query"
                     },
                     {
                         "type": "string",
@@ -2153,12 +2229,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "K8sSecretForTlsCreate 对象",
+                        "description": "K8sHTTPRouteSimpleCreate 对象",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.K8sSecretForTlsCreate"
+                            "$ref": "#/definitions/dto.K8sHTTPRouteSimpleCreate"
                         }
                     }
                 ],
@@ -2172,16 +2248,15 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "创建 tls 类型的 Secret",
+                "description": "创建 HTTPRoute, 支持多个parentRef、hostname和规则, 每个规则可以有多个匹配条件和带权重的后端",
                 "produces": [
-                    "application/json",
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Secret"
+                    "GatewayAPI"
                 ],
-                "summary": "创建 tls 类型的 Secret",
+                "summary": "创建简单 HTTPRoute",
                 "parameters": [
                     {
                         "type": "string",
@@ -2190,13 +2265,6 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
-                    {
-                        "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
@@ -2205,12 +2273,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "K8sSecretForTlsCreate 对象",
+                        "description": "K8sHTTPRouteSimpleCreate 对象",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.K8sSecretForTlsCreate"
+                            "$ref": "#/definitions/dto.K8sHTTPRouteSimpleCreate"
                         }
                     }
                 ],
@@ -2224,17 +2292,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/secret/{namespace}": {
+        "/api/v1/k8s/{clusterName}/gateway/httproute/{namespace}": {
             "get": {
-                "description": "获取Secret列表",
+                "description": "获取HTTPRoute列表",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Secret"
+                    "GatewayAPI"
                 ],
-                "summary": "获取Secret列表",
+                "summary": "获取HTTPRoute列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -2258,7 +2326,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "根据Secret名字模糊查询",
+                        "description": "根据HTTPRoute名字模糊查询",
                         "name": "filter",
                         "in": "query"
                     },
@@ -2277,7 +2345,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回Secret列表",
+                        "description": "成功返回HTTPRoute列表",
                         "schema": {
                             "$ref": "#/definitions/httputil.PageResponseBody"
                         }
@@ -2285,17 +2353,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/secret/{namespace}/{secretName}": {
+        "/api/v1/k8s/{clusterName}/gateway/httproute/{namespace}/{name}": {
             "get": {
-                "description": "获取Secret信息",
+                "description": "获取HTTPRoute信息",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Secret"
+                    "GatewayAPI"
                 ],
-                "summary": "获取Secret信息",
+                "summary": "获取HTTPRoute信息",
                 "parameters": [
                     {
                         "type": "string",
@@ -2306,8 +2374,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Secret名称",
-                        "name": "secretName",
+                        "description": "HTTPRoute名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
@@ -2328,7 +2396,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回Secret信息",
+                        "description": "成功返回HTTPRoute信息",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
@@ -2336,15 +2404,15 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "删除Secret",
+                "description": "删除HTTPRoute",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Secret"
+                    "GatewayAPI"
                 ],
-                "summary": "删除Secret",
+                "summary": "删除HTTPRoute",
                 "parameters": [
                     {
                         "type": "string",
@@ -2355,8 +2423,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Secret名称",
-                        "name": "secretName",
+                        "description": "HTTPRoute名称",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
@@ -2382,17 +2450,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/svc/": {
+        "/api/v1/k8s/{clusterName}/gateway/httproute/{namespace}/{name}/status": {
             "get": {
-                "description": "获取Svc列表",
+                "description": "获取 HTTPRoute 在每个Gateway上的状态, 包括是否被接受、后端是否能解析以及原因",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Svc"
+                    "GatewayAPI"
                 ],
-                "summary": "获取Svc列表",
+                "summary": "获取 HTTPRoute 状态",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HTTPRoute名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回 dto.K8sHTTPRouteStatus",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/gateway/referencegrant/": {
+            "get": {
+                "description": "获取ReferenceGrant列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "GatewayAPI"
+                ],
+                "summary": "获取ReferenceGrant列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -2416,7 +2535,7 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "根据service名字模糊查询",
+                        "description": "根据ReferenceGrant名字模糊查询",
                         "name": "filter",
                         "in": "query"
                     },
@@ -2435,24 +2554,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回Service列表",
+                        "description": "成功返回ReferenceGrant列表",
                         "schema": {
                             "$ref": "#/definitions/httputil.PageResponseBody"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "更新简单 Svc",
+            }
+        },
+        "/api/v1/k8s/{clusterName}/gateway/referencegrant/{namespace}": {
+            "get": {
+                "description": "获取ReferenceGrant列表",
                 "produces": [
-                    "application/json",
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Svc"
+                    "GatewayAPI"
                 ],
-                "summary": "更新简单 Svc",
+                "summary": "获取ReferenceGrant列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -2463,10 +2583,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
-                        "in": "path",
-                        "required": true
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
                     },
                     {
                         "type": "string",
@@ -2476,35 +2595,45 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "K8sSvcSimpleCreate 对象",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.K8sSvcSimpleCreate"
-                        }
+                        "type": "string",
+                        "description": "根据ReferenceGrant名字模糊查询",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "一页获取多少条数据,默认十条",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "获取第几页的数据,默认第一页",
+                        "name": "page",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回",
+                        "description": "成功返回ReferenceGrant列表",
                         "schema": {
-                            "$ref": "#/definitions/httputil.ResponseBody"
+                            "$ref": "#/definitions/httputil.PageResponseBody"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "创建简单 Svc, 支持通过DeploymentName 转换为 Selector, 也可以自定义Selector, 二选一。 仅支持创建ClusterIP类型",
+            }
+        },
+        "/api/v1/k8s/{clusterName}/gateway/referencegrant/{namespace}/{name}": {
+            "get": {
+                "description": "获取ReferenceGrant信息",
                 "produces": [
-                    "application/json",
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Svc"
+                    "GatewayAPI"
                 ],
-                "summary": "创建简单 Svc",
+                "summary": "获取ReferenceGrant信息",
                 "parameters": [
                     {
                         "type": "string",
@@ -2515,8 +2644,15 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Cluster Name",
-                        "name": "clusterName",
+                        "description": "ReferenceGrant名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
                         "in": "path",
                         "required": true
                     },
@@ -2526,38 +2662,27 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "K8sSvcSimpleCreate 对象",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.K8sSvcSimpleCreate"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回",
+                        "description": "成功返回ReferenceGrant信息",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/k8s/{clusterName}/svc/{namespace}": {
-            "get": {
-                "description": "获取Svc列表",
+            },
+            "delete": {
+                "description": "删除ReferenceGrant",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Svc"
+                    "GatewayAPI"
                 ],
-                "summary": "获取Svc列表",
+                "summary": "删除ReferenceGrant",
                 "parameters": [
                     {
                         "type": "string",
@@ -2568,9 +2693,17 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Namespace 不填为全部",
+                        "description": "ReferenceGrant名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
                         "name": "namespace",
-                        "in": "path"
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
@@ -2578,47 +2711,26 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "根据service名字模糊查询",
-                        "name": "filter",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "一页获取多少条数据,默认十条",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "获取第几页的数据,默认第一页",
-                        "name": "page",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回Service列表",
-                        "schema": {
-                            "$ref": "#/definitions/httputil.PageResponseBody"
-                        }
+                        "description": "成功返回"
                     }
                 }
             }
         },
-        "/api/v1/k8s/{clusterName}/svc/{namespace}/{svcName}": {
+        "/api/v1/k8s/{clusterName}/helm/": {
             "get": {
-                "description": "获取Svc信息",
+                "description": "获取Helm Release列表",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Svc"
+                    "Helm"
                 ],
-                "summary": "获取Svc信息",
+                "summary": "获取Helm Release列表",
                 "parameters": [
                     {
                         "type": "string",
@@ -2629,17 +2741,9 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Svc名称",
-                        "name": "svcName",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Namespace",
+                        "description": "Namespace 不填为全部",
                         "name": "namespace",
-                        "in": "path",
-                        "required": true
+                        "in": "path"
                     },
                     {
                         "type": "string",
@@ -2651,23 +2755,26 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回 service 信息",
+                        "description": "成功返回Release列表",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "删除Svc",
+            "post": {
+                "description": "安装chart, 上传chart包(file)和本地chart仓库中的chart二选一",
+                "consumes": [
+                    "multipart/form-data"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "K8s",
-                    "Svc"
+                    "Helm"
                 ],
-                "summary": "删除Svc",
+                "summary": "安装chart",
                 "parameters": [
                     {
                         "type": "string",
@@ -2678,93 +2785,141 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Svc名称",
-                        "name": "svcName",
-                        "in": "path",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "chart包(.tgz)",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "本地chart仓库中的chart",
+                        "name": "chart",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release名称",
+                        "name": "name",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
                         "description": "Namespace",
                         "name": "namespace",
-                        "in": "path",
+                        "in": "formData",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Authorization token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "values(yaml)",
+                        "name": "values",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "等待资源就绪",
+                        "name": "wait",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "超时时间(秒), 默认300",
+                        "name": "timeout",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回"
+                        "description": "成功返回Release",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
                     }
                 }
             }
         },
-        "/api/v1/system/dbInitializer/": {
+        "/api/v1/k8s/{clusterName}/helm/{namespace}": {
             "get": {
-                "description": "是否已经初始化数据库",
+                "description": "获取Helm Release列表",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "DbInitializer"
+                    "K8s",
+                    "Helm"
                 ],
-                "summary": "是否已经初始化数据库",
-                "responses": {
-                    "200": {
-                        "description": "成功返回",
-                        "schema": {
-                            "type": "string"
-                        }
+                "summary": "获取Helm Release列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
-                }
-            },
-            "post": {
-                "description": "初始化数据库数据",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "DbInitializer"
                 ],
-                "summary": "初始化数据库数据",
                 "responses": {
                     "200": {
-                        "description": "成功返回",
+                        "description": "成功返回Release列表",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
             }
         },
-        "/api/v1/system/user/": {
-            "post": {
-                "description": "添加用户",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/k8s/{clusterName}/helm/{namespace}/{releaseName}": {
+            "get": {
+                "description": "获取Helm Release详情",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "K8s",
+                    "Helm"
                 ],
-                "summary": "添加用户",
+                "summary": "获取Helm Release详情",
                 "parameters": [
                     {
-                        "description": "用户信息",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.SystemAdd"
-                        }
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release名称",
+                        "name": "releaseName",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
@@ -2776,67 +2931,150 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回",
+                        "description": "成功返回Release详情",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/system/user/info": {
-            "get": {
-                "description": "用户信息",
+            },
+            "put": {
+                "description": "升级Helm Release, 上传chart包(file)和本地chart仓库中的chart二选一",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "User"
+                    "K8s",
+                    "Helm"
                 ],
-                "summary": "用户信息",
+                "summary": "升级Helm Release",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release名称",
+                        "name": "releaseName",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
                         "description": "Authorization token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "chart包(.tgz)",
+                        "name": "file",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "本地chart仓库中的chart",
+                        "name": "chart",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "values(yaml)",
+                        "name": "values",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "在上一个版本values的基础上合并",
+                        "name": "reuseValues",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "等待资源就绪",
+                        "name": "wait",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "超时时间(秒), 默认300",
+                        "name": "timeout",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回用户信息",
+                        "description": "成功返回Release",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/system/user/login": {
-            "post": {
-                "description": "用户登录",
-                "consumes": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "卸载Helm Release",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "K8s",
+                    "Helm"
                 ],
-                "summary": "用户登录",
+                "summary": "卸载Helm Release",
                 "parameters": [
                     {
-                        "description": "手机号,密码",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.SystemLogin"
-                        }
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release名称",
+                        "name": "releaseName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "保留历史版本",
+                        "name": "keepHistory",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回token",
+                        "description": "成功返回",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
@@ -2844,33 +3082,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/system/user/refreshToken": {
-            "post": {
-                "description": "刷新用户Token",
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/v1/k8s/{clusterName}/helm/{namespace}/{releaseName}/history": {
+            "get": {
+                "description": "获取Helm Release历史版本",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "K8s",
+                    "Helm"
                 ],
-                "summary": "刷新用户Token",
+                "summary": "获取Helm Release历史版本",
                 "parameters": [
                     {
-                        "description": "{",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release名称",
+                        "name": "releaseName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回token",
+                        "description": "成功返回历史版本",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
@@ -2878,9 +3133,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/system/user/{userId}/roles": {
-            "post": {
-                "description": "为用户分配一个角色",
+        "/api/v1/k8s/{clusterName}/helm/{namespace}/{releaseName}/rollback": {
+            "put": {
+                "description": "回滚Helm Release, revision为0时回滚到上一个版本",
                 "consumes": [
                     "application/json"
                 ],
@@ -2888,18 +3143,88 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "K8s",
+                    "Helm"
                 ],
-                "summary": "为用户分配一个角色",
+                "summary": "回滚Helm Release",
                 "parameters": [
                     {
-                        "description": "用户和角色Id: {",
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release名称",
+                        "name": "releaseName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "回滚版本",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/dto.K8sHelmRollback"
                         }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回"
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/helm/{namespace}/{releaseName}/values": {
+            "get": {
+                "description": "获取Helm Release的values",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Helm"
+                ],
+                "summary": "获取Helm Release的values",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Release名称",
+                        "name": "releaseName",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
@@ -2907,11 +3232,23 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "版本, 默认最新版本",
+                        "name": "revision",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "包含chart默认值",
+                        "name": "all",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功返回",
+                        "description": "成功返回values",
                         "schema": {
                             "$ref": "#/definitions/httputil.ResponseBody"
                         }
@@ -2919,107 +3256,5277 @@ const docTemplate = `{
                 }
             }
         },
-        "/ping": {
-            "get": {
-                "description": "do ping",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "core"
-                ],
-                "summary": "health check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/core.resp"
-                        }
-                    }
+        "/api/v1/k8s/{clusterName}/ingress/": {
+            "get": {
+                "description": "获取 Ingress 列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Ingress"
+                ],
+                "summary": "获取 Ingress 列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "根据 Ingress 名字模糊查询",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "一页获取多少条数据,默认十条",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "获取第几页的数据,默认第一页",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回 Ingress 列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.PageResponseBody"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "更新 Ingress, 使用merge patch, 保留其他工具添加的注解",
+                "produces": [
+                    "application/json",
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Ingress"
+                ],
+                "summary": "更新简单 Ingress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "只返回 dry-run 更新后的差异, 不实际更新",
+                        "name": "diff",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "跳过后端、TLS和host冲突检查",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "description": "K8sIngressSimpleCreate 对象",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sIngressSimpleCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回, 检查未通过时data为 dto.K8sIngressValidation",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建 Ingress, 支持多个host和path、默认后端和TLS, 后端端口可以是端口号或端口名称",
+                "produces": [
+                    "application/json",
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Ingress"
+                ],
+                "summary": "创建简单 Ingress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "跳过后端、TLS和host冲突检查",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "description": "K8sIngressSimpleCreate 对象",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sIngressSimpleCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回, 检查未通过时data为 dto.K8sIngressValidation",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/ingress/lint": {
+            "get": {
+                "description": "检查集群中已有的 Ingress, 只返回有问题的 Ingress, host和path冲突在整个集群范围内检查",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Ingress"
+                ],
+                "summary": "检查集群中的 Ingress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回 dto.K8sIngressLint",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/ingress/validate": {
+            "post": {
+                "description": "检查 Ingress 的后端Service和端口、TLS Secret和证书、IngressClass以及与其他Ingress的host和path冲突, 不会创建或更新",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Ingress"
+                ],
+                "summary": "检查 Ingress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "K8sIngressSimpleCreate 对象",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sIngressSimpleCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回 dto.K8sIngressValidation",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/ingress/{namespace}": {
+            "get": {
+                "description": "获取 Ingress 列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Ingress"
+                ],
+                "summary": "获取 Ingress 列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "根据 Ingress 名字模糊查询",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "一页获取多少条数据,默认十条",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "获取第几页的数据,默认第一页",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回 Ingress 列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.PageResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/ingress/{namespace}/{ingressName}": {
+            "get": {
+                "description": "获取 Ingress 信息",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Ingress"
+                ],
+                "summary": "获取 Ingress 信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ingress名称",
+                        "name": "ingressName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回 Ingress 信息",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除 Ingress",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Ingress"
+                ],
+                "summary": "删除 Ingress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ingress名称",
+                        "name": "ingressName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回"
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/log/{namespace}": {
+            "get": {
+                "description": "以SSE的方式推送多个Pod的聚合日志, 每行带有[pod/container]前缀并按时间戳排序, follow模式下自动读取新Pod的日志",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "聚合推送多个Pod的日志",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "deployment或statefulset, 与labelSelector二选一",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deployment/StatefulSet名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod标签选择器",
+                        "name": "labelSelector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名,默认所有容器",
+                        "name": "containerName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "持续推送新日志",
+                        "name": "follow",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "每行日志带上时间戳",
+                        "name": "timestamps",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每个容器从最后多少行开始, 默认1000",
+                        "name": "tailLines",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "最近多少秒的日志",
+                        "name": "sinceSeconds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "正则表达式, 只返回匹配的行",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "日志流"
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/log/{namespace}/bundle": {
+            "get": {
+                "description": "下载工作负载的诊断包(tar.gz), 包含所有Pod的YAML、事件、所有容器的日志和上一个实例的日志",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "下载诊断包",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "deployment或statefulset, 与labelSelector二选一",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deployment/StatefulSet名称",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod标签选择器",
+                        "name": "labelSelector",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每个日志从最后多少行开始",
+                        "name": "tailLines",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "最近多少秒的日志",
+                        "name": "sinceSeconds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每个日志文件的大小上限, 默认10MB",
+                        "name": "limitBytes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "诊断包",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/namespace/": {
+            "get": {
+                "description": "获取 Namespace 列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s"
+                ],
+                "summary": "获取 Namespace 列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "根据Namespace名字模糊查询",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "一页获取多少条数据,默认十条",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "获取第几页的数据,默认第一页",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回Namespace列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.PageResponseBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建 Namespace",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Namespace"
+                ],
+                "summary": "创建 Namespace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Namespace对象",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/namespace/{namespaceName}": {
+            "get": {
+                "description": "获取 Namespace 信息",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Namespace"
+                ],
+                "summary": "获取 Namespace 信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace名称",
+                        "name": "namespaceName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回 Namespace 信息",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/namespace/{namespaceName}/": {
+            "delete": {
+                "description": "删除Namespace",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s"
+                ],
+                "summary": "删除Namespace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace名称",
+                        "name": "namespaceName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回"
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/node/": {
+            "get": {
+                "description": "获取节点列表, 包括可分配资源和使用量, metrics-server不可用时usage为null",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Node"
+                ],
+                "summary": "获取节点列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回节点列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/node/{nodeName}": {
+            "get": {
+                "description": "获取节点的可分配资源和使用量, metrics-server不可用时usage为null",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Node"
+                ],
+                "summary": "获取节点信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "节点名称",
+                        "name": "nodeName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回节点信息",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/node/{nodeName}/debug": {
+            "post": {
+                "description": "在节点上启动特权调试Pod并获取终端sessionId, 节点根目录挂载在/host, 终端关闭后删除调试Pod, 只有管理员可以使用",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "节点调试",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "节点名称",
+                        "name": "nodeName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "调试参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sNodeDebug"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回 sessionId 和一次性bind token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sDebugTerminal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/": {
+            "get": {
+                "description": "获取Pod列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "获取Pod列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "根据Pod名字模糊查询",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "一页获取多少条数据,默认十条",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "获取第几页的数据,默认第一页",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回Pod列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.PageResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}": {
+            "get": {
+                "description": "获取Pod列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "获取Pod列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "根据Pod名字模糊查询",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "一页获取多少条数据,默认十条",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "获取第几页的数据,默认第一页",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回Pod列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.PageResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}/bulk": {
+            "post": {
+                "description": "按标签选择器和状态批量删除、强制删除或驱逐Pod, 例如删除所有Evicted的Pod, dryRun为true时只返回匹配的Pod",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "批量操作Pod",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "批量操作参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sPodBulkOperation"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回每个Pod的操作结果",
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sPodBulkResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}": {
+            "get": {
+                "description": "获取Pod信息",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "获取Pod信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回Pod信息, metrics为各容器的资源使用量, metrics-server不可用时为null",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除Pod, force为true时以宽限期0强制删除, 用于一直处于Terminating的Pod",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "删除Pod",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "强制删除",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回"
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/containers": {
+            "get": {
+                "description": "获取Pod容器信息",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "获取Pod容器信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/debug": {
+            "post": {
+                "description": "在Pod中添加临时调试容器并获取终端sessionId, 用于没有shell的镜像, 指定targetContainer时共享该容器的进程命名空间",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "临时调试容器",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "调试参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sPodDebug"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回 sessionId 和一次性bind token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sDebugTerminal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/diagnose": {
+            "get": {
+                "description": "分析Pod无法正常运行的原因, 包括调度失败、容器状态、退出码、事件、缺少的ConfigMap/Secret/PVC和镜像拉取密钥问题",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "Pod诊断",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回诊断结果",
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sPodDiagnosis"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/evict": {
+            "post": {
+                "description": "通过Eviction API驱逐Pod, 会遵守PodDisruptionBudget, 被PDB阻止时返回错误",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "驱逐Pod",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回"
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/exec": {
+            "get": {
+                "description": "获取 exec sessionId",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "获取 exec sessionId",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名,默认第1个容器",
+                        "name": "containerName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "执行shell",
+                        "name": "shell",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回 sessionId 和一次性bind token"
+                    }
+                }
+            },
+            "post": {
+                "description": "在容器中执行非交互命令, 不分配TTY, 返回stdout、stderr和退出码, 超时时exitCode为-1",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "执行命令",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "命令参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sPodExec"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回执行结果",
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sPodExecResult"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/files": {
+            "get": {
+                "description": "列出容器中的目录",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "列出容器中的目录",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名,默认第1个容器",
+                        "name": "containerName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "目录的绝对路径",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回文件列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/files/download": {
+            "get": {
+                "description": "以tar格式下载容器中的文件或目录, 需要容器中有tar命令",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "下载容器中的文件",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名,默认第1个容器",
+                        "name": "containerName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "文件或目录的绝对路径",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "tar包",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/files/upload": {
+            "put": {
+                "description": "上传文件到容器的目录中, 需要容器中有tar命令。archive=true时请求体是tar包, 解压到path目录; 否则请求体是单个文件的内容, 保存为path/fileName",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "上传文件到容器",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名,默认第1个容器",
+                        "name": "containerName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "目标目录的绝对路径",
+                        "name": "path",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "文件名, archive为false时必填",
+                        "name": "fileName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "请求体是否是tar包",
+                        "name": "archive",
+                        "in": "query"
+                    },
+                    {
+                        "description": "文件内容",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "上传成功",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/log": {
+            "get": {
+                "description": "获取Pod日志",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "获取Pod日志",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名,默认第1个容器",
+                        "name": "containerName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "查看最后多少行日志,默认200",
+                        "name": "line",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回Pod日志"
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/log/download": {
+            "get": {
+                "description": "以文件的方式下载Pod容器日志",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "下载Pod日志",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名,默认第1个容器",
+                        "name": "containerName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "上一个容器实例的日志",
+                        "name": "previous",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "每行日志带上时间戳",
+                        "name": "timestamps",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "从最后多少行开始",
+                        "name": "tailLines",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "最近多少秒的日志, 与sinceTime二选一",
+                        "name": "sinceSeconds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "从某个时间开始的日志(RFC3339), 与sinceSeconds二选一",
+                        "name": "sinceTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "最多读取多少字节",
+                        "name": "limitBytes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "日志文件",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/log/stream": {
+            "get": {
+                "description": "以SSE的方式推送Pod日志, 事件类型: log(一行日志)、error(读取失败)、end(日志结束)",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "实时推送Pod日志",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "容器名,默认第1个容器",
+                        "name": "containerName",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "持续推送新日志",
+                        "name": "follow",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "上一个容器实例的日志",
+                        "name": "previous",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "每行日志带上时间戳",
+                        "name": "timestamps",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "从最后多少行开始",
+                        "name": "tailLines",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "最近多少秒的日志, 与sinceTime二选一",
+                        "name": "sinceSeconds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "从某个时间开始的日志(RFC3339), 与sinceSeconds二选一",
+                        "name": "sinceTime",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "最多读取多少字节",
+                        "name": "limitBytes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "日志流"
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/portforward/{port}": {
+            "post": {
+                "description": "创建到Pod端口的转发会话, 之后可以通过返回的proxyPath访问Pod端口, 会话空闲或达到最长时间后自动关闭",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "创建端口转发",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pod端口",
+                        "name": "port",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回端口转发会话",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "关闭自己的端口转发会话",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "关闭端口转发",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pod端口",
+                        "name": "port",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "操作成功",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/pod/{namespace}/{podName}/portforward/{port}/{path}": {
+            "get": {
+                "description": "通过已创建的端口转发会话, 将请求反向代理到Pod端口, Authorization头不会转发给Pod",
+                "tags": [
+                    "K8s",
+                    "Pod"
+                ],
+                "summary": "端口转发反向代理",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pod名称",
+                        "name": "podName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Pod端口",
+                        "name": "port",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "转发到Pod的路径",
+                        "name": "path",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Pod的响应"
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/prometheus/servicemonitor/": {
+            "get": {
+                "description": "获取ServiceMonitor列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "ServiceMonitor"
+                ],
+                "summary": "获取ServiceMonitor列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "根据ServiceMonitor名字模糊查询",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "一页获取多少条数据,默认十条",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "获取第几页的数据,默认第一页",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回ServiceMonitor列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.PageResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/prometheus/servicemonitor/{namespace}": {
+            "get": {
+                "description": "获取ServiceMonitor列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "ServiceMonitor"
+                ],
+                "summary": "获取ServiceMonitor列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "根据ServiceMonitor名字模糊查询",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "一页获取多少条数据,默认十条",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "获取第几页的数据,默认第一页",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回ServiceMonitor列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.PageResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/prometheus/servicemonitor/{namespace}/{name}": {
+            "get": {
+                "description": "获取ServiceMonitor信息",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "ServiceMonitor"
+                ],
+                "summary": "获取ServiceMonitor信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ServiceMonitor名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回ServiceMonitor信息",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除ServiceMonitor",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "ServiceMonitor"
+                ],
+                "summary": "删除ServiceMonitor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ServiceMonitor名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回"
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/scalepolicy/": {
+            "get": {
+                "description": "获取定时扩缩容策略列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "ScalePolicy"
+                ],
+                "summary": "获取定时扩缩容策略列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回策略列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建定时扩缩容策略, deploymentName为空时作用于namespace下所有Deployment",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "ScalePolicy"
+                ],
+                "summary": "创建定时扩缩容策略",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "策略信息",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sScalePolicyCreate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回策略",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/scalepolicy/{id}": {
+            "get": {
+                "description": "获取定时扩缩容策略",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "ScalePolicy"
+                ],
+                "summary": "获取定时扩缩容策略",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "策略ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回策略",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "更新定时扩缩容策略",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "ScalePolicy"
+                ],
+                "summary": "更新定时扩缩容策略",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "策略ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "策略信息",
+                        "name": "policy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sScalePolicyCreate"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回策略",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除定时扩缩容策略",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "ScalePolicy"
+                ],
+                "summary": "删除定时扩缩容策略",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "策略ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回"
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/scalepolicy/{id}/history": {
+            "get": {
+                "description": "获取定时扩缩容策略的执行记录",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "ScalePolicy"
+                ],
+                "summary": "获取策略执行记录",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "策略ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "一页获取多少条数据,默认十条",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "获取第几页的数据,默认第一页",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回执行记录",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.PageResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/secret/": {
+            "get": {
+                "description": "获取Secret列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Secret"
+                ],
+                "summary": "获取Secret列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "根据Secret名字模糊查询",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "一页获取多少条数据,默认十条",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "获取第几页的数据,默认第一页",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回Secret列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.PageResponseBody"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "更新 Opaque 类型的 Secret",
+                "produces": [
+                    "application/json",
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Secret"
+                ],
+                "summary": "更新 Opaque 类型的 Secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "只返回 dry-run 更新后的差异, 不实际更新",
+                        "name": "diff",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "K8sSecretCreate 对象",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sSecretCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建 Opaque 类型的 Secret",
+                "produces": [
+                    "application/json",
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Secret"
+                ],
+                "summary": "创建 Opaque 类型的 Secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "K8sSecretCreate 对象",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sSecretCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/secret/_docker-registry": {
+            "put": {
+                "description": "更新 docker-registry 类型的 Secret",
+                "produces": [
+                    "application/json",
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Secret"
+                ],
+                "summary": "更新 docker-registry 类型的 Secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "只返回 dry-run 更新后的差异, 不实际更新",
+                        "name": "diff",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "K8sSecretForDockerRegistryCreate 对象",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sSecretForDockerRegistryCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建 docker-registry 类型的 Secret",
+                "produces": [
+                    "application/json",
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Secret"
+                ],
+                "summary": "创建 docker-registry 类型的 Secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "K8sSecretForDockerRegistryCreate 对象",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sSecretForDockerRegistryCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/secret/_tls": {
+            "put": {
+                "description": "更新 tls 类型的 Secret",
+                "produces": [
+                    "application/json",
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Secret"
+                ],
+                "summary": "更新 tls 类型的 Secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "只返回 dry-run 更新后的差异, 不实际更新",
+                        "name": "diff",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "K8sSecretForTlsCreate 对象",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sSecretForTlsCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建 tls 类型的 Secret",
+                "produces": [
+                    "application/json",
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Secret"
+                ],
+                "summary": "创建 tls 类型的 Secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "K8sSecretForTlsCreate 对象",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sSecretForTlsCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/secret/{namespace}": {
+            "get": {
+                "description": "获取Secret列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Secret"
+                ],
+                "summary": "获取Secret列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "根据Secret名字模糊查询",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "一页获取多少条数据,默认十条",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "获取第几页的数据,默认第一页",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回Secret列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.PageResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/secret/{namespace}/{secretName}": {
+            "get": {
+                "description": "获取Secret信息",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Secret"
+                ],
+                "summary": "获取Secret信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Secret名称",
+                        "name": "secretName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回Secret信息",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除Secret",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Secret"
+                ],
+                "summary": "删除Secret",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Secret名称",
+                        "name": "secretName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回"
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/svc/": {
+            "get": {
+                "description": "获取Svc列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Svc"
+                ],
+                "summary": "获取Svc列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "根据service名字模糊查询",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "一页获取多少条数据,默认十条",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "获取第几页的数据,默认第一页",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回Service列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.PageResponseBody"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "更新简单 Svc",
+                "produces": [
+                    "application/json",
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Svc"
+                ],
+                "summary": "更新简单 Svc",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "只返回 dry-run 更新后的差异, 不实际更新",
+                        "name": "diff",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "K8sSvcSimpleCreate 对象",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sSvcSimpleCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "创建简单 Svc, 支持通过DeploymentName 转换为 Selector, 也可以自定义Selector, 二选一。 仅支持创建ClusterIP类型",
+                "produces": [
+                    "application/json",
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Svc"
+                ],
+                "summary": "创建简单 Svc",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "K8sSvcSimpleCreate 对象",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sSvcSimpleCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/svc/{namespace}": {
+            "get": {
+                "description": "获取Svc列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Svc"
+                ],
+                "summary": "获取Svc列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "根据service名字模糊查询",
+                        "name": "filter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "一页获取多少条数据,默认十条",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "获取第几页的数据,默认第一页",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回Service列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.PageResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/svc/{namespace}/{svcName}": {
+            "get": {
+                "description": "获取Svc信息",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Svc"
+                ],
+                "summary": "获取Svc信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Svc名称",
+                        "name": "svcName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回 service 信息",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "删除Svc",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Svc"
+                ],
+                "summary": "删除Svc",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Svc名称",
+                        "name": "svcName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回"
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/svc/{namespace}/{svcName}/detail": {
+            "get": {
+                "description": "获取Svc的EndpointSlice、后端Pod和引用它的Ingress, 并检查selector和targetPort是否能匹配到Pod",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Svc"
+                ],
+                "summary": "获取Svc连通性详情",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Svc名称",
+                        "name": "svcName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回 dto.K8sSvcDetail",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/svc/{namespace}/{svcName}/portforward/{port}": {
+            "post": {
+                "description": "创建到Service端口的转发会话, 与kubectl port-forward svc/name一样选择一个就绪的Pod, 返回的proxyPath指向该Pod",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "Svc"
+                ],
+                "summary": "创建Service端口转发",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Service名称",
+                        "name": "svcName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Service端口",
+                        "name": "port",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回端口转发会话",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/templateinstance/": {
+            "get": {
+                "description": "获取由模板创建的应用实例列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "AppTemplate"
+                ],
+                "summary": "获取模板实例列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回实例列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "使用模板创建应用, dryRun为true时只返回渲染结果",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "AppTemplate"
+                ],
+                "summary": "使用模板创建应用",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "模板及参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sTemplateInstanceCreate"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "只渲染不创建",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回实例",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/templateinstance/{namespace}": {
+            "get": {
+                "description": "获取由模板创建的应用实例列表",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "AppTemplate"
+                ],
+                "summary": "获取模板实例列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace 不填为全部",
+                        "name": "namespace",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回实例列表",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/k8s/{clusterName}/templateinstance/{namespace}/{name}": {
+            "get": {
+                "description": "获取模板实例, 包含模板版本和参数值",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "AppTemplate"
+                ],
+                "summary": "获取模板实例",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "应用名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回实例",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "使用新版本模板或新参数重新渲染并更新应用, 参数会与之前的参数合并, dryRun为true时只返回渲染结果",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "K8s",
+                    "AppTemplate"
+                ],
+                "summary": "升级模板实例",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cluster Name",
+                        "name": "clusterName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Namespace",
+                        "name": "namespace",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "应用名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "模板版本及参数",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.K8sTemplateInstanceUpgrade"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "只渲染不更新",
+                        "name": "dryRun",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回实例",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/system/dbInitializer/": {
+            "get": {
+                "description": "是否已经初始化数据库",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DbInitializer"
+                ],
+                "summary": "是否已经初始化数据库",
+                "responses": {
+                    "200": {
+                        "description": "成功返回",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "初始化数据库数据",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "DbInitializer"
+                ],
+                "summary": "初始化数据库数据",
+                "responses": {
+                    "200": {
+                        "description": "成功返回",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/system/user/": {
+            "post": {
+                "description": "添加用户",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "添加用户",
+                "parameters": [
+                    {
+                        "description": "用户信息",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SystemAdd"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/system/user/info": {
+            "get": {
+                "description": "用户信息",
+                "tags": [
+                    "User"
+                ],
+                "summary": "用户信息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回用户信息",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/system/user/login": {
+            "post": {
+                "description": "用户登录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "用户登录",
+                "parameters": [
+                    {
+                        "description": "手机号,密码",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SystemLogin"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回token",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/system/user/refreshToken": {
+            "post": {
+                "description": "刷新用户Token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "刷新用户Token",
+                "parameters": [
+                    {
+                        "description": "{",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回token",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/system/user/{userId}/roles": {
+            "post": {
+                "description": "为用户分配一个角色",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "为用户分配一个角色",
+                "parameters": [
+                    {
+                        "description": "用户和角色Id: {",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功返回",
+                        "schema": {
+                            "$ref": "#/definitions/httputil.ResponseBody"
+                        }
+                    }
+                }
+            }
+        },
+        "/ping": {
+            "get": {
+                "description": "do ping",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "core"
+                ],
+                "summary": "health check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/core.resp"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "core.resp": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "pong"
+                }
+            }
+        },
+        "dto.K8sAppTemplateCreate": {
+            "type": "object",
+            "required": [
+                "content",
+                "name"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parameters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.TemplateParameter"
+                    }
+                }
+            }
+        },
+        "dto.K8sApplicationCreate": {
+            "type": "object",
+            "required": [
+                "name",
+                "namespace"
+            ],
+            "properties": {
+                "affinity": {
+                    "type": "object"
+                },
+                "containerPort": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.containerPort"
+                    }
+                },
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.container"
+                    }
+                },
+                "cpu": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "httpHealthCheck": {
+                    "$ref": "#/definitions/k8s.httpHealthCheck"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "imagePullSecret": {
+                    "type": "string"
+                },
+                "imagePullSecrets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ingress": {
+                    "$ref": "#/definitions/k8s.applicationIngress"
+                },
+                "initContainers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.container"
+                    }
+                },
+                "label": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "memory": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "nodeSelector": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "replicas": {
+                    "type": "integer"
+                },
+                "revisionHistoryLimit": {
+                    "type": "integer"
+                },
+                "strategy": {
+                    "$ref": "#/definitions/k8s.strategy"
+                },
+                "tolerations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.toleration"
+                    }
+                },
+                "volumes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.volume"
+                    }
+                }
+            }
+        },
+        "dto.K8sBatchExecResult": {
+            "type": "object",
+            "properties": {
+                "failed": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.PodExecResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.K8sClusterInfo": {
+            "type": "object",
+            "properties": {
+                "bearerToken": {
+                    "type": "string"
+                },
+                "clusterName": {
+                    "type": "string"
+                },
+                "host": {
+                    "type": "string"
+                },
+                "nodeNum": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tlsClientConfig": {
+                    "$ref": "#/definitions/k8s.TlsClientConfig"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.K8sDebugTerminal": {
+            "type": "object",
+            "properties": {
+                "containerName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "podName": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.K8sDeploymentCreate": {
+            "type": "object",
+            "required": [
+                "name",
+                "namespace"
+            ],
+            "properties": {
+                "affinity": {
+                    "type": "object"
+                },
+                "containerPort": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.containerPort"
+                    }
+                },
+                "containers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.container"
+                    }
+                },
+                "cpu": {
+                    "type": "string"
+                },
+                "httpHealthCheck": {
+                    "$ref": "#/definitions/k8s.httpHealthCheck"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "imagePullSecret": {
+                    "type": "string"
+                },
+                "imagePullSecrets": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "initContainers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.container"
+                    }
+                },
+                "label": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "memory": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "nodeSelector": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "replicas": {
+                    "type": "integer"
+                },
+                "revisionHistoryLimit": {
+                    "type": "integer"
+                },
+                "strategy": {
+                    "$ref": "#/definitions/k8s.strategy"
+                },
+                "tolerations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.toleration"
+                    }
+                },
+                "volumes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.volume"
+                    }
+                }
+            }
+        },
+        "dto.K8sDeploymentDiagnosis": {
+            "type": "object",
+            "properties": {
+                "findings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.DiagnosticFinding"
+                    }
+                },
+                "healthy": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.PodDiagnosis"
+                    }
+                }
+            }
+        },
+        "dto.K8sDeploymentPromote": {
+            "type": "object",
+            "required": [
+                "targetCluster",
+                "targetNamespace"
+            ],
+            "properties": {
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "imageTag": {
+                    "description": "覆盖所有容器的镜像tag",
+                    "type": "string"
+                },
+                "images": {
+                    "description": "按容器名覆盖完整镜像地址, 优先级高于imageTag",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "replicas": {
+                    "type": "integer"
+                },
+                "targetCluster": {
+                    "type": "string"
+                },
+                "targetNamespace": {
+                    "type": "string"
+                },
+                "withConfigMaps": {
+                    "description": "同时迁移pod中引用的ConfigMap",
+                    "type": "boolean"
+                },
+                "withIngress": {
+                    "description": "同时迁移引用了上述Service的Ingress",
+                    "type": "boolean"
+                },
+                "withSecrets": {
+                    "description": "同时迁移pod中引用的Secret",
+                    "type": "boolean"
+                },
+                "withService": {
+                    "description": "同时迁移selector匹配的Service",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.K8sHTTPRouteSimpleCreate": {
+            "type": "object",
+            "required": [
+                "name",
+                "namespace",
+                "parentRefs",
+                "rules"
+            ],
+            "properties": {
+                "annotations": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "hostnames": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "parentRefs": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/k8s.httpRouteParent"
+                    }
+                },
+                "rules": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/k8s.httpRouteRule"
+                    }
+                }
+            }
+        },
+        "dto.K8sHelmRollback": {
+            "type": "object",
+            "properties": {
+                "revision": {
+                    "description": "为0时回滚到上一个版本",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "timeout": {
+                    "description": "单位秒, 为0时使用300",
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "wait": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.K8sIngressSimpleCreate": {
+            "type": "object",
+            "required": [
+                "name",
+                "namespace"
+            ],
+            "properties": {
+                "annotations": {
+                    "description": "更新时与已有的注解合并, 不会删除其他工具添加的注解",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "defaultBackend": {
+                    "$ref": "#/definitions/k8s.ingressBackend"
+                },
+                "ingressClassName": {
+                    "type": "string"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "rule": {
+                    "$ref": "#/definitions/k8s.ruleConfig"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.ingressRule"
+                    }
+                },
+                "tls": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.tlsConfig"
+                    }
+                }
+            }
+        },
+        "dto.K8sNodeDebug": {
+            "type": "object",
+            "properties": {
+                "image": {
+                    "description": "为空时使用配置的默认镜像",
+                    "type": "string"
+                },
+                "shell": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.K8sPodBulkOperation": {
+            "type": "object",
+            "required": [
+                "action"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "delete",
+                        "forceDelete",
+                        "evict"
+                    ]
+                },
+                "dryRun": {
+                    "description": "只返回匹配的Pod, 不执行操作",
+                    "type": "boolean"
+                },
+                "labelSelector": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "与kubectl get pod的STATUS列相同, 如Evicted、CrashLoopBackOff、Completed、Terminating",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.K8sPodBulkResult": {
+            "type": "object",
+            "properties": {
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.PodOperationResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.K8sPodDebug": {
+            "type": "object",
+            "properties": {
+                "image": {
+                    "description": "为空时使用配置的默认镜像",
+                    "type": "string"
+                },
+                "shell": {
+                    "type": "string"
+                },
+                "targetContainer": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.K8sPodDiagnosis": {
+            "type": "object",
+            "properties": {
+                "findings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.DiagnosticFinding"
+                    }
+                },
+                "healthy": {
+                    "type": "boolean"
+                },
+                "podName": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.K8sPodExec": {
+            "type": "object",
+            "required": [
+                "command"
+            ],
+            "properties": {
+                "command": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "containerName": {
+                    "description": "为空时使用第1个容器",
+                    "type": "string"
+                },
+                "stdin": {
+                    "type": "string"
+                },
+                "timeout": {
+                    "description": "超时时间, 单位秒",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.K8sPodExecResult": {
+            "type": "object",
+            "properties": {
+                "containerName": {
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "执行时间, 单位毫秒",
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "exitCode": {
+                    "type": "integer"
+                },
+                "podName": {
+                    "type": "string"
+                },
+                "stderr": {
+                    "type": "string"
+                },
+                "stdout": {
+                    "type": "string"
+                },
+                "truncated": {
+                    "description": "输出超过大小上限, 超过的部分被丢弃",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.K8sScalePolicyCreate": {
+            "type": "object",
+            "required": [
+                "name",
+                "namespace",
+                "scaleSchedule"
+            ],
+            "properties": {
+                "deploymentName": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "restoreSchedule": {
+                    "type": "string"
+                },
+                "scaleReplicas": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "scaleSchedule": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.K8sSecretCreate": {
+            "type": "object",
+            "required": [
+                "data",
+                "name",
+                "namespace"
+            ],
+            "properties": {
+                "data": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.K8sSecretForDockerRegistryCreate": {
+            "type": "object",
+            "required": [
+                "auths",
+                "name",
+                "namespace"
+            ],
+            "properties": {
+                "auths": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/k8s.Auth"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.K8sSecretForTlsCreate": {
+            "type": "object",
+            "required": [
+                "certificate",
+                "name",
+                "namespace",
+                "privateKey"
+            ],
+            "properties": {
+                "certificate": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "privateKey": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.K8sSvcSimpleCreate": {
+            "type": "object",
+            "required": [
+                "name",
+                "namespace"
+            ],
+            "properties": {
+                "annotations": {
+                    "description": "例如LoadBalancer的云厂商注解, 更新时与已有的注解合并",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "deploymentName": {
+                    "type": "string"
+                },
+                "externalName": {
+                    "type": "string"
+                },
+                "externalTrafficPolicy": {
+                    "type": "string",
+                    "enum": [
+                        "Cluster",
+                        "Local"
+                    ]
+                },
+                "headless": {
+                    "type": "boolean"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "loadBalancerSourceRanges": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.ports"
+                    }
+                },
+                "selector": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "sessionAffinity": {
+                    "type": "string",
+                    "enum": [
+                        "None",
+                        "ClientIP"
+                    ]
+                },
+                "sessionAffinityTimeout": {
+                    "description": "ClientIP时有效, 单位秒, 默认10800",
+                    "type": "integer",
+                    "maximum": 86400
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "ClusterIP",
+                        "NodePort",
+                        "LoadBalancer",
+                        "ExternalName"
+                    ]
+                }
+            }
+        },
+        "dto.K8sTemplateInstanceCreate": {
+            "type": "object",
+            "required": [
+                "name",
+                "namespace",
+                "templateId"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "namespace": {
+                    "type": "string"
+                },
+                "templateId": {
+                    "type": "integer"
+                },
+                "values": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "any"
+                    }
+                },
+                "version": {
+                    "description": "为空时使用最新版本",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.K8sTemplateInstanceUpgrade": {
+            "type": "object",
+            "properties": {
+                "values": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "any"
+                    }
+                },
+                "version": {
+                    "description": "为空时使用最新版本",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SystemAdd": {
+            "type": "object",
+            "required": [
+                "nickname",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "avatar": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "mobile": {
+                    "type": "string"
+                },
+                "nickname": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SystemLogin": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "httputil.PageResp": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "any"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
-        }
-    },
-    "definitions": {
-        "core.resp": {
+        },
+        "httputil.PageResponseBody": {
             "type": "object",
             "properties": {
+                "data": {
+                    "$ref": "#/definitions/httputil.PageResp"
+                },
+                "msg": {
+                    "type": "string"
+                },
                 "status": {
-                    "type": "string",
-                    "example": "pong"
+                    "type": "string"
                 }
             }
         },
-        "dto.K8sClusterInfo": {
+        "httputil.ResponseBody": {
             "type": "object",
             "properties": {
-                "TLSClientConfig": {
-                    "$ref": "#/definitions/k8s.TlsClientConfig"
-                },
-                "bearerToken": {
-                    "type": "string"
+                "data": {
+                    "type": "any"
                 },
-                "clusterName": {
+                "msg": {
                     "type": "string"
                 },
-                "host": {
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "dto.K8sDeploymentCreate": {
+        "k8s.Auth": {
             "type": "object",
             "required": [
-                "image",
-                "name",
-                "namespace"
+                "password",
+                "user"
             ],
             "properties": {
-                "containerPort": {
+                "password": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "k8s.DiagnosticFinding": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "description": "Scheduling、Image、Crash、Config、Storage、Probe、Node、Event等",
+                    "type": "string"
+                },
+                "container": {
+                    "type": "string"
+                },
+                "explanation": {
+                    "description": "可能的原因和处理建议",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Kubernetes返回的原始信息",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "severity": {
+                    "type": "string"
+                }
+            }
+        },
+        "k8s.PodDiagnosis": {
+            "type": "object",
+            "properties": {
+                "findings": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/k8s.containerPort"
+                        "$ref": "#/definitions/k8s.DiagnosticFinding"
                     }
                 },
-                "cpu": {
+                "healthy": {
+                    "type": "boolean"
+                },
+                "podName": {
                     "type": "string"
                 },
-                "httpHealthCheck": {
-                    "$ref": "#/definitions/k8s.httpHealthCheck"
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "k8s.PodExecResult": {
+            "type": "object",
+            "properties": {
+                "containerName": {
+                    "type": "string"
                 },
-                "image": {
+                "duration": {
+                    "description": "执行时间, 单位毫秒",
+                    "type": "integer"
+                },
+                "error": {
                     "type": "string"
                 },
-                "imagePullSecret": {
+                "exitCode": {
+                    "type": "integer"
+                },
+                "podName": {
                     "type": "string"
                 },
-                "label": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
+                "stderr": {
+                    "type": "string"
                 },
-                "memory": {
+                "stdout": {
                     "type": "string"
                 },
-                "name": {
+                "truncated": {
+                    "description": "输出超过大小上限, 超过的部分被丢弃",
+                    "type": "boolean"
+                }
+            }
+        },
+        "k8s.PodOperationResult": {
+            "type": "object",
+            "properties": {
+                "error": {
                     "type": "string"
                 },
-                "namespace": {
+                "podName": {
                     "type": "string"
                 },
-                "replicas": {
-                    "type": "integer"
+                "status": {
+                    "description": "操作前的状态",
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "dto.K8sIngressSimpleCreate": {
+        "k8s.TemplateParameter": {
             "type": "object",
             "required": [
                 "name",
-                "namespace",
-                "rule"
+                "type"
             ],
+            "properties": {
+                "default": {
+                    "type": "any"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "string",
+                        "int",
+                        "bool",
+                        "image",
+                        "domain",
+                        "cpu",
+                        "memory"
+                    ]
+                }
+            }
+        },
+        "k8s.TlsClientConfig": {
+            "type": "object",
+            "properties": {
+                "caData": {
+                    "type": "string"
+                },
+                "certData": {
+                    "type": "string"
+                },
+                "insecure": {
+                    "type": "boolean"
+                },
+                "keyData": {
+                    "type": "string"
+                }
+            }
+        },
+        "k8s.applicationIngress": {
+            "type": "object",
             "properties": {
                 "annotations": {
                     "type": "object",
@@ -3027,17 +8534,21 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
-                "ingressClassName": {
-                    "type": "string"
+                "hosts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "name": {
+                "ingressClassName": {
                     "type": "string"
                 },
-                "namespace": {
+                "path": {
                     "type": "string"
                 },
-                "rule": {
-                    "$ref": "#/definitions/k8s.ruleConfig"
+                "servicePort": {
+                    "description": "默认第一个容器端口",
+                    "type": "integer"
                 },
                 "tls": {
                     "type": "array",
@@ -3047,274 +8558,431 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.K8sSecretCreate": {
+        "k8s.container": {
             "type": "object",
             "required": [
-                "data",
-                "name",
-                "namespace"
+                "image",
+                "name"
             ],
             "properties": {
-                "data": {
-                    "type": "object",
-                    "additionalProperties": {
+                "args": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "command": {
+                    "type": "array",
+                    "items": {
                         "type": "string"
                     }
                 },
+                "env": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.envVar"
+                    }
+                },
+                "envFrom": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.envFrom"
+                    }
+                },
+                "image": {
+                    "type": "string"
+                },
+                "imagePullPolicy": {
+                    "type": "string",
+                    "enum": [
+                        "Always",
+                        "IfNotPresent",
+                        "Never"
+                    ]
+                },
+                "livenessProbe": {
+                    "$ref": "#/definitions/k8s.probe"
+                },
                 "name": {
                     "type": "string"
                 },
-                "namespace": {
+                "ports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.containerPort"
+                    }
+                },
+                "readinessProbe": {
+                    "$ref": "#/definitions/k8s.probe"
+                },
+                "resources": {
+                    "$ref": "#/definitions/k8s.resources"
+                },
+                "startupProbe": {
+                    "$ref": "#/definitions/k8s.probe"
+                },
+                "volumeMounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.volumeMount"
+                    }
+                },
+                "workingDir": {
                     "type": "string"
                 }
             }
         },
-        "dto.K8sSecretForDockerRegistryCreate": {
+        "k8s.containerPort": {
             "type": "object",
             "required": [
-                "auths",
-                "name",
-                "namespace"
+                "port"
             ],
             "properties": {
-                "auths": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/k8s.Auth"
-                    }
-                },
                 "name": {
                     "type": "string"
                 },
-                "namespace": {
+                "port": {
+                    "type": "integer",
+                    "maximum": 65535,
+                    "minimum": 1
+                },
+                "protocol": {
+                    "type": "string",
+                    "enum": [
+                        "TCP",
+                        "UDP",
+                        "SCTP"
+                    ]
+                }
+            }
+        },
+        "k8s.envFrom": {
+            "type": "object",
+            "properties": {
+                "configMap": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "secret": {
                     "type": "string"
                 }
             }
         },
-        "dto.K8sSecretForTlsCreate": {
+        "k8s.envVar": {
             "type": "object",
             "required": [
-                "certificate",
-                "name",
-                "namespace",
-                "privateKey"
+                "name"
             ],
             "properties": {
-                "certificate": {
-                    "type": "string"
+                "configMapKeyRef": {
+                    "$ref": "#/definitions/k8s.keySelector"
                 },
                 "name": {
                     "type": "string"
                 },
-                "namespace": {
-                    "type": "string"
+                "secretKeyRef": {
+                    "$ref": "#/definitions/k8s.keySelector"
                 },
-                "privateKey": {
+                "value": {
                     "type": "string"
                 }
             }
         },
-        "dto.K8sSvcSimpleCreate": {
+        "k8s.httpHealthCheck": {
             "type": "object",
             "required": [
-                "name",
-                "namespace"
+                "httpHealthPath"
             ],
             "properties": {
-                "deploymentName": {
-                    "type": "string"
-                },
-                "labels": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                },
-                "name": {
+                "httpHealthPath": {
                     "type": "string"
                 },
-                "namespace": {
+                "httpHealthPort": {
+                    "description": "默认使用容器的0号端口",
                     "type": "string"
-                },
-                "ports": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/k8s.ports"
-                    }
-                },
-                "selector": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
                 }
             }
         },
-        "dto.SystemAdd": {
+        "k8s.httpRouteBackend": {
             "type": "object",
             "required": [
-                "nickname",
-                "password",
-                "username"
+                "port",
+                "service"
             ],
             "properties": {
-                "avatar": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "mobile": {
+                "namespace": {
                     "type": "string"
                 },
-                "nickname": {
-                    "type": "string"
+                "port": {
+                    "type": "integer",
+                    "maximum": 65535
                 },
-                "password": {
+                "service": {
                     "type": "string"
                 },
-                "roles": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
+                "weight": {
+                    "type": "integer",
+                    "maximum": 1000000,
+                    "minimum": 0
+                }
+            }
+        },
+        "k8s.httpRouteMatch": {
+            "type": "object",
+            "properties": {
+                "headers": {
+                    "description": "请求头精确匹配",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
                     }
                 },
-                "username": {
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "GET",
+                        "HEAD",
+                        "POST",
+                        "PUT",
+                        "DELETE",
+                        "CONNECT",
+                        "OPTIONS",
+                        "TRACE",
+                        "PATCH"
+                    ]
+                },
+                "path": {
                     "type": "string"
+                },
+                "pathType": {
+                    "type": "string",
+                    "enum": [
+                        "PathPrefix",
+                        "Exact",
+                        "RegularExpression"
+                    ]
                 }
             }
         },
-        "dto.SystemLogin": {
+        "k8s.httpRouteParent": {
             "type": "object",
             "required": [
-                "password",
-                "username"
+                "name"
             ],
             "properties": {
-                "password": {
+                "name": {
                     "type": "string"
                 },
-                "username": {
+                "namespace": {
+                    "type": "string"
+                },
+                "sectionName": {
                     "type": "string"
                 }
             }
         },
-        "httputil.PageResp": {
+        "k8s.httpRouteRule": {
             "type": "object",
+            "required": [
+                "backends"
+            ],
             "properties": {
-                "items": {},
-                "limit": {
-                    "type": "integer"
-                },
-                "page": {
-                    "type": "integer"
+                "backends": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/k8s.httpRouteBackend"
+                    }
                 },
-                "total": {
-                    "type": "integer"
+                "matches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/k8s.httpRouteMatch"
+                    }
                 }
             }
         },
-        "httputil.PageResponseBody": {
+        "k8s.ingressBackend": {
             "type": "object",
+            "required": [
+                "service"
+            ],
             "properties": {
-                "data": {
-                    "$ref": "#/definitions/httputil.PageResp"
-                },
-                "msg": {
+                "service": {
                     "type": "string"
                 },
-                "status": {
+                "servicePort": {
+                    "type": "integer",
+                    "maximum": 65535
+                },
+                "servicePortName": {
                     "type": "string"
                 }
             }
         },
-        "httputil.ResponseBody": {
+        "k8s.ingressPath": {
             "type": "object",
+            "required": [
+                "service"
+            ],
             "properties": {
-                "data": {},
-                "msg": {
+                "path": {
                     "type": "string"
                 },
-                "status": {
+                "pathType": {
+                    "type": "string",
+                    "enum": [
+                        "Prefix",
+                        "Exact",
+                        "ImplementationSpecific"
+                    ]
+                },
+                "service": {
+                    "type": "string"
+                },
+                "servicePort": {
+                    "type": "integer",
+                    "maximum": 65535
+                },
+                "servicePortName": {
                     "type": "string"
                 }
             }
         },
-        "k8s.Auth": {
+        "k8s.ingressRule": {
             "type": "object",
             "required": [
-                "password",
-                "user"
+                "paths"
             ],
             "properties": {
-                "password": {
+                "host": {
                     "type": "string"
                 },
-                "user": {
-                    "type": "string"
+                "paths": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/k8s.ingressPath"
+                    }
                 }
             }
         },
-        "k8s.TlsClientConfig": {
+        "k8s.keySelector": {
             "type": "object",
+            "required": [
+                "key",
+                "name"
+            ],
             "properties": {
-                "CAData": {
+                "key": {
                     "type": "string"
                 },
-                "certData": {
+                "name": {
                     "type": "string"
                 },
-                "insecure": {
+                "optional": {
                     "type": "boolean"
-                },
-                "keyData": {
-                    "type": "string"
                 }
             }
         },
-        "k8s.containerPort": {
+        "k8s.ports": {
             "type": "object",
             "properties": {
+                "containerPort": {
+                    "description": "Service端口",
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
-                "port": {
+                "nodePort": {
+                    "description": "NodePort和LoadBalancer类型固定的节点端口, 为0时自动分配, 更新时保留已分配的端口",
                     "type": "integer"
                 },
                 "protocol": {
                     "type": "string"
+                },
+                "targetPort": {
+                    "description": "容器端口号或端口名称, 为空时与containerPort相同",
+                    "type": "string"
                 }
             }
         },
-        "k8s.httpHealthCheck": {
+        "k8s.probe": {
             "type": "object",
             "required": [
-                "httpHealthPath"
+                "type"
             ],
             "properties": {
-                "httpHealthPath": {
+                "command": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failureThreshold": {
+                    "type": "integer"
+                },
+                "initialDelaySeconds": {
+                    "type": "integer"
+                },
+                "path": {
                     "type": "string"
                 },
-                "httpHealthPort": {
+                "periodSeconds": {
+                    "type": "integer"
+                },
+                "port": {
                     "description": "默认使用容器的0号端口",
                     "type": "string"
+                },
+                "scheme": {
+                    "type": "string",
+                    "enum": [
+                        "HTTP",
+                        "HTTPS"
+                    ]
+                },
+                "successThreshold": {
+                    "type": "integer"
+                },
+                "timeoutSeconds": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "http",
+                        "tcp",
+                        "exec"
+                    ]
                 }
             }
         },
-        "k8s.ports": {
+        "k8s.resourceList": {
             "type": "object",
             "properties": {
-                "containerPort": {
-                    "type": "integer"
-                },
-                "name": {
+                "cpu": {
                     "type": "string"
                 },
-                "protocol": {
+                "memory": {
                     "type": "string"
                 }
             }
         },
+        "k8s.resources": {
+            "type": "object",
+            "properties": {
+                "limits": {
+                    "$ref": "#/definitions/k8s.resourceList"
+                },
+                "requests": {
+                    "$ref": "#/definitions/k8s.resourceList"
+                }
+            }
+        },
         "k8s.ruleConfig": {
             "type": "object",
             "required": [
@@ -3338,6 +9006,26 @@ const docTemplate = `{
                 }
             }
         },
+        "k8s.strategy": {
+            "type": "object",
+            "properties": {
+                "maxSurge": {
+                    "description": "默认20%",
+                    "type": "string"
+                },
+                "maxUnavailable": {
+                    "description": "默认20%",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "RollingUpdate",
+                        "Recreate"
+                    ]
+                }
+            }
+        },
         "k8s.tlsConfig": {
             "type": "object",
             "properties": {
@@ -3351,25 +9039,104 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
-        }
-    },
-    "securityDefinitions": {
-        "ApiKeyAuth": {
-            "type": "apiKey",
-            "name": "Authorization",
-            "in": "header"
+        },
+        "k8s.toleration": {
+            "type": "object",
+            "properties": {
+                "effect": {
+                    "type": "string",
+                    "enum": [
+                        "NoSchedule",
+                        "PreferNoSchedule",
+                        "NoExecute"
+                    ]
+                },
+                "key": {
+                    "type": "string"
+                },
+                "operator": {
+                    "type": "string",
+                    "enum": [
+                        "Exists",
+                        "Equal"
+                    ]
+                },
+                "tolerationSeconds": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "k8s.volume": {
+            "type": "object",
+            "required": [
+                "name",
+                "type"
+            ],
+            "properties": {
+                "medium": {
+                    "description": "emptyDir, 可选 Memory",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "readOnly": {
+                    "description": "pvc",
+                    "type": "boolean"
+                },
+                "sizeLimit": {
+                    "description": "emptyDir",
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "configMap",
+                        "secret",
+                        "pvc",
+                        "emptyDir"
+                    ]
+                }
+            }
+        },
+        "k8s.volumeMount": {
+            "type": "object",
+            "required": [
+                "mountPath",
+                "name"
+            ],
+            "properties": {
+                "mountPath": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "readOnly": {
+                    "type": "boolean"
+                },
+                "subPath": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
-	Version:          "v0.1",
-	Host:             "localhost:8080",
+	Version:          "",
+	Host:             "",
 	BasePath:         "",
 	Schemes:          []string{},
-	Title:            "HandoverCloud Backend Server",
-	Description:      "This is a Kubernetes-focused operations platform that includes CI/CD capabilities, gateway management, and service management features.",
+	Title:            "",
+	Description:      "",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 }
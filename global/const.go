@@ -2,4 +2,8 @@ package global
 
 const (
 	K8sManager = "HandoverCloud"
+	// K8sAppLabel 标记资源所属的应用
+	K8sAppLabel = "handovercloud.soulchild.cn/app"
+	// K8sNodeDebugLabel 标记平台创建的节点调试Pod, 服务异常退出后据此清理残留的Pod
+	K8sNodeDebugLabel = "handovercloud.soulchild.cn/node-debug"
 )
@@ -37,4 +37,34 @@ func setDevDefaultParams(v *viper.Viper) {
 	// jwt 配置
 	v.SetDefault("jwt.secret", []byte("soul"))
 	v.SetDefault("jwt.ttl", "43200s") // 单位秒, 默认12小时
+
+	// helm 配置
+	v.SetDefault("helm.chartDir", "./charts")
+	v.SetDefault("helm.maxChartSize", 20)
+
+	// 终端配置
+	v.SetDefault("terminal.recordDir", "./recordings")
+	v.SetDefault("terminal.maxSessionsPerUser", 5)
+	v.SetDefault("terminal.idleTimeout", "30m")
+	v.SetDefault("terminal.maxDuration", "8h")
+	v.SetDefault("terminal.warnBefore", "1m")
+
+	// 容器文件传输配置
+	v.SetDefault("podFile.maxUploadSize", 100)
+	v.SetDefault("podFile.maxDownloadSize", 1024)
+
+	// 端口转发配置
+	v.SetDefault("portForward.idleTimeout", "10m")
+	v.SetDefault("portForward.maxDuration", "1h")
+
+	// 调试容器配置
+	v.SetDefault("debug.image", "busybox:1.36")
+	v.SetDefault("debug.nodeNamespace", "default")
+	v.SetDefault("debug.startTimeout", "2m")
+
+	// 非交互命令执行配置
+	v.SetDefault("exec.defaultTimeout", "30s")
+	v.SetDefault("exec.maxTimeout", "10m")
+	v.SetDefault("exec.maxOutputSize", 1024)
+	v.SetDefault("exec.concurrency", 10)
 }
@@ -0,0 +1,201 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"github.com/robfig/cron/v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"soul/apis/dao"
+	"soul/apis/service/k8s/deployment"
+	"soul/global"
+	log "soul/internal/logger"
+	"soul/model"
+	"sync"
+)
+
+const (
+	actionScale   = "scale"
+	actionRestore = "restore"
+)
+
+var runner = struct {
+	sync.Mutex
+	cron    *cron.Cron
+	entries map[uint][]cron.EntryID
+}{
+	cron:    cron.New(),
+	entries: map[uint][]cron.EntryID{},
+}
+
+// InitScheduler 加载所有启用的策略并启动调度器
+func InitScheduler() {
+	for _, policy := range dao.K8sScalePolicy.ListScalePolicy("") {
+		if !policy.Enabled {
+			continue
+		}
+		if err := SchedulePolicy(policy); err != nil {
+			log.Error("加载扩缩容策略%s失败: %s", policy.Name, err.Error())
+		}
+	}
+	runner.cron.Start()
+}
+
+// SchedulePolicy 注册策略的定时任务, 已注册的会先移除
+func SchedulePolicy(policy model.K8sScalePolicy) error {
+	UnschedulePolicy(policy.ID.ID)
+	if !policy.Enabled {
+		return nil
+	}
+
+	runner.Lock()
+	defer runner.Unlock()
+
+	policyId := policy.ID.ID
+	scaleId, err := runner.cron.AddFunc(policy.ScaleSchedule, func() { runScale(policyId) })
+	if err != nil {
+		return err
+	}
+	entries := []cron.EntryID{scaleId}
+
+	if policy.RestoreSchedule != "" {
+		restoreId, err := runner.cron.AddFunc(policy.RestoreSchedule, func() { runRestore(policyId) })
+		if err != nil {
+			runner.cron.Remove(scaleId)
+			return err
+		}
+		entries = append(entries, restoreId)
+	}
+
+	runner.entries[policyId] = entries
+	return nil
+}
+
+// UnschedulePolicy 移除策略的定时任务
+func UnschedulePolicy(policyId uint) {
+	runner.Lock()
+	defer runner.Unlock()
+
+	for _, id := range runner.entries[policyId] {
+		runner.cron.Remove(id)
+	}
+	delete(runner.entries, policyId)
+}
+
+// targetDeployments 获取策略作用的Deployment及当前副本数
+func targetDeployments(policy *model.K8sScalePolicy) (map[string]int32, error) {
+	if global.K8s.Get(policy.ClusterName) == nil {
+		return nil, fmt.Errorf("集群%s不存在", policy.ClusterName)
+	}
+
+	deployments := global.K8s.Use(policy.ClusterName).ClientSet.AppsV1().Deployments(policy.Namespace)
+	targets := map[string]int32{}
+
+	if policy.DeploymentName != "" {
+		scale, err := deployments.GetScale(context.TODO(), policy.DeploymentName, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		targets[policy.DeploymentName] = scale.Spec.Replicas
+		return targets, nil
+	}
+
+	list, err := deployments.List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range list.Items {
+		var replicas int32 = 1
+		if item.Spec.Replicas != nil {
+			replicas = *item.Spec.Replicas
+		}
+		targets[item.Name] = replicas
+	}
+	return targets, nil
+}
+
+// runScale 缩容, 只在没有记录时保存原始副本数, 避免重复执行时覆盖
+func runScale(policyId uint) {
+	policy := dao.K8sScalePolicy.GetScalePolicyById(policyId)
+	if policy == nil || !policy.Enabled {
+		return
+	}
+
+	targets, err := targetDeployments(policy)
+	if err != nil {
+		saveHistory(policy, actionScale, policy.DeploymentName, 0, policy.ScaleReplicas, err)
+		return
+	}
+
+	d := &deployment.Deployment{}
+	for name, replicas := range targets {
+		if dao.K8sScalePolicy.GetScaleRecord(policy.ID.ID, policy.Namespace, name) == nil {
+			err = dao.K8sScalePolicy.CreateScaleRecord(&model.K8sScaleRecord{
+				PolicyID:       policy.ID.ID,
+				Namespace:      policy.Namespace,
+				DeploymentName: name,
+				Replicas:       replicas,
+			})
+			if err != nil {
+				saveHistory(policy, actionScale, name, replicas, policy.ScaleReplicas, err)
+				continue
+			}
+		}
+
+		err = d.ScaleDeployment(policy.ClusterName, name, policy.Namespace, policy.ScaleReplicas)
+		saveHistory(policy, actionScale, name, replicas, policy.ScaleReplicas, err)
+	}
+}
+
+// runRestore 恢复到缩容前记录的副本数
+func runRestore(policyId uint) {
+	policy := dao.K8sScalePolicy.GetScalePolicyById(policyId)
+	if policy == nil || !policy.Enabled {
+		return
+	}
+	_ = RestorePolicy(policy)
+}
+
+// RestorePolicy 将策略缩容过的Deployment恢复到记录的副本数, 恢复成功的记录会被删除.
+// Deployment已不存在时直接删除记录, 返回第一个恢复失败的错误
+func RestorePolicy(policy *model.K8sScalePolicy) error {
+	var firstErr error
+	d := &deployment.Deployment{}
+	for _, record := range dao.K8sScalePolicy.ListScaleRecord(policy.ID.ID) {
+		err := d.ScaleDeployment(policy.ClusterName, record.DeploymentName, record.Namespace, record.Replicas)
+		saveHistory(policy, actionRestore, record.DeploymentName, policy.ScaleReplicas, record.Replicas, err)
+		if err != nil && !apierrors.IsNotFound(err) {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if err = dao.K8sScalePolicy.DeleteScaleRecord(record.ID.ID); err != nil {
+			log.Error(err.Error())
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+func saveHistory(policy *model.K8sScalePolicy, action, deploymentName string, from, to int32, err error) {
+	history := &model.K8sScaleHistory{
+		PolicyID:       policy.ID.ID,
+		Action:         action,
+		ClusterName:    policy.ClusterName,
+		Namespace:      policy.Namespace,
+		DeploymentName: deploymentName,
+		FromReplicas:   from,
+		ToReplicas:     to,
+		Success:        err == nil,
+	}
+	if err != nil {
+		history.Message = err.Error()
+		log.Error("扩缩容策略%s执行%s失败: %s", policy.Name, action, err.Error())
+	}
+	if err = dao.K8sScalePolicy.CreateScaleHistory(history); err != nil {
+		log.Error(err.Error())
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"soul/apis/service"
 	"soul/global"
 	"soul/internal/logger"
 	"soul/middleware"
@@ -38,10 +40,18 @@ func StartServer() {
 	// 注册自定义验证器
 	httputil.RegisterAllValidator()
 
+	// 所有请求的ctx都派生自baseCtx, 停止服务时取消, 让SSE日志流、端口转发代理等长请求及时结束
+	baseCtx, cancelBase := context.WithCancel(context.Background())
+	defer cancelBase()
+
 	srv := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", global.Config.Listen, global.Config.Port),
 		Handler: r,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
 	}
+	srv.RegisterOnShutdown(cancelBase)
 
 	go func() {
 		// 服务连接
@@ -57,6 +67,10 @@ func StartServer() {
 
 	logger.Info("Shutdown Server ...")
 
+	// SockJS终端的websocket连接被劫持, Shutdown不会等待也不会关闭它们; 端口转发的隧道不属于任何请求. 都需要主动关闭
+	service.K8sPod.CloseAllTerminalSessions("服务器正在停止, 会话已关闭")
+	service.K8sPod.CloseAllPortForwards("服务器停止")
+
 	// 创建一个5秒超时的ctx
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
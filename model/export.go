@@ -6,8 +6,18 @@ import (
 )
 
 type (
-	SystemUser = system.User
-	SystemRole = system.Role
-	SystemLock = system.Lock
-	K8sCluster = k8s.Cluster
+	SystemUser            = system.User
+	SystemRole            = system.Role
+	SystemLock            = system.Lock
+	K8sCluster            = k8s.Cluster
+	K8sScalePolicy        = k8s.ScalePolicy
+	K8sScaleRecord        = k8s.ScaleRecord
+	K8sScaleHistory       = k8s.ScaleHistory
+	K8sApplication        = k8s.Application
+	K8sTemplateParameter  = k8s.TemplateParameter
+	K8sAppTemplate        = k8s.AppTemplate
+	K8sAppTemplateVersion = k8s.AppTemplateVersion
+	K8sTemplateInstance   = k8s.TemplateInstance
+	K8sTerminalRecord     = k8s.TerminalRecord
+	K8sPortForwardRecord  = k8s.PortForwardRecord
 )
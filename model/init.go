@@ -10,6 +10,15 @@ func Init(db *gorm.DB) error {
 		&SystemUser{},
 		&SystemLock{},
 		&K8sCluster{},
+		&K8sScalePolicy{},
+		&K8sScaleRecord{},
+		&K8sScaleHistory{},
+		&K8sApplication{},
+		&K8sAppTemplate{},
+		&K8sAppTemplateVersion{},
+		&K8sTemplateInstance{},
+		&K8sTerminalRecord{},
+		&K8sPortForwardRecord{},
 	}
 	err := db.AutoMigrate(MigrateModels...)
 
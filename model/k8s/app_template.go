@@ -0,0 +1,62 @@
+package k8s
+
+import (
+	"soul/model/common"
+	"time"
+)
+
+// TemplateParameter 模板参数定义
+type TemplateParameter struct {
+	Name        string   `json:"name"`
+	Label       string   `json:"label"`
+	Description string   `json:"description"`
+	Type        string   `json:"type"` // string, int, bool, image, domain, cpu, memory
+	Required    bool     `json:"required"`
+	Default     any      `json:"default"`
+	Options     []string `json:"options"` // 仅string类型, 可选值
+}
+
+// AppTemplate 应用模板, Content为渲染后是dto.K8sApplicationCreate格式的json模板(text/template语法)
+type AppTemplate struct {
+	common.ID
+	Name        string              `json:"name" gorm:"size:64;not null;uniqueIndex;comment:模板名称"`
+	Description string              `json:"description" gorm:"size:256;comment:描述"`
+	Version     uint                `json:"version" gorm:"not null;comment:当前版本"`
+	Parameters  []TemplateParameter `json:"parameters" gorm:"type:text;serializer:json;comment:参数定义"`
+	Content     string              `json:"content" gorm:"type:text;not null;comment:模板内容"`
+	common.Timestamps
+}
+
+func (a AppTemplate) TableName() string {
+	return "t_k8s_app_template"
+}
+
+// AppTemplateVersion 模板的历史版本, 创建后不再修改
+type AppTemplateVersion struct {
+	common.ID
+	TemplateID uint                `json:"templateId" gorm:"not null;uniqueIndex:idx_template_version;comment:模板ID"`
+	Version    uint                `json:"version" gorm:"not null;uniqueIndex:idx_template_version;comment:版本"`
+	Parameters []TemplateParameter `json:"parameters" gorm:"type:text;serializer:json;comment:参数定义"`
+	Content    string              `json:"content" gorm:"type:text;not null;comment:模板内容"`
+	CreatedAt  time.Time           `json:"createdAt"`
+}
+
+func (a AppTemplateVersion) TableName() string {
+	return "t_k8s_app_template_version"
+}
+
+// TemplateInstance 记录应用由哪个模板的哪个版本、使用哪些参数渲染而来, 用于升级时重新渲染
+type TemplateInstance struct {
+	common.ID
+	TemplateID      uint           `json:"templateId" gorm:"not null;index;comment:模板ID"`
+	TemplateVersion uint           `json:"templateVersion" gorm:"not null;comment:模板版本"`
+	ClusterName     string         `json:"clusterName" gorm:"size:32;not null;uniqueIndex:idx_instance_cluster_namespace_name;comment:集群名称"`
+	Namespace       string         `json:"namespace" gorm:"size:64;not null;uniqueIndex:idx_instance_cluster_namespace_name;comment:Namespace"`
+	Name            string         `json:"name" gorm:"size:64;not null;uniqueIndex:idx_instance_cluster_namespace_name;comment:应用名称"`
+	Values          map[string]any `json:"values" gorm:"type:text;serializer:json;comment:参数值"`
+	common.Timestamps
+}
+
+func (t TemplateInstance) TableName() string {
+	return "t_k8s_template_instance"
+}
@@ -0,0 +1,20 @@
+package k8s
+
+import "soul/model/common"
+
+// Application 应用, 包含同名的Deployment、Service和Ingress, 资源上带有 handovercloud.soulchild.cn/app 标签
+type Application struct {
+	common.ID
+	Name           string `json:"name" gorm:"size:64;not null;uniqueIndex:idx_cluster_namespace_name;comment:应用名称"`
+	ClusterName    string `json:"clusterName" gorm:"size:32;not null;uniqueIndex:idx_cluster_namespace_name;comment:集群名称"`
+	Namespace      string `json:"namespace" gorm:"size:64;not null;uniqueIndex:idx_cluster_namespace_name;comment:Namespace"`
+	Description    string `json:"description" gorm:"size:256;comment:描述"`
+	DeploymentName string `json:"deploymentName" gorm:"size:64;comment:Deployment名称"`
+	ServiceName    string `json:"serviceName" gorm:"size:64;comment:Service名称"`
+	IngressName    string `json:"ingressName" gorm:"size:64;comment:Ingress名称"`
+	common.Timestamps
+}
+
+func (a Application) TableName() string {
+	return "t_k8s_application"
+}
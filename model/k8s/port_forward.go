@@ -0,0 +1,27 @@
+package k8s
+
+import (
+	"soul/model/common"
+	"time"
+)
+
+// PortForwardRecord 端口转发审计记录, 每个会话一条
+type PortForwardRecord struct {
+	common.ID
+	SessionID   string     `json:"sessionId" gorm:"size:32;not null;uniqueIndex;comment:会话ID"`
+	UserID      uint       `json:"userId" gorm:"index;comment:用户ID"`
+	Username    string     `json:"username" gorm:"size:32;index;comment:用户名"`
+	ClusterName string     `json:"clusterName" gorm:"size:32;not null;index;comment:集群名称"`
+	Namespace   string     `json:"namespace" gorm:"size:64;not null;comment:Namespace"`
+	PodName     string     `json:"podName" gorm:"size:253;not null;comment:Pod名称"`
+	Port        int32      `json:"port" gorm:"not null;comment:Pod端口"`
+	Target      string     `json:"target" gorm:"size:256;comment:请求的目标,如service/name:port"`
+	Requests    int64      `json:"requests" gorm:"comment:转发的请求数"`
+	CloseReason string     `json:"closeReason" gorm:"size:256;comment:关闭原因"`
+	StartedAt   time.Time  `json:"startedAt" gorm:"index;comment:开始时间"`
+	EndedAt     *time.Time `json:"endedAt" gorm:"comment:结束时间,为空代表会话进行中"`
+}
+
+func (p PortForwardRecord) TableName() string {
+	return "t_k8s_port_forward_record"
+}
@@ -0,0 +1,57 @@
+package k8s
+
+import (
+	"soul/model/common"
+	"time"
+)
+
+// ScalePolicy 定时扩缩容策略, DeploymentName为空时作用于Namespace下所有Deployment
+type ScalePolicy struct {
+	common.ID
+	Name            string `json:"name" gorm:"size:64;not null;uniqueIndex;comment:策略名称"`
+	ClusterName     string `json:"clusterName" gorm:"size:32;not null;index;comment:集群名称"`
+	Namespace       string `json:"namespace" gorm:"size:64;not null;comment:Namespace"`
+	DeploymentName  string `json:"deploymentName" gorm:"size:64;comment:Deployment名称,为空代表Namespace下所有Deployment"`
+	ScaleSchedule   string `json:"scaleSchedule" gorm:"size:64;not null;comment:缩容cron表达式"`
+	ScaleReplicas   int32  `json:"scaleReplicas" gorm:"comment:缩容后的副本数"`
+	RestoreSchedule string `json:"restoreSchedule" gorm:"size:64;comment:恢复原副本数的cron表达式"`
+	Enabled         bool   `json:"enabled" gorm:"comment:是否启用"`
+	common.Timestamps
+}
+
+func (s ScalePolicy) TableName() string {
+	return "t_k8s_scale_policy"
+}
+
+// ScaleRecord 缩容前的原始副本数, 恢复后删除
+type ScaleRecord struct {
+	common.ID
+	PolicyID       uint      `json:"policyId" gorm:"not null;uniqueIndex:idx_policy_deployment;comment:策略ID"`
+	Namespace      string    `json:"namespace" gorm:"size:64;not null;uniqueIndex:idx_policy_deployment;comment:Namespace"`
+	DeploymentName string    `json:"deploymentName" gorm:"size:64;not null;uniqueIndex:idx_policy_deployment;comment:Deployment名称"`
+	Replicas       int32     `json:"replicas" gorm:"not null;comment:原始副本数"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+func (s ScaleRecord) TableName() string {
+	return "t_k8s_scale_record"
+}
+
+// ScaleHistory 策略执行记录
+type ScaleHistory struct {
+	common.ID
+	PolicyID       uint      `json:"policyId" gorm:"not null;index;comment:策略ID"`
+	Action         string    `json:"action" gorm:"size:16;not null;comment:scale or restore"`
+	ClusterName    string    `json:"clusterName" gorm:"size:32;not null;comment:集群名称"`
+	Namespace      string    `json:"namespace" gorm:"size:64;not null;comment:Namespace"`
+	DeploymentName string    `json:"deploymentName" gorm:"size:64;comment:Deployment名称"`
+	FromReplicas   int32     `json:"fromReplicas" gorm:"comment:执行前副本数"`
+	ToReplicas     int32     `json:"toReplicas" gorm:"comment:执行后副本数"`
+	Success        bool      `json:"success" gorm:"comment:是否成功"`
+	Message        string    `json:"message" gorm:"size:1024;comment:失败原因"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+func (s ScaleHistory) TableName() string {
+	return "t_k8s_scale_history"
+}
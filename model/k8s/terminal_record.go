@@ -0,0 +1,27 @@
+package k8s
+
+import (
+	"soul/model/common"
+	"time"
+)
+
+// TerminalRecord 终端会话录像, 录像内容以asciicast v2格式保存在磁盘上
+type TerminalRecord struct {
+	common.ID
+	SessionID     string     `json:"sessionId" gorm:"size:32;not null;uniqueIndex;comment:终端会话ID"`
+	UserID        uint       `json:"userId" gorm:"index;comment:用户ID"`
+	Username      string     `json:"username" gorm:"size:32;index;comment:用户名"`
+	ClusterName   string     `json:"clusterName" gorm:"size:32;not null;index;comment:集群名称"`
+	Namespace     string     `json:"namespace" gorm:"size:64;not null;comment:Namespace"`
+	PodName       string     `json:"podName" gorm:"size:253;not null;comment:Pod名称"`
+	ContainerName string     `json:"containerName" gorm:"size:64;comment:容器名称"`
+	Shell         string     `json:"shell" gorm:"size:32;comment:请求的shell"`
+	FilePath      string     `json:"-" gorm:"size:512;not null;comment:录像文件路径"`
+	Size          int64      `json:"size" gorm:"comment:录像文件大小"`
+	StartedAt     time.Time  `json:"startedAt" gorm:"index;comment:开始时间"`
+	EndedAt       *time.Time `json:"endedAt" gorm:"comment:结束时间,为空代表会话进行中"`
+}
+
+func (t TerminalRecord) TableName() string {
+	return "t_k8s_terminal_record"
+}
@@ -2,14 +2,21 @@ package k8s
 
 import (
 	"github.com/gin-gonic/gin"
+	k8sapplication "soul/apis/controller/k8s/application"
+	k8sapptemplate "soul/apis/controller/k8s/apptemplate"
 	k8scluster "soul/apis/controller/k8s/cluster"
 	k8sdeployment "soul/apis/controller/k8s/deployment"
+	k8shelm "soul/apis/controller/k8s/helm"
+	k8sgateway "soul/apis/controller/k8s/gateway"
 	k8singress "soul/apis/controller/k8s/ingress"
 	k8snamespace "soul/apis/controller/k8s/namespace"
+	k8snode "soul/apis/controller/k8s/node"
 	k8spod "soul/apis/controller/k8s/pod"
 	k8sprometheus "soul/apis/controller/k8s/prometheus"
+	k8sscalepolicy "soul/apis/controller/k8s/scalepolicy"
 	k8ssecret "soul/apis/controller/k8s/secret"
 	k8ssvc "soul/apis/controller/k8s/svc"
+	k8sterminalrecord "soul/apis/controller/k8s/terminalrecord"
 	"soul/middleware"
 )
 
@@ -26,6 +33,41 @@ func RegisterRoute(r *gin.RouterGroup) {
 		clusterResource.DELETE("/:clusterName", k8scluster.DeleteCluster)
 	}
 
+	appTemplate := r.Group("/apptemplate")
+	{
+		appTemplate.GET("/", k8sapptemplate.GetAppTemplateList)
+		appTemplate.GET("/:id", k8sapptemplate.GetAppTemplateById)
+		appTemplate.GET("/:id/versions", k8sapptemplate.GetAppTemplateVersions)
+		appTemplate.POST("/", k8sapptemplate.CreateAppTemplate)
+		appTemplate.PUT("/:id", k8sapptemplate.UpdateAppTemplate)
+		appTemplate.DELETE("/:id", k8sapptemplate.DeleteAppTemplate)
+	}
+
+	helmChart := r.Group("/helmchart")
+	{
+		helmChart.GET("/", k8shelm.GetChartList)
+	}
+
+	terminal := r.Group("/terminal")
+	{
+		terminal.GET("/", k8spod.GetTerminalSessionList)
+		terminal.DELETE("/:sessionId", k8spod.KillTerminalSession)
+	}
+
+	portForward := r.Group("/portforward")
+	{
+		portForward.GET("/", k8spod.GetPortForwardSessionList)
+		portForward.GET("/record", k8spod.GetPortForwardRecordList)
+	}
+
+	terminalRecord := r.Group("/terminalrecord")
+	{
+		terminalRecord.GET("/", k8sterminalrecord.GetTerminalRecordList)
+		terminalRecord.GET("/:id", k8sterminalrecord.GetTerminalRecordById)
+		terminalRecord.GET("/:id/cast", k8sterminalrecord.ReplayTerminalRecord)
+		terminalRecord.DELETE("/:id", k8sterminalrecord.DeleteTerminalRecord)
+	}
+
 	cluster := r.Group("/:clusterName")
 	cluster.Use(middleware.ClusterExists)
 	pod := cluster.Group("/pod")
@@ -34,9 +76,35 @@ func RegisterRoute(r *gin.RouterGroup) {
 		pod.GET("/:namespace", k8spod.GetPodList)
 		pod.GET("/:namespace/:podName", k8spod.GetPodByName)
 		pod.DELETE("/:namespace/:podName", k8spod.DeletePodByName)
+		pod.GET("/:namespace/:podName/diagnose", k8spod.DiagnosePod)
+		pod.POST("/:namespace/:podName/evict", k8spod.EvictPod)
+		pod.POST("/:namespace/bulk", k8spod.BulkPodOperation)
 		pod.GET("/:namespace/:podName/log", k8spod.GetPodLog)
+		pod.GET("/:namespace/:podName/log/stream", k8spod.StreamPodLog)
+		pod.GET("/:namespace/:podName/log/download", k8spod.DownloadPodLog)
 		pod.GET("/:namespace/:podName/containers", k8spod.GetPodContainers)
 		pod.GET("/:namespace/:podName/shell", k8spod.ExecContainer)
+		pod.POST("/:namespace/:podName/exec", k8spod.ExecPodCommand)
+		pod.POST("/:namespace/:podName/debug", k8spod.DebugContainer)
+		pod.GET("/:namespace/:podName/files", k8spod.GetPodFileList)
+		pod.GET("/:namespace/:podName/files/download", k8spod.DownloadPodFile)
+		pod.PUT("/:namespace/:podName/files/upload", k8spod.UploadPodFile)
+		pod.POST("/:namespace/:podName/portforward/:port", k8spod.StartPortForward)
+		pod.DELETE("/:namespace/:podName/portforward/:port", k8spod.StopPortForward)
+		pod.Any("/:namespace/:podName/portforward/:port/*path", k8spod.ProxyPortForward)
+	}
+
+	podLog := cluster.Group("/log")
+	{
+		podLog.GET("/:namespace", k8spod.StreamAggregatedLog)
+		podLog.GET("/:namespace/bundle", k8spod.DownloadDiagnosticBundle)
+	}
+
+	node := cluster.Group("/node")
+	{
+		node.GET("/", k8snode.GetNodeList)
+		node.GET("/:nodeName", k8snode.GetNodeByName)
+		node.POST("/:nodeName/debug", k8spod.DebugNode)
 	}
 
 	deployment := cluster.Group("/deployment")
@@ -50,16 +118,21 @@ func RegisterRoute(r *gin.RouterGroup) {
 		deployment.PUT("/:namespace/:deploymentName/scale", k8sdeployment.ScaleDeployment)
 		deployment.PUT("/:namespace/:deploymentName/restart", k8sdeployment.RestartDeployment)
 		deployment.GET("/:namespace/:deploymentName/pods", k8sdeployment.GetDeploymentPods)
+		deployment.POST("/:namespace/:deploymentName/promote", k8sdeployment.PromoteDeployment)
+		deployment.POST("/:namespace/:deploymentName/exec", k8sdeployment.ExecDeploymentCommand)
+		deployment.GET("/:namespace/:deploymentName/diagnose", k8sdeployment.DiagnoseDeployment)
 		deployment.POST("/", k8sdeployment.CreateDeployment)
 	}
 
 	ingress := cluster.Group("/ingress")
 	{
 		ingress.GET("/", k8singress.GetIngressList)
+		ingress.GET("/lint", k8singress.LintIngresses)
 		ingress.GET("/:namespace", k8singress.GetIngressList)
 		ingress.GET("/:namespace/:ingressName", k8singress.GetIngressByName)
 		ingress.DELETE("/:namespace/:ingressName", k8singress.DeleteIngressByName)
 		ingress.POST("/", k8singress.CreateSimpleIngress)
+		ingress.POST("/validate", k8singress.ValidateSimpleIngress)
 		ingress.PUT("/", k8singress.UpdateSimpleIngress)
 	}
 
@@ -76,7 +149,9 @@ func RegisterRoute(r *gin.RouterGroup) {
 		svc.GET("/", k8ssvc.GetSvcList)
 		svc.GET("/:namespace", k8ssvc.GetSvcList)
 		svc.GET("/:namespace/:svcName", k8ssvc.GetSvcByName)
+		svc.GET("/:namespace/:svcName/detail", k8ssvc.GetSvcDetail)
 		svc.DELETE("/:namespace/:svcName", k8ssvc.DeleteSvcByName)
+		svc.POST("/:namespace/:svcName/portforward/:port", k8ssvc.StartSvcPortForward)
 		svc.POST("/", k8ssvc.CreateSimpleSvc)
 		svc.PUT("/", k8ssvc.UpdateSimpleSvc)
 	}
@@ -96,11 +171,57 @@ func RegisterRoute(r *gin.RouterGroup) {
 		secret.PUT("/_tls", k8ssecret.UpdateSecretForTls)
 	}
 
+	application := cluster.Group("/application")
+	{
+		application.GET("/", k8sapplication.GetApplicationList)
+		application.GET("/:namespace", k8sapplication.GetApplicationList)
+		application.GET("/:namespace/:appName", k8sapplication.GetApplicationByName)
+		application.DELETE("/:namespace/:appName", k8sapplication.DeleteApplication)
+		application.POST("/", k8sapplication.CreateApplication)
+	}
+
+	templateInstance := cluster.Group("/templateinstance")
+	{
+		templateInstance.GET("/", k8sapptemplate.GetTemplateInstanceList)
+		templateInstance.GET("/:namespace", k8sapptemplate.GetTemplateInstanceList)
+		templateInstance.GET("/:namespace/:name", k8sapptemplate.GetTemplateInstance)
+		templateInstance.POST("/", k8sapptemplate.CreateTemplateInstance)
+		templateInstance.PUT("/:namespace/:name", k8sapptemplate.UpgradeTemplateInstance)
+	}
+
+	helm := cluster.Group("/helm")
+	{
+		helm.GET("/", k8shelm.GetReleaseList)
+		helm.GET("/:namespace", k8shelm.GetReleaseList)
+		helm.GET("/:namespace/:releaseName", k8shelm.GetReleaseByName)
+		helm.GET("/:namespace/:releaseName/history", k8shelm.GetReleaseHistory)
+		helm.GET("/:namespace/:releaseName/values", k8shelm.GetReleaseValues)
+		helm.POST("/", k8shelm.InstallRelease)
+		helm.PUT("/:namespace/:releaseName", k8shelm.UpgradeRelease)
+		helm.PUT("/:namespace/:releaseName/rollback", k8shelm.RollbackRelease)
+		helm.DELETE("/:namespace/:releaseName", k8shelm.UninstallRelease)
+	}
+
+	scalePolicy := cluster.Group("/scalepolicy")
+	{
+		scalePolicy.GET("/", k8sscalepolicy.GetScalePolicyList)
+		scalePolicy.GET("/:id", k8sscalepolicy.GetScalePolicyById)
+		scalePolicy.POST("/", k8sscalepolicy.CreateScalePolicy)
+		scalePolicy.PUT("/:id", k8sscalepolicy.UpdateScalePolicy)
+		scalePolicy.DELETE("/:id", k8sscalepolicy.DeleteScalePolicy)
+		scalePolicy.GET("/:id/history", k8sscalepolicy.GetScaleHistory)
+	}
+
 	prometheus := cluster.Group("/prometheus")
 	{
 		prometheusRouteGroup(prometheus)
 	}
 
+	gateway := cluster.Group("/gateway")
+	{
+		gatewayRouteGroup(gateway)
+	}
+
 }
 
 func prometheusRouteGroup(r *gin.RouterGroup) {
@@ -112,3 +233,39 @@ func prometheusRouteGroup(r *gin.RouterGroup) {
 		servicemonitor.DELETE("/:namespace/:name", k8sprometheus.DeleteServiceMonitorByName)
 	}
 }
+
+func gatewayRouteGroup(r *gin.RouterGroup) {
+	gatewayClass := r.Group("/gatewayclass")
+	{
+		gatewayClass.GET("/", k8sgateway.GetGatewayClassList)
+		gatewayClass.GET("/:name", k8sgateway.GetGatewayClassByName)
+		gatewayClass.DELETE("/:name", k8sgateway.DeleteGatewayClassByName)
+	}
+
+	gateway := r.Group("/gateway")
+	{
+		gateway.GET("/", k8sgateway.GetGatewayList)
+		gateway.GET("/:namespace", k8sgateway.GetGatewayList)
+		gateway.GET("/:namespace/:name", k8sgateway.GetGatewayByName)
+		gateway.DELETE("/:namespace/:name", k8sgateway.DeleteGatewayByName)
+	}
+
+	httpRoute := r.Group("/httproute")
+	{
+		httpRoute.GET("/", k8sgateway.GetHTTPRouteList)
+		httpRoute.GET("/:namespace", k8sgateway.GetHTTPRouteList)
+		httpRoute.GET("/:namespace/:name", k8sgateway.GetHTTPRouteByName)
+		httpRoute.GET("/:namespace/:name/status", k8sgateway.GetHTTPRouteStatus)
+		httpRoute.DELETE("/:namespace/:name", k8sgateway.DeleteHTTPRouteByName)
+		httpRoute.POST("/", k8sgateway.CreateSimpleHTTPRoute)
+		httpRoute.PUT("/", k8sgateway.UpdateSimpleHTTPRoute)
+	}
+
+	referenceGrant := r.Group("/referencegrant")
+	{
+		referenceGrant.GET("/", k8sgateway.GetReferenceGrantList)
+		referenceGrant.GET("/:namespace", k8sgateway.GetReferenceGrantList)
+		referenceGrant.GET("/:namespace/:name", k8sgateway.GetReferenceGrantByName)
+		referenceGrant.DELETE("/:namespace/:name", k8sgateway.DeleteReferenceGrantByName)
+	}
+}
@@ -0,0 +1,283 @@
+package diffutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sigs.k8s.io/yaml"
+	"sort"
+	"strings"
+)
+
+const contextLines = 3
+
+// Change 结构化的字段变更, Path形如 spec.template.spec.containers[0].image
+type Change struct {
+	Path string `json:"path"`
+	Type string `json:"type"` // add, remove, replace
+	Old  any    `json:"old,omitempty"`
+	New  any    `json:"new,omitempty"`
+}
+
+// Result 对象对比结果
+type Result struct {
+	Changes []Change `json:"changes"`
+	Unified string   `json:"unified"`
+}
+
+// Diff 对比两个对象, 返回结构化的字段变更和yaml格式的unified diff。live为nil时代表对象不存在
+func Diff(name string, live, desired any) (*Result, error) {
+	liveMap, err := toMap(live)
+	if err != nil {
+		return nil, err
+	}
+	desiredMap, err := toMap(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Changes: []Change{}}
+	var before, after any
+	if liveMap != nil {
+		before = liveMap
+	}
+	if desiredMap != nil {
+		after = desiredMap
+	}
+	compare("", before, after, &result.Changes)
+
+	liveYaml, err := toYaml(liveMap)
+	if err != nil {
+		return nil, err
+	}
+	desiredYaml, err := toYaml(desiredMap)
+	if err != nil {
+		return nil, err
+	}
+	result.Unified = Unified("live/"+name, "desired/"+name, liveYaml, desiredYaml)
+
+	return result, nil
+}
+
+func toMap(obj any) (map[string]any, error) {
+	if obj == nil || reflect.ValueOf(obj).Kind() == reflect.Ptr && reflect.ValueOf(obj).IsNil() {
+		return nil, nil
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err = json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func toYaml(m map[string]any) (string, error) {
+	if m == nil {
+		return "", nil
+	}
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func compare(path string, before, after any, changes *[]Change) {
+	switch {
+	case before == nil && after == nil:
+		return
+	case before == nil:
+		*changes = append(*changes, Change{Path: path, Type: "add", New: after})
+		return
+	case after == nil:
+		*changes = append(*changes, Change{Path: path, Type: "remove", Old: before})
+		return
+	}
+
+	switch beforeValue := before.(type) {
+	case map[string]any:
+		afterValue, ok := after.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := map[string]struct{}{}
+		for k := range beforeValue {
+			keys[k] = struct{}{}
+		}
+		for k := range afterValue {
+			keys[k] = struct{}{}
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for k := range keys {
+			sortedKeys = append(sortedKeys, k)
+		}
+		sort.Strings(sortedKeys)
+		for _, k := range sortedKeys {
+			compare(joinPath(path, k), beforeValue[k], afterValue[k], changes)
+		}
+		return
+	case []any:
+		afterValue, ok := after.([]any)
+		if !ok {
+			break
+		}
+		for i := 0; i < len(beforeValue) || i < len(afterValue); i++ {
+			var o, n any
+			if i < len(beforeValue) {
+				o = beforeValue[i]
+			}
+			if i < len(afterValue) {
+				n = afterValue[i]
+			}
+			compare(fmt.Sprintf("%s[%d]", path, i), o, n, changes)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(before, after) {
+		*changes = append(*changes, Change{Path: path, Type: "replace", Old: before, New: after})
+	}
+}
+
+// Unified 生成两段文本的unified diff
+func Unified(oldName, newName, oldText, newText string) string {
+	oldLines := splitLines(oldText)
+	newLines := splitLines(newText)
+
+	ops := diffLines(oldLines, newLines)
+
+	// 没有变更
+	changed := false
+	for _, op := range ops {
+		if op.kind != ' ' {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "--- %s\n+++ %s\n", oldName, newName)
+
+	for start := 0; start < len(ops); {
+		// 找到下一处变更
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start >= len(ops) {
+			break
+		}
+
+		// hunk范围: 向前后各扩展contextLines行, 两处变更间隔较近时合并为一个hunk
+		begin := start - contextLines
+		if begin < 0 {
+			begin = 0
+		}
+		end := start
+		for end < len(ops) {
+			if ops[end].kind != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(ops) && ops[next].kind == ' ' {
+				next++
+			}
+			if next >= len(ops) || next-end > contextLines*2 {
+				end += contextLines
+				if end > len(ops) {
+					end = len(ops)
+				}
+				break
+			}
+			end = next
+		}
+
+		oldStart, newStart := ops[begin].oldIndex+1, ops[begin].newIndex+1
+		oldCount, newCount := 0, 0
+		for _, op := range ops[begin:end] {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+		fmt.Fprintf(&buf, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		for _, op := range ops[begin:end] {
+			fmt.Fprintf(&buf, "%c%s\n", op.kind, op.text)
+		}
+		start = end
+	}
+
+	return buf.String()
+}
+
+type lineOp struct {
+	kind     byte // ' ', '-', '+'
+	text     string
+	oldIndex int
+	newIndex int
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines 基于最长公共子序列计算行级别的差异
+func diffLines(a, b []string) []lineOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []lineOp
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, lineOp{kind: ' ', text: a[i], oldIndex: i, newIndex: j})
+			i++
+			j++
+		case j < len(b) && (i >= len(a) || lcs[i][j+1] > lcs[i+1][j]):
+			ops = append(ops, lineOp{kind: '+', text: b[j], oldIndex: i, newIndex: j})
+			j++
+		default:
+			ops = append(ops, lineOp{kind: '-', text: a[i], oldIndex: i, newIndex: j})
+			i++
+		}
+	}
+	return ops
+}
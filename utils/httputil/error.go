@@ -33,3 +33,13 @@ func ErrorWithCode(c *gin.Context, code int, msg string) {
 	}
 	c.JSON(code, resp)
 }
+
+// ErrorWithData 返回错误和错误的详细信息, 例如校验未通过的具体问题
+func ErrorWithData(c *gin.Context, data any, msg string) {
+	resp := ResponseBody{
+		Status: "error",
+		Msg:    msg,
+		Data:   data,
+	}
+	c.JSON(http.StatusBadRequest, resp)
+}
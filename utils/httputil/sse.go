@@ -0,0 +1,54 @@
+package httputil
+
+import (
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"time"
+)
+
+const sseHeartbeat = 15 * time.Second
+
+// Event SSE事件
+type Event struct {
+	Name string
+	Data any
+}
+
+// SSE 将events中的事件推送给客户端, 直到events关闭或客户端断开。
+// 写入客户端会阻塞, 生产方应使用容量较小的channel, 使客户端消费慢时上游读取也随之变慢;
+// 客户端断开时 c.Request.Context() 会被取消, 生产方需要据此关闭上游并退出
+func SSE(c *gin.Context, events <-chan Event) {
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no") // 禁止nginx缓存响应
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(sseHeartbeat)
+	defer ticker.Stop()
+
+	ctx := c.Request.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// 心跳, 用于及时发现客户端断开
+			if _, err := io.WriteString(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case event, ok := <-events:
+			if !ok {
+				c.SSEvent("end", "")
+				c.Writer.Flush()
+				return
+			}
+			c.SSEvent(event.Name, event.Data)
+			c.Writer.Flush()
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/robfig/cron/v3"
 	"regexp"
 	"soul/utils"
 	"strings"
@@ -37,6 +38,7 @@ func RegisterAllValidator() {
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("mobile", mobileValidate) // 指定tag名称和处理函数
 		v.RegisterValidation("pem", pemValidate)       // 指定tag名称和处理函数
+		v.RegisterValidation("cron", cronValidate)     // 指定tag名称和处理函数
 	}
 }
 
@@ -88,3 +90,10 @@ func CheckParams(c *gin.Context, params ...string) error {
 	}
 	return nil
 }
+
+// cronValidate 校验标准的5段式cron表达式, 也支持@daily等描述符
+func cronValidate(fl validator.FieldLevel) bool {
+	spec, _ := fl.Field().Interface().(string)
+	_, err := cron.ParseStandard(spec)
+	return err == nil
+}
@@ -22,29 +22,51 @@ func GetTagValue(obj any, fieldName string, tagName string) (string, error) {
 
 func GetTagValueByNamespace(obj any, fieldNamespace string, tagName string) (string, error) {
 	fields := strings.Split(fieldNamespace, ".")
-	value := reflect.ValueOf(obj)
-	if value.Kind() == reflect.Ptr {
-		value = value.Elem()
-	}
+	t := reflect.TypeOf(obj)
 
 	for index, field := range fields {
-		if index == 0 || index == len(fields)-1 {
+		t = indirectType(t)
+		if index == 0 {
+			if strings.Contains(field, "[") {
+				t = indirectType(t.Elem())
+			}
 			continue
 		}
 
-		value = value.FieldByName(field)
-		if value.Kind() == reflect.Ptr {
-			value = value.Elem()
+		// 切片/map字段的命名空间形如 Containers[0]
+		if i := strings.Index(field, "["); i != -1 {
+			field = field[:i]
 		}
-	}
 
-	f, exist := value.Type().FieldByName(fields[len(fields)-1])
-	if !exist {
-		return "", errors.New("字段不存在")
+		if t.Kind() != reflect.Struct {
+			return "", errors.New("字段不存在")
+		}
+		f, exist := t.FieldByName(field)
+		if !exist {
+			return "", errors.New("字段不存在")
+		}
+
+		if index == len(fields)-1 {
+			tag, ok := f.Tag.Lookup(tagName)
+			if !ok {
+				return "", errors.New("tag不存在")
+			}
+			return tag, nil
+		}
+
+		t = f.Type
+		if strings.Contains(fields[index], "[") {
+			t = indirectType(t).Elem()
+		}
 	}
-	tag, ok := f.Tag.Lookup(tagName)
-	if !ok {
-		return "", errors.New("tag不存在")
+
+	return "", errors.New("字段不存在")
+}
+
+// indirectType 获取指针指向的类型
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
 	}
-	return tag, nil
+	return t
 }